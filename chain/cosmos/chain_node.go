@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -384,8 +385,14 @@ func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
 	return uint64(height), nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	_, txs, err := tn.FindBlock(ctx, height)
+	return txs, err
+}
+
+// FindBlock implements blockdb.BlockFinder.
+func (tn *ChainNode) FindBlock(ctx context.Context, height uint64) (blockdb.BlockHeader, []blockdb.Tx, error) {
 	h := int64(height)
 	var eg errgroup.Group
 	var blockRes *coretypes.ResultBlockResults
@@ -399,27 +406,37 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		return err
 	})
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return blockdb.BlockHeader{}, nil, err
 	}
-	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
+	header := blockdb.BlockHeader{
+		Time:     block.Block.Time,
+		Proposer: block.Block.ProposerAddress.String(),
+		AppHash:  block.Block.AppHash.String(),
+		NumTxs:   len(block.Block.Txs),
+	}
+	cfg := tn.Chain.Config()
+	decoder := TxDecoderFor(cfg.Type)
 	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
 	for i, tx := range block.Block.Txs {
 		var newTx blockdb.Tx
-		newTx.Data = []byte(fmt.Sprintf(`{"data":"%s"}`, hex.EncodeToString(tx)))
+		newTx.Hash = fmt.Sprintf("%X", tx.Hash())
 
-		sdkTx, err := decodeTX(interfaceRegistry, tx)
+		decoded, err := decoder.DecodeTx(cfg, tx)
 		if err != nil {
-			tn.logger().Info("Failed to decode tx", zap.Uint64("height", height), zap.Error(err))
-			continue
-		}
-		b, err := encodeTxToJSON(interfaceRegistry, sdkTx)
-		if err != nil {
-			tn.logger().Info("Failed to marshal tx to json", zap.Uint64("height", height), zap.Error(err))
-			continue
+			// Keep the raw bytes, so the tx and its events are still tracked.
+			tn.logger().Info("Failed to decode tx", zap.Uint64("height", height), zap.String("tx_hash", newTx.Hash), zap.Error(err))
+			newTx.Data = []byte(fmt.Sprintf(`{"data":"%s"}`, hex.EncodeToString(tx)))
+			newTx.DecodeError = err.Error()
+		} else {
+			newTx.Data = decoded.JSON
+			newTx.Fee = decoded.Fee
 		}
-		newTx.Data = b
 
 		rTx := blockRes.TxsResults[i]
+		newTx.GasWanted = rTx.GasWanted
+		newTx.GasUsed = rTx.GasUsed
+		newTx.Code = rTx.Code
+		newTx.Codespace = rTx.Codespace
 
 		newTx.Events = make([]blockdb.Event, len(rTx.Events))
 		for j, e := range rTx.Events {
@@ -478,7 +495,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		txs = append(txs, endBlockTx)
 	}
 
-	return txs, nil
+	return header, txs, nil
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
@@ -653,6 +670,25 @@ func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, erro
 	return gen, nil
 }
 
+// ExportHome writes the node's entire home directory to w as a tar stream.
+// The node should be stopped so that its databases are consistent.
+func (tn *ChainNode) ExportHome(ctx context.Context, w io.Writer) error {
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fr.Archive(ctx, tn.VolumeName, w); err != nil {
+		return fmt.Errorf("failed to export home of %s: %w", tn.Name(), err)
+	}
+	return nil
+}
+
+// ImportHome extracts a tar stream written by ExportHome into the node's home directory.
+func (tn *ChainNode) ImportHome(ctx context.Context, r io.Reader) error {
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteArchive(ctx, tn.VolumeName, r); err != nil {
+		return fmt.Errorf("failed to import home of %s: %w", tn.Name(), err)
+	}
+	return nil
+}
+
 // CreateKey creates a key in the keyring backend test for the given node
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
@@ -1409,6 +1445,11 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}, retry.Context(ctx), retry.Attempts(40), retry.Delay(3*time.Second), retry.DelayType(retry.FixedDelay))
 }
 
+// Logs returns the last tail lines of the node container's output, or all of it when tail is 0.
+func (tn *ChainNode) Logs(ctx context.Context, tail int) ([]byte, error) {
+	return tn.containerLifecycle.Logs(ctx, tail)
+}
+
 func (tn *ChainNode) PauseContainer(ctx context.Context) error {
 	for _, s := range tn.Sidecars {
 		if err := s.PauseContainer(ctx); err != nil {
@@ -1576,6 +1617,9 @@ func (tn *ChainNode) logger() *zap.Logger {
 }
 
 // RegisterICA will attempt to register an interchain account on the counterparty chain.
+//
+// Deprecated: RegisterICA requires the intertx example module. Use RegisterInterchainAccount,
+// which uses the ibc-go interchain accounts controller module.
 func (tn *ChainNode) RegisterICA(ctx context.Context, keyName, connectionID string) (string, error) {
 	return tn.ExecTx(ctx, keyName,
 		"intertx", "register",
@@ -1584,6 +1628,9 @@ func (tn *ChainNode) RegisterICA(ctx context.Context, keyName, connectionID stri
 }
 
 // QueryICA will query for an interchain account controlled by the specified address on the counterparty chain.
+//
+// Deprecated: QueryICA requires the intertx example module. Use QueryInterchainAccount,
+// which uses the ibc-go interchain accounts controller module.
 func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string) (string, error) {
 	stdout, _, err := tn.ExecQuery(ctx,
 		"intertx", "interchainaccounts", connectionID, address,
@@ -1604,6 +1651,9 @@ func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string)
 
 // SendICABankTransfer builds a bank transfer message for a specified address and sends it to the specified
 // interchain account.
+//
+// Deprecated: SendICABankTransfer requires the intertx example module. Use SendICATx with a banktypes.MsgSend,
+// which uses the ibc-go interchain accounts controller module.
 func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
 	msg, err := json.Marshal(map[string]any{
 		"@type":        "/cosmos.bank.v1beta1.MsgSend",
@@ -1626,3 +1676,33 @@ func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, from
 	)
 	return err
 }
+
+// RegisterPayee registers a payee address on this chain for the ICS-29 acknowledgement and timeout fees earned by relayerAddr
+// on the given channel. The transaction must be signed by the relayer, so keyName should hold the relayer's key.
+func (tn *ChainNode) RegisterPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, payeeAddr string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"ibc-fee", "register-payee", portID, channelID, relayerAddr, payeeAddr,
+	)
+	return err
+}
+
+// RegisterCounterpartyPayee registers the address on the counterparty chain that receives the ICS-29 receive fees
+// earned by relayerAddr on the given channel. The transaction must be signed by the relayer, so keyName should hold the relayer's key.
+func (tn *ChainNode) RegisterCounterpartyPayee(ctx context.Context, keyName, portID, channelID, relayerAddr, counterpartyPayeeAddr string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"ibc-fee", "register-counterparty-payee", portID, channelID, relayerAddr, counterpartyPayeeAddr,
+	)
+	return err
+}
+
+// PayPacketFee escrows ICS-29 relayer incentives for an already sent packet.
+// Fees are coin strings, e.g. "100stake".
+func (tn *ChainNode) PayPacketFee(ctx context.Context, keyName, portID, channelID string, sequence uint64, recvFee, ackFee, timeoutFee string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"ibc-fee", "pay-packet-fee", portID, channelID, strconv.FormatUint(sequence, 10),
+		"--recv-fee", recvFee,
+		"--ack-fee", ackFee,
+		"--timeout-fee", timeoutFee,
+	)
+	return err
+}
@@ -23,6 +23,7 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 	"github.com/cosmos/ibc-go/modules/capability"
 
+	ica "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts"
 	transfer "github.com/cosmos/ibc-go/v7/modules/apps/transfer"
 	ibccore "github.com/cosmos/ibc-go/v7/modules/core"
 	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
@@ -51,6 +52,7 @@ func DefaultEncoding() testutil.TestEncodingConfig {
 		upgrade.AppModuleBasic{},
 		consensus.AppModuleBasic{},
 		transfer.AppModuleBasic{},
+		ica.AppModuleBasic{},
 		ibccore.AppModuleBasic{},
 		ibctm.AppModuleBasic{},
 		ibcwasm.AppModuleBasic{},
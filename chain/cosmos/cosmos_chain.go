@@ -44,7 +44,9 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -649,6 +651,7 @@ func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, co
 
 func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 	for _, image := range c.Config().Images {
+		ctx, span := tracing.Start(ctx, "pull image", attribute.String("image", image.Repository+":"+image.Version))
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -664,6 +667,7 @@ func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 			_, _ = io.Copy(io.Discard, rc)
 			_ = rc.Close()
 		}
+		tracing.End(span, err)
 	}
 }
 
@@ -1469,7 +1473,7 @@ func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.Packet
 	return ibcTimeouts, nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
 	fn := c.getFullNode()
 	c.findTxMu.Lock()
@@ -1477,6 +1481,14 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *CosmosChain) FindBlock(ctx context.Context, height uint64) (blockdb.BlockHeader, []blockdb.Tx, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
@@ -1580,6 +1592,11 @@ func (c *CosmosChain) StartAllValSidecars(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// VoteOnProposal casts a vote on a governance proposal from keyName.
+func (c *CosmosChain) VoteOnProposal(ctx context.Context, keyName string, proposalID string, vote string) error {
+	return c.getFullNode().VoteOnProposal(ctx, keyName, proposalID, vote)
+}
+
 func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
 	var eg errgroup.Group
 	for _, n := range c.Nodes() {
@@ -0,0 +1,191 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	controllertypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ICAOptions configures the channel opened when registering an interchain account.
+type ICAOptions struct {
+	// Ordering of the interchain account channel. Left unset, the controller chain's default is used,
+	// which is ordered for ibc-go v7. Choosing an ordering requires ibc-go v8.1 or later on the controller chain.
+	Ordering ibc.Order
+
+	// Encoding of the messages carried in interchain account packets, either icatypes.EncodingProtobuf
+	// or icatypes.EncodingProto3JSON. Left unset, the controller chain's default is used.
+	Encoding string
+}
+
+// RegisterInterchainAccount registers an interchain account owned by keyName on the counterparty chain of connectionID
+// using the ibc-go controller module, and returns the transaction hash.
+// The account is usable once the relayer completes the channel handshake, see WaitForICAChannelOpen.
+func (tn *ChainNode) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string, opts ICAOptions) (string, error) {
+	command := []string{"interchain-accounts", "controller", "register", connectionID}
+
+	if opts.Encoding != "" {
+		version, err := tn.icaVersion(ctx, connectionID, opts.Encoding)
+		if err != nil {
+			return "", err
+		}
+		command = append(command, "--version", version)
+	}
+
+	switch opts.Ordering {
+	case ibc.Invalid:
+	case ibc.Ordered:
+		command = append(command, "--ordering", chantypes.ORDERED.String())
+	case ibc.Unordered:
+		command = append(command, "--ordering", chantypes.UNORDERED.String())
+	default:
+		return "", fmt.Errorf("invalid interchain account channel ordering: %d", opts.Ordering)
+	}
+
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// icaVersion returns the interchain account metadata version string for connectionID with the given encoding.
+func (tn *ChainNode) icaVersion(ctx context.Context, connectionID, encoding string) (string, error) {
+	conn, err := grpc.Dial(tn.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	res, err := connectiontypes.NewQueryClient(conn).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return "", fmt.Errorf("failed to query connection %s: %w", connectionID, err)
+	}
+
+	metadata := icatypes.NewMetadata(icatypes.Version, connectionID, res.Connection.Counterparty.ConnectionId, "", encoding, icatypes.TxTypeSDKMultiMsg)
+	version, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(version), nil
+}
+
+// QueryInterchainAccount returns the address on the host chain of the interchain account owned by owner on connectionID.
+func (tn *ChainNode) QueryInterchainAccount(ctx context.Context, connectionID, owner string) (string, error) {
+	stdout, _, err := tn.ExecQuery(ctx,
+		"interchain-accounts", "controller", "interchain-account", owner, connectionID,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	var res controllertypes.QueryInterchainAccountResponse
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return "", fmt.Errorf("failed to unmarshal interchain account: %w", err)
+	}
+	return res.Address, nil
+}
+
+// InterchainAccountChannels returns every channel, in any state, on connectionID bound to the controller port of owner.
+// A closed channel is left behind each time the account's channel times out and is re-opened.
+func (c *CosmosChain) InterchainAccountChannels(ctx context.Context, connectionID, owner string) ([]ibc.ChannelOutput, error) {
+	portID, err := icatypes.NewControllerPortID(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(c.getFullNode().hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{Connection: connectionID})
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []ibc.ChannelOutput
+	for _, ch := range res.Channels {
+		if ch.PortId != portID {
+			continue
+		}
+		channels = append(channels, ibc.ChannelOutput{
+			State:    ch.State.String(),
+			Ordering: ch.Ordering.String(),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.Counterparty.PortId,
+				ChannelID: ch.Counterparty.ChannelId,
+			},
+			ConnectionHops: ch.ConnectionHops,
+			Version:        ch.Version,
+			PortID:         ch.PortId,
+			ChannelID:      ch.ChannelId,
+		})
+	}
+	return channels, nil
+}
+
+// WaitForICAChannelOpen polls until the interchain account channel of owner on connectionID is open,
+// and returns it. An error is returned if the channel does not open within timeout.
+func (c *CosmosChain) WaitForICAChannelOpen(ctx context.Context, connectionID, owner string, timeout time.Duration) (ibc.ChannelOutput, error) {
+	var open ibc.ChannelOutput
+	err := testutil.WaitForCondition(timeout, time.Second, func() (bool, error) {
+		channels, err := c.InterchainAccountChannels(ctx, connectionID, owner)
+		if err != nil {
+			return false, err
+		}
+		for _, ch := range channels {
+			if ch.State == chantypes.OPEN.String() {
+				open = ch
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return ibc.ChannelOutput{}, fmt.Errorf("interchain account channel for %s on %s did not open: %w", owner, connectionID, err)
+	}
+	return open, nil
+}
+
+// SendICATx signs and broadcasts, through the broadcaster, a transaction submitting msgs for execution by
+// the interchain account owned by owner on connectionID. The packet times out relativeTimeout after the
+// controller chain's latest block time. encoding must match the one negotiated when the account was registered.
+func SendICATx(ctx context.Context, broadcaster *Broadcaster, owner User, connectionID string, relativeTimeout time.Duration, encoding string, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	if encoding == "" {
+		encoding = icatypes.EncodingProtobuf
+	}
+
+	protoMsgs := make([]proto.Message, len(msgs))
+	for i, msg := range msgs {
+		protoMsgs[i] = msg
+	}
+
+	data, err := icatypes.SerializeCosmosTxWithEncoding(broadcaster.chain.cfg.EncodingConfig.Codec, protoMsgs, encoding)
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to serialize interchain account messages: %w", err)
+	}
+
+	packetData := icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: data,
+	}
+
+	msg := controllertypes.NewMsgSendTx(owner.FormattedAddress(), connectionID, uint64(relativeTimeout.Nanoseconds()), packetData)
+	resp, err := BroadcastTx(ctx, broadcaster, owner, msg)
+	if err != nil {
+		return resp, err
+	}
+	if resp.Code != 0 {
+		return resp, fmt.Errorf("interchain account tx failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	return resp, nil
+}
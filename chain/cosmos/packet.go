@@ -0,0 +1,105 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var _ testutil.PacketInspector = (*CosmosChain)(nil)
+
+// channelQuery dials the full node's gRPC endpoint and calls fn with an IBC channel query client.
+func (c *CosmosChain) channelQuery(fn func(chanTypes.QueryClient) error) error {
+	conn, err := grpc.Dial(c.getFullNode().hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(chanTypes.NewQueryClient(conn))
+}
+
+// HasPacketCommitment implements testutil.PacketInspector.
+func (c *CosmosChain) HasPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	var found bool
+	err := c.channelQuery(func(qc chanTypes.QueryClient) error {
+		res, err := qc.PacketCommitment(ctx, &chanTypes.QueryPacketCommitmentRequest{PortId: portID, ChannelId: channelID, Sequence: sequence})
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		found = len(res.Commitment) > 0
+		return nil
+	})
+	return found, err
+}
+
+// HasPacketReceipt implements testutil.PacketInspector.
+func (c *CosmosChain) HasPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	var received bool
+	err := c.channelQuery(func(qc chanTypes.QueryClient) error {
+		res, err := qc.PacketReceipt(ctx, &chanTypes.QueryPacketReceiptRequest{PortId: portID, ChannelId: channelID, Sequence: sequence})
+		if err != nil {
+			return err
+		}
+		received = res.Received
+		return nil
+	})
+	return received, err
+}
+
+// PacketAcknowledgement implements testutil.PacketInspector.
+func (c *CosmosChain) PacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	var ack []byte
+	err := c.channelQuery(func(qc chanTypes.QueryClient) error {
+		res, err := qc.PacketAcknowledgement(ctx, &chanTypes.QueryPacketAcknowledgementRequest{PortId: portID, ChannelId: channelID, Sequence: sequence})
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ack = res.Acknowledgement
+		return nil
+	})
+	return ack, err
+}
+
+// PacketEvents implements testutil.PacketInspector by searching the transactions indexed by the full node.
+func (c *CosmosChain) PacketEvents(ctx context.Context, eventType, srcPort, srcChannel string, sequence uint64) ([]testutil.PacketEvent, error) {
+	query := fmt.Sprintf("%[1]s.%[2]s='%[3]s' AND %[1]s.%[4]s='%[5]s' AND %[1]s.%[6]s='%[7]d'",
+		eventType,
+		chanTypes.AttributeKeySrcPort, srcPort,
+		chanTypes.AttributeKeySrcChannel, srcChannel,
+		chanTypes.AttributeKeySequence, sequence,
+	)
+
+	client := c.getFullNode().Client
+	res, err := client.TxSearch(ctx, query, false, nil, nil, "asc")
+	if err != nil {
+		return nil, fmt.Errorf("search %s: %w", query, err)
+	}
+
+	events := make([]testutil.PacketEvent, len(res.Txs))
+	for i, tx := range res.Txs {
+		height := tx.Height
+		block, err := client.Block(ctx, &height)
+		if err != nil {
+			return nil, fmt.Errorf("get block at height %d: %w", height, err)
+		}
+		events[i] = testutil.PacketEvent{
+			Height: uint64(height),
+			Time:   block.Block.Time,
+			TxHash: fmt.Sprintf("%X", tx.Hash),
+		}
+	}
+	return events, nil
+}
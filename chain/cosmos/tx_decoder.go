@@ -0,0 +1,111 @@
+package cosmos
+
+import (
+	"fmt"
+	"sync"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// DecodedTx is a tx decoded for the block database.
+type DecodedTx struct {
+	JSON []byte // The tx encoded as proto JSON, messages include their "@type".
+	Fee  string // Empty if the tx has no fee.
+}
+
+// TxDecoder decodes the raw bytes of txs found in blocks of a chain.
+type TxDecoder interface {
+	DecodeTx(cfg ibc.ChainConfig, txbz []byte) (DecodedTx, error)
+}
+
+// TxDecoderFunc adapts a function to a TxDecoder.
+type TxDecoderFunc func(cfg ibc.ChainConfig, txbz []byte) (DecodedTx, error)
+
+func (f TxDecoderFunc) DecodeTx(cfg ibc.ChainConfig, txbz []byte) (DecodedTx, error) {
+	return f(cfg, txbz)
+}
+
+var (
+	txDecodersMu sync.RWMutex
+	txDecoders   = make(map[string]TxDecoder)
+)
+
+// RegisterTxDecoder sets the decoder for txs of chains whose ChainConfig.Type is chainType,
+// e.g. to decode custom module messages missing from the chain's EncodingConfig.
+// Registering a nil decoder restores the default.
+func RegisterTxDecoder(chainType string, decoder TxDecoder) {
+	txDecodersMu.Lock()
+	defer txDecodersMu.Unlock()
+	if decoder == nil {
+		delete(txDecoders, chainType)
+		return
+	}
+	txDecoders[chainType] = decoder
+}
+
+// TxDecoderFor returns the decoder registered for chainType, or DefaultTxDecoder.
+func TxDecoderFor(chainType string) TxDecoder {
+	txDecodersMu.RLock()
+	defer txDecodersMu.RUnlock()
+	if decoder, ok := txDecoders[chainType]; ok {
+		return decoder
+	}
+	return DefaultTxDecoder
+}
+
+// DefaultTxDecoder decodes txs with the interfaces of the chain's EncodingConfig, or DefaultEncoding if unset.
+var DefaultTxDecoder TxDecoder = NewTxDecoder()
+
+// NewTxDecoder returns a decoder which uses the interfaces of the chain's EncodingConfig, or DefaultEncoding if unset.
+// The first time a registry is used it is extended by registers,
+// e.g. the RegisterInterfaces functions of custom modules missing from the chain's EncodingConfig.
+func NewTxDecoder(registers ...func(codectypes.InterfaceRegistry)) TxDecoder {
+	var (
+		once            sync.Once
+		defaultRegistry codectypes.InterfaceRegistry
+
+		mu       sync.Mutex
+		extended = make(map[codectypes.InterfaceRegistry]bool)
+	)
+	return TxDecoderFunc(func(cfg ibc.ChainConfig, txbz []byte) (DecodedTx, error) {
+		var registry codectypes.InterfaceRegistry
+		if cfg.EncodingConfig != nil {
+			registry = cfg.EncodingConfig.InterfaceRegistry
+		} else {
+			once.Do(func() {
+				defaultRegistry = DefaultEncoding().InterfaceRegistry
+			})
+			registry = defaultRegistry
+		}
+
+		if len(registers) > 0 {
+			mu.Lock()
+			if !extended[registry] {
+				for _, register := range registers {
+					register(registry)
+				}
+				extended[registry] = true
+			}
+			mu.Unlock()
+		}
+		return decodeTxWithRegistry(registry, txbz)
+	})
+}
+
+func decodeTxWithRegistry(registry codectypes.InterfaceRegistry, txbz []byte) (DecodedTx, error) {
+	sdkTx, err := decodeTX(registry, txbz)
+	if err != nil {
+		return DecodedTx{}, fmt.Errorf("decode tx: %w", err)
+	}
+	b, err := encodeTxToJSON(registry, sdkTx)
+	if err != nil {
+		return DecodedTx{}, fmt.Errorf("marshal tx to json: %w", err)
+	}
+	decoded := DecodedTx{JSON: b}
+	if feeTx, ok := sdkTx.(sdk.FeeTx); ok {
+		decoded.Fee = feeTx.GetFee().String()
+	}
+	return decoded, nil
+}
@@ -0,0 +1,71 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxDecoder(t *testing.T) {
+	enc := cosmos.DefaultEncoding()
+	builder := enc.TxConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+	}))
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("uatom", 500)))
+	txbz, err := enc.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		decoded, err := cosmos.DefaultTxDecoder.DecodeTx(ibc.ChainConfig{EncodingConfig: &enc}, txbz)
+		require.NoError(t, err)
+		require.Contains(t, string(decoded.JSON), `"@type":"/cosmos.bank.v1beta1.MsgSend"`)
+		require.Equal(t, "500uatom", decoded.Fee)
+
+		_, err = cosmos.DefaultTxDecoder.DecodeTx(ibc.ChainConfig{EncodingConfig: &enc}, []byte("garbage"))
+		require.Error(t, err)
+	})
+
+	t.Run("custom interfaces", func(t *testing.T) {
+		// The chain's encoding config does not know about bank messages.
+		bare := testutil.MakeTestEncodingConfig()
+		cfg := ibc.ChainConfig{EncodingConfig: &bare}
+
+		_, err := cosmos.DefaultTxDecoder.DecodeTx(cfg, txbz)
+		require.Error(t, err)
+
+		decoded, err := cosmos.NewTxDecoder(banktypes.RegisterInterfaces).DecodeTx(cfg, txbz)
+		require.NoError(t, err)
+		require.Contains(t, string(decoded.JSON), `"from_address":"cosmos1from"`)
+
+		// The chain's own registry was extended, rather than a copy of DefaultEncoding.
+		_, err = bare.InterfaceRegistry.Resolve(sdk.MsgTypeURL(&banktypes.MsgSend{}))
+		require.NoError(t, err)
+	})
+
+	t.Run("registry", func(t *testing.T) {
+		const chainType = "test-tx-decoder"
+		cfg := ibc.ChainConfig{Type: chainType, EncodingConfig: &enc}
+		_, err := cosmos.TxDecoderFor(chainType).DecodeTx(cfg, []byte("garbage"))
+		require.Error(t, err)
+
+		custom := cosmos.TxDecoderFunc(func(ibc.ChainConfig, []byte) (cosmos.DecodedTx, error) {
+			return cosmos.DecodedTx{JSON: []byte(`{}`)}, nil
+		})
+		cosmos.RegisterTxDecoder(chainType, custom)
+		decoded, err := cosmos.TxDecoderFor(chainType).DecodeTx(cfg, []byte("garbage"))
+		require.NoError(t, err)
+		require.Equal(t, `{}`, string(decoded.JSON))
+
+		cosmos.RegisterTxDecoder(chainType, nil)
+		_, err = cosmos.TxDecoderFor(chainType).DecodeTx(cfg, []byte("garbage"))
+		require.Error(t, err)
+	})
+}
@@ -1,20 +1,25 @@
 package ethereum
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"cosmossdk.io/math"
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
@@ -246,7 +251,29 @@ func (c *EthereumChain) Start(testName string, ctx context.Context, additionalGe
 	c.hostRPCPort = hostPorts[0]
 	fmt.Println("Host RPC port: ", c.hostRPCPort)
 
-	return testutil.WaitForBlocks(ctx, 2, c)
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return err
+	}
+
+	for _, wallet := range additionalGenesisWallets {
+		if err := c.SetBalance(ctx, wallet.Address, wallet.Amount); err != nil {
+			return fmt.Errorf("failed to fund genesis wallet %s: %w", wallet.Address, err)
+		}
+	}
+	return nil
+}
+
+// Stop stops and removes the anvil container. The chain's volume is kept.
+func (c *EthereumChain) Stop(ctx context.Context) error {
+	if err := c.containerLifecycle.StopContainer(ctx); err != nil {
+		return err
+	}
+	return c.containerLifecycle.RemoveContainer(ctx)
+}
+
+// Logs returns the last tail lines of the anvil container's output, or all of it when tail is 0.
+func (c *EthereumChain) Logs(ctx context.Context, tail int) ([]byte, error) {
+	return c.containerLifecycle.Logs(ctx, tail)
 }
 
 func (c *EthereumChain) HostName() string {
@@ -330,3 +357,153 @@ func (c *EthereumChain) GetRPCAddress() string {
 func (c *EthereumChain) GetHostRPCAddress() string {
 	return "http://" + c.hostRPCPort
 }
+
+// signerFlags returns the cast and forge flags used to sign transactions as keyName.
+func (c *EthereumChain) signerFlags(keyName string) ([]string, error) {
+	if keyName == "faucet" {
+		return []string{"--private-key", FaucetPrivateKey}, nil
+	}
+	keystore, ok := c.keystoreMap[keyName]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyName)
+	}
+	return []string{"--keystore", keystore, "--password", ""}, nil
+}
+
+// GetBalance returns the wei balance of address. denom is ignored, only the native token is supported.
+func (c *EthereumChain) GetBalance(ctx context.Context, address string, denom string) (math.Int, error) {
+	cmd := []string{"cast", "balance", address, "--rpc-url", c.GetRPCAddress()}
+	stdout, _, err := c.Exec(ctx, cmd, nil)
+	if err != nil {
+		return math.Int{}, err
+	}
+	balance, ok := math.NewIntFromString(strings.TrimSpace(string(stdout)))
+	if !ok {
+		return math.Int{}, fmt.Errorf("failed to parse balance: %s", stdout)
+	}
+	return balance, nil
+}
+
+// SendFunds sends amount wei from keyName to amount.Address.
+func (c *EthereumChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	signer, err := c.signerFlags(keyName)
+	if err != nil {
+		return err
+	}
+	cmd := append([]string{"cast", "send", amount.Address, "--value", amount.Amount.String(), "--rpc-url", c.GetRPCAddress()}, signer...)
+	_, _, err = c.Exec(ctx, cmd, nil)
+	return err
+}
+
+// SetBalance overwrites the wei balance of address using anvil's cheat codes.
+func (c *EthereumChain) SetBalance(ctx context.Context, address string, amount math.Int) error {
+	cmd := []string{"cast", "rpc", "anvil_setBalance", address, hexutil.EncodeBig(amount.BigInt()), "--rpc-url", c.GetRPCAddress()}
+	_, _, err := c.Exec(ctx, cmd, nil)
+	return err
+}
+
+// WriteFile writes content to relPath in the chain's volume, relative to HomeDir.
+func (c *EthereumChain) WriteFile(ctx context.Context, content []byte, relPath string) error {
+	fw := dockerutil.NewFileWriter(c.logger(), c.DockerClient, c.testName)
+	return fw.WriteFile(ctx, c.VolumeName, relPath, content)
+}
+
+// DeployContract compiles and deploys the Solidity contract named contractName from relPath, relative to HomeDir,
+// signing as keyName. It returns the address of the deployed contract.
+func (c *EthereumChain) DeployContract(ctx context.Context, keyName, relPath, contractName string, constructorArgs ...string) (string, error) {
+	signer, err := c.signerFlags(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := []string{
+		"forge", "create",
+		"--root", c.HomeDir(),
+		"--broadcast",
+		"--json",
+		"--rpc-url", c.GetRPCAddress(),
+		fmt.Sprintf("%s:%s", path.Join(c.HomeDir(), relPath), contractName),
+	}
+	cmd = append(cmd, signer...)
+	if len(constructorArgs) > 0 {
+		cmd = append(cmd, "--constructor-args")
+		cmd = append(cmd, constructorArgs...)
+	}
+
+	stdout, stderr, err := c.Exec(ctx, cmd, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to deploy %s: %w: %s", contractName, err, stderr)
+	}
+
+	// forge prints compiler output before the json result, which is always the last line.
+	lines := strings.Split(strings.TrimSpace(string(stdout)), "\n")
+	var res struct {
+		DeployedTo string `json:"deployedTo"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &res); err != nil {
+		return "", fmt.Errorf("failed to parse forge output: %w: %s", err, stdout)
+	}
+	return res.DeployedTo, nil
+}
+
+// rpc calls method on the chain's JSON-RPC endpoint from the host and unmarshals the result into result.
+func (c *EthereumChain) rpc(ctx context.Context, result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.GetHostRPCAddress(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	var rpcRes struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rpcRes); err != nil {
+		return fmt.Errorf("%s: decode response: %w", method, err)
+	}
+	if rpcRes.Error != nil {
+		return fmt.Errorf("%s: %s (code %d)", method, rpcRes.Error.Message, rpcRes.Error.Code)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(rpcRes.Result, result)
+}
+
+// DumpState returns anvil's entire chain state, hex encoded, so it can be restored with LoadState.
+func (c *EthereumChain) DumpState(ctx context.Context) (string, error) {
+	var state string
+	if err := c.rpc(ctx, &state, "anvil_dumpState"); err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// LoadState merges a state returned by DumpState into the running chain.
+func (c *EthereumChain) LoadState(ctx context.Context, state string) error {
+	var ok bool
+	if err := c.rpc(ctx, &ok, "anvil_loadState", state); err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("anvil_loadState: state was not loaded")
+	}
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"runtime"
 
-	"cosmossdk.io/math"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
@@ -71,12 +70,3 @@ func (c *EthereumChain) CreateKey(ctx context.Context, keyName string) error {
 	// Placeholder for future implementation
 	return fmt.Errorf("CreateKey not implemented")
 }
-
-func (c *EthereumChain) GetBalance(ctx context.Context, address string, denom string) (math.Int, error) {
-	// Placeholder for future implementation
-	return math.Int{}, fmt.Errorf("GetBalance not implemented")
-}
-
-func (c *EthereumChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return fmt.Errorf("SendFunds not implemented")
-}
@@ -7,6 +7,13 @@ import (
 
 var _ ibc.Wallet = &EthereumWallet{}
 
+const (
+	// FaucetAddress is the first of anvil's default, pre-funded accounts.
+	FaucetAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	// FaucetPrivateKey is the well known private key of FaucetAddress. Never use it outside of local networks.
+	FaucetPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
 type EthereumWallet struct {
 	address string
 	keyName string
@@ -49,5 +56,5 @@ func NewGenesisWallet() GenesisWallets {
 }
 
 func (w *GenesisWallets) GetFaucetWallet(keyname string) ibc.Wallet {
-	return NewWallet(keyname, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	return NewWallet(keyname, FaucetAddress)
 }
@@ -0,0 +1,106 @@
+package ibcevents
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/multierr"
+)
+
+// TxSearcher searches transactions by their events, as implemented by the CometBFT RPC client.
+type TxSearcher interface {
+	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error)
+}
+
+// SearchPacket returns the first eventType event for packet among the transactions indexed by client.
+func SearchPacket(ctx context.Context, client TxSearcher, eventType string, packet ibc.Packet) (Event, error) {
+	query := fmt.Sprintf("%[1]s.%[2]s='%[3]s' AND %[1]s.%[4]s='%[5]s' AND %[1]s.%[6]s='%[7]d'",
+		eventType, AttributeKeySrcPort, packet.SourcePort, AttributeKeySrcChannel, packet.SourceChannel, AttributeKeySequence, packet.Sequence)
+	if packet.DestChannel != "" {
+		query += fmt.Sprintf(" AND %s.%s='%s'", eventType, AttributeKeyDstChannel, packet.DestChannel)
+	}
+	res, err := client.TxSearch(ctx, query, false, nil, nil, "")
+	if err != nil {
+		return Event{}, fmt.Errorf("search %s for sequence %d: %w", eventType, packet.Sequence, err)
+	}
+	for _, tx := range res.Txs {
+		if event, err := FindPacket(FromABCIEvents(tx.TxResult.Events), eventType, packet); err == nil {
+			return event, nil
+		}
+	}
+	return Event{}, fmt.Errorf("%s for sequence %d on %s/%s: %w", eventType, packet.Sequence, packet.SourcePort, packet.SourceChannel, ErrNotFound)
+}
+
+// BackfillAcknowledgement fills in the acknowledgement bytes of ack, and its packet data if missing,
+// from the write_acknowledgement event the destination chain emitted when it received the packet.
+// The destination chain is only known by its channel, so each of counterparties is searched in turn.
+func BackfillAcknowledgement(ctx context.Context, ack *ibc.PacketAcknowledgement, counterparties []TxSearcher) error {
+	if len(ack.Acknowledgement) > 0 && len(ack.Packet.Data) > 0 {
+		return nil
+	}
+	var errs error
+	for _, client := range counterparties {
+		written, err := SearchPacket(ctx, client, EventTypeWriteAck, ack.Packet)
+		if err != nil {
+			multierr.AppendInto(&errs, err)
+			continue
+		}
+		if len(ack.Acknowledgement) == 0 {
+			if ack.Acknowledgement, err = written.Attributes.Acknowledgement(); err != nil {
+				return err
+			}
+		}
+		if len(ack.Packet.Data) == 0 {
+			packet, err := written.Attributes.Packet()
+			if err != nil {
+				return err
+			}
+			ack.Packet.Data = packet.Data
+		}
+		return nil
+	}
+	if errs == nil {
+		errs = fmt.Errorf("%s for sequence %d on %s/%s: no counterparty chains: %w",
+			EventTypeWriteAck, ack.Packet.Sequence, ack.Packet.SourcePort, ack.Packet.SourceChannel, ErrNotFound)
+	}
+	return errs
+}
+
+// Counterparties tracks the chains on the other end of a chain's IBC links,
+// whose events complete the packets the chain acknowledges.
+// The zero value is ready to use.
+type Counterparties struct {
+	mu     sync.Mutex
+	chains []ibc.Chain
+}
+
+// Add adds chain, unless it is already tracked.
+func (c *Counterparties) Add(chain ibc.Chain) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.chains {
+		if existing == chain {
+			return
+		}
+	}
+	c.chains = append(c.chains, chain)
+}
+
+// TxSearchers returns CometBFT RPC clients for the host RPC addresses of the tracked chains.
+func (c *Counterparties) TxSearchers() ([]TxSearcher, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clients := make([]TxSearcher, len(c.chains))
+	for i, chain := range c.chains {
+		client, err := rpchttp.New(chain.GetHostRPCAddress(), "/websocket")
+		if err != nil {
+			return nil, fmt.Errorf("rpc client for counterparty %s: %w", chain.Config().ChainID, err)
+		}
+		clients[i] = client
+	}
+	return clients, nil
+}
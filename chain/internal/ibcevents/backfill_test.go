@@ -0,0 +1,91 @@
+package ibcevents
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAck = `{"result":"AQ=="}`
+
+// fakeTxSearcher returns a single tx with events, or err.
+type fakeTxSearcher struct {
+	events  []abcitypes.Event
+	err     error
+	queries []string
+}
+
+func (f *fakeTxSearcher) TxSearch(_ context.Context, query string, _ bool, _, _ *int, _ string) (*coretypes.ResultTxSearch, error) {
+	f.queries = append(f.queries, query)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &coretypes.ResultTxSearch{Txs: []*coretypes.ResultTx{{TxResult: abcitypes.ResponseDeliverTx{Events: f.events}}}}, nil
+}
+
+func writeAckCounterparty() *fakeTxSearcher {
+	return &fakeTxSearcher{events: []abcitypes.Event{
+		packetAttrs(EventTypeWriteAck,
+			abcitypes.EventAttribute{Key: "packet_data_hex", Value: "7b7d"},
+			abcitypes.EventAttribute{Key: "packet_ack_hex", Value: hex.EncodeToString([]byte(testAck))},
+		),
+	}}
+}
+
+func TestBackfillAcknowledgement_Penumbra(t *testing.T) {
+	// Penumbra's acknowledge_packet events carry neither data nor ack.
+	acks, err := Acknowledgements(FromABCIEvents([]abcitypes.Event{packetAttrs(EventTypeAcknowledgePacket)}))
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	require.Error(t, acks[0].Validate())
+
+	down := &fakeTxSearcher{err: errors.New("connection refused")}
+	counterparty := writeAckCounterparty()
+	require.NoError(t, BackfillAcknowledgement(context.Background(), &acks[0], []TxSearcher{down, counterparty}))
+
+	require.NoError(t, acks[0].Validate())
+	require.Equal(t, wantPacket("{}"), acks[0].Packet)
+	require.Equal(t, []byte(testAck), acks[0].Acknowledgement)
+	require.Equal(t, []string{
+		"write_acknowledgement.packet_src_port='transfer' AND write_acknowledgement.packet_src_channel='channel-0' AND " +
+			"write_acknowledgement.packet_sequence='7' AND write_acknowledgement.packet_dst_channel='channel-1'",
+	}, counterparty.queries)
+}
+
+func TestBackfillAcknowledgement_Polkadot(t *testing.T) {
+	const raw = `[{"AcknowledgePacket":{"packet":{"sequence":7,"source_port":"transfer","source_channel":"channel-0","destination_port":"transfer","destination_channel":"channel-1","data":"7B7D","timeout_height":{"revision_number":0,"revision_height":100},"timeout_timestamp":{"time":"1970-01-01T00:00:00.000001Z"}}}}]`
+	var result []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &result))
+	events, err := FromSubstrateEvents(result)
+	require.NoError(t, err)
+	acks, err := Acknowledgements(events)
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	require.Error(t, acks[0].Validate())
+
+	require.NoError(t, BackfillAcknowledgement(context.Background(), &acks[0], []TxSearcher{writeAckCounterparty()}))
+	require.NoError(t, acks[0].Validate())
+	require.Equal(t, []byte(testAck), acks[0].Acknowledgement)
+}
+
+func TestBackfillAcknowledgement_NotFound(t *testing.T) {
+	acks, err := Acknowledgements(FromABCIEvents([]abcitypes.Event{packetAttrs(EventTypeAcknowledgePacket)}))
+	require.NoError(t, err)
+
+	err = BackfillAcknowledgement(context.Background(), &acks[0], nil)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorContains(t, err, "no counterparty chains")
+
+	// The write_acknowledgement of another packet does not match.
+	other := &fakeTxSearcher{events: []abcitypes.Event{packetAttrs(EventTypeWriteAck)}}
+	acks[0].Packet.Sequence = 8
+	err = BackfillAcknowledgement(context.Background(), &acks[0], []TxSearcher{other})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Empty(t, acks[0].Acknowledgement)
+}
@@ -0,0 +1,2 @@
+// Package ibcevents decodes IBC packet lifecycle events emitted by non-Cosmos chains into ibc types.
+package ibcevents
@@ -0,0 +1,192 @@
+package ibcevents
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// Event types emitted by ibc-go compatible implementations.
+const (
+	EventTypeSendPacket        = "send_packet"
+	EventTypeWriteAck          = "write_acknowledgement"
+	EventTypeAcknowledgePacket = "acknowledge_packet"
+	EventTypeTimeoutPacket     = "timeout_packet"
+)
+
+// Attribute keys shared by all packet events.
+const (
+	AttributeKeySequence         = "packet_sequence"
+	AttributeKeySrcPort          = "packet_src_port"
+	AttributeKeySrcChannel       = "packet_src_channel"
+	AttributeKeyDstPort          = "packet_dst_port"
+	AttributeKeyDstChannel       = "packet_dst_channel"
+	AttributeKeyTimeoutHeight    = "packet_timeout_height"
+	AttributeKeyTimeoutTimestamp = "packet_timeout_timestamp"
+	AttributeKeyData             = "packet_data"
+	AttributeKeyDataHex          = "packet_data_hex"
+	AttributeKeyAckHex           = "packet_ack_hex"
+)
+
+// ErrNotFound is returned by FindPacket when no event matches.
+var ErrNotFound = errors.New("packet event not found")
+
+// Attributes is a flattened packet event, keyed by the ibc-go attribute names above.
+type Attributes map[string]string
+
+// Event is a single packet event normalized across chain implementations.
+type Event struct {
+	Type       string
+	Attributes Attributes
+}
+
+// FromABCIEvents converts tendermint events into normalized events, skipping anything not packet related.
+func FromABCIEvents(events []abcitypes.Event) []Event {
+	var found []Event
+	for _, event := range events {
+		switch event.Type {
+		case EventTypeSendPacket, EventTypeWriteAck, EventTypeAcknowledgePacket, EventTypeTimeoutPacket:
+		default:
+			continue
+		}
+		attrs := make(Attributes, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			key, value := attr.Key, attr.Value
+			// tendermint < v0.37-alpha returns base64 encoded strings in events.
+			if !isKnownKey(key) {
+				k, err := base64.StdEncoding.DecodeString(key)
+				if err != nil || !isKnownKey(string(k)) {
+					continue
+				}
+				v, err := base64.StdEncoding.DecodeString(value)
+				if err != nil {
+					continue
+				}
+				key, value = string(k), string(v)
+			}
+			attrs[key] = value
+		}
+		found = append(found, Event{Type: event.Type, Attributes: attrs})
+	}
+	return found
+}
+
+func isKnownKey(key string) bool {
+	switch key {
+	case AttributeKeySequence, AttributeKeySrcPort, AttributeKeySrcChannel, AttributeKeyDstPort, AttributeKeyDstChannel,
+		AttributeKeyTimeoutHeight, AttributeKeyTimeoutTimestamp, AttributeKeyData, AttributeKeyDataHex, AttributeKeyAckHex:
+		return true
+	}
+	return false
+}
+
+// Packet decodes the packet described by the attributes.
+// Data is read from packet_data_hex when present, falling back to packet_data.
+func (attrs Attributes) Packet() (ibc.Packet, error) {
+	var packet ibc.Packet
+
+	seq, err := strconv.ParseUint(attrs[AttributeKeySequence], 10, 64)
+	if err != nil {
+		return packet, fmt.Errorf("invalid packet sequence %q: %w", attrs[AttributeKeySequence], err)
+	}
+	packet.Sequence = seq
+
+	if ts := attrs[AttributeKeyTimeoutTimestamp]; ts != "" {
+		timeoutNano, err := strconv.ParseUint(ts, 10, 64)
+		if err != nil {
+			return packet, fmt.Errorf("invalid packet timestamp timeout %q: %w", ts, err)
+		}
+		packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
+	}
+
+	packet.SourcePort = attrs[AttributeKeySrcPort]
+	packet.SourceChannel = attrs[AttributeKeySrcChannel]
+	packet.DestPort = attrs[AttributeKeyDstPort]
+	packet.DestChannel = attrs[AttributeKeyDstChannel]
+	packet.TimeoutHeight = attrs[AttributeKeyTimeoutHeight]
+
+	if dataHex, ok := attrs[AttributeKeyDataHex]; ok {
+		data, err := hex.DecodeString(dataHex)
+		if err != nil {
+			return packet, fmt.Errorf("invalid packet data hex: %w", err)
+		}
+		packet.Data = data
+	} else if data, ok := attrs[AttributeKeyData]; ok {
+		packet.Data = []byte(data)
+	}
+
+	return packet, nil
+}
+
+// Acknowledgement returns the acknowledgement bytes if the event carries them.
+func (attrs Attributes) Acknowledgement() ([]byte, error) {
+	ackHex, ok := attrs[AttributeKeyAckHex]
+	if !ok {
+		return nil, nil
+	}
+	ack, err := hex.DecodeString(ackHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet ack hex: %w", err)
+	}
+	return ack, nil
+}
+
+// Acknowledgements decodes all acknowledge_packet events.
+// Implementations do not include the packet data or acknowledgement bytes in acknowledge_packet,
+// so callers typically need to backfill them, see BackfillAcknowledgement.
+func Acknowledgements(events []Event) ([]ibc.PacketAcknowledgement, error) {
+	var acks []ibc.PacketAcknowledgement
+	for _, event := range events {
+		if event.Type != EventTypeAcknowledgePacket {
+			continue
+		}
+		packet, err := event.Attributes.Packet()
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		ack, err := event.Attributes.Acknowledgement()
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{Packet: packet, Acknowledgement: ack})
+	}
+	return acks, nil
+}
+
+// Timeouts decodes all timeout_packet events.
+func Timeouts(events []Event) ([]ibc.PacketTimeout, error) {
+	var timeouts []ibc.PacketTimeout
+	for _, event := range events {
+		if event.Type != EventTypeTimeoutPacket {
+			continue
+		}
+		packet, err := event.Attributes.Packet()
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Type, err)
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{Packet: packet})
+	}
+	return timeouts, nil
+}
+
+// FindPacket returns the first event of eventType matching the packet's source port, source channel and sequence.
+func FindPacket(events []Event, eventType string, packet ibc.Packet) (Event, error) {
+	want := strconv.FormatUint(packet.Sequence, 10)
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		attrs := event.Attributes
+		if attrs[AttributeKeySequence] == want &&
+			attrs[AttributeKeySrcPort] == packet.SourcePort &&
+			attrs[AttributeKeySrcChannel] == packet.SourceChannel {
+			return event, nil
+		}
+	}
+	return Event{}, ErrNotFound
+}
@@ -0,0 +1,130 @@
+package ibcevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func packetAttrs(eventType string, extra ...abcitypes.EventAttribute) abcitypes.Event {
+	return abcitypes.Event{Type: eventType, Attributes: append([]abcitypes.EventAttribute{
+		{Key: "packet_sequence", Value: "7"},
+		{Key: "packet_src_port", Value: "transfer"},
+		{Key: "packet_src_channel", Value: "channel-0"},
+		{Key: "packet_dst_port", Value: "transfer"},
+		{Key: "packet_dst_channel", Value: "channel-1"},
+		{Key: "packet_timeout_height", Value: "0-100"},
+		{Key: "packet_timeout_timestamp", Value: "1000"},
+		{Key: "packet_channel_ordering", Value: "ORDER_UNORDERED"},
+	}, extra...)}
+}
+
+func wantPacket(data string) ibc.Packet {
+	packet := ibc.Packet{
+		Sequence:         7,
+		SourcePort:       "transfer",
+		SourceChannel:    "channel-0",
+		DestPort:         "transfer",
+		DestChannel:      "channel-1",
+		TimeoutHeight:    "0-100",
+		TimeoutTimestamp: 1000,
+	}
+	if data != "" {
+		packet.Data = []byte(data)
+	}
+	return packet
+}
+
+func TestFromABCIEvents(t *testing.T) {
+	events := FromABCIEvents([]abcitypes.Event{
+		{Type: "message", Attributes: []abcitypes.EventAttribute{{Key: "action", Value: "ignored"}}},
+		packetAttrs(EventTypeSendPacket, abcitypes.EventAttribute{Key: "packet_data_hex", Value: "7b7d"}),
+		packetAttrs(EventTypeAcknowledgePacket),
+		packetAttrs(EventTypeTimeoutPacket),
+	})
+	require.Len(t, events, 3)
+	require.Equal(t, EventTypeSendPacket, events[0].Type)
+	require.NotContains(t, events[0].Attributes, "packet_channel_ordering")
+
+	sent, err := FindPacket(events, EventTypeSendPacket, wantPacket(""))
+	require.NoError(t, err)
+	packet, err := sent.Attributes.Packet()
+	require.NoError(t, err)
+	require.Equal(t, wantPacket("{}"), packet)
+
+	acks, err := Acknowledgements(events)
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	require.Equal(t, wantPacket(""), acks[0].Packet)
+	require.Empty(t, acks[0].Acknowledgement)
+
+	timeouts, err := Timeouts(events)
+	require.NoError(t, err)
+	require.Len(t, timeouts, 1)
+	require.Equal(t, wantPacket(""), timeouts[0].Packet)
+
+	_, err = FindPacket(events, EventTypeWriteAck, wantPacket(""))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFromABCIEvents_Base64(t *testing.T) {
+	enc := base64.StdEncoding.EncodeToString
+	event := packetAttrs(EventTypeAcknowledgePacket, abcitypes.EventAttribute{Key: "packet_ack_hex", Value: "6f6b"})
+	for i, attr := range event.Attributes {
+		event.Attributes[i] = abcitypes.EventAttribute{Key: enc([]byte(attr.Key)), Value: enc([]byte(attr.Value))}
+	}
+
+	acks, err := Acknowledgements(FromABCIEvents([]abcitypes.Event{event}))
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	require.Equal(t, wantPacket(""), acks[0].Packet)
+	require.Equal(t, []byte("ok"), acks[0].Acknowledgement)
+}
+
+func TestAttributes_Packet(t *testing.T) {
+	_, err := Attributes{}.Packet()
+	require.ErrorContains(t, err, "invalid packet sequence")
+
+	_, err = Attributes{AttributeKeySequence: "1", AttributeKeyTimeoutTimestamp: "soon"}.Packet()
+	require.ErrorContains(t, err, "invalid packet timestamp timeout")
+
+	_, err = Attributes{AttributeKeySequence: "1", AttributeKeyDataHex: "zz"}.Packet()
+	require.ErrorContains(t, err, "invalid packet data hex")
+
+	packet, err := Attributes{AttributeKeySequence: "1", AttributeKeyData: "raw", AttributeKeyDataHex: "6865"}.Packet()
+	require.NoError(t, err)
+	require.Equal(t, []byte("he"), packet.Data)
+}
+
+func TestFromSubstrateEvents(t *testing.T) {
+	const raw = `[
+		{"CreateClient":{"client_id":"07-tendermint-0"}},
+		{"AcknowledgePacket":{"height":{"revision_number":0,"revision_height":10},"packet":{"sequence":7,"source_port":"transfer","source_channel":"channel-0","destination_port":"transfer","destination_channel":"channel-1","data":"7B7D","timeout_height":{"revision_number":0,"revision_height":100},"timeout_timestamp":{"time":"1970-01-01T00:00:00.000001Z"}}}},
+		{"TimeoutPacket":{"height":{"revision_number":0,"revision_height":10},"packet":{"sequence":7,"source_port":"transfer","source_channel":"channel-0","destination_port":"transfer","destination_channel":"channel-1","data":"{}","timeout_height":{"revision_number":0,"revision_height":100},"timeout_timestamp":{"time":null}}}}
+	]`
+	var result []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &result))
+
+	events, err := FromSubstrateEvents(result)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	acks, err := Acknowledgements(events)
+	require.NoError(t, err)
+	require.Len(t, acks, 1)
+	require.Equal(t, wantPacket("{}"), acks[0].Packet)
+
+	timeouts, err := Timeouts(events)
+	require.NoError(t, err)
+	require.Len(t, timeouts, 1)
+	want := wantPacket("{}")
+	want.TimeoutTimestamp = 0
+	require.Equal(t, want, timeouts[0].Packet)
+
+	_, err = FromSubstrateEvents([]map[string]interface{}{{"SendPacket": "not an object"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package ibcevents
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// substrateEventTypes maps pallet-ibc event names to their ibc-go equivalents.
+var substrateEventTypes = map[string]string{
+	"SendPacket":           EventTypeSendPacket,
+	"WriteAcknowledgement": EventTypeWriteAck,
+	"AcknowledgePacket":    EventTypeAcknowledgePacket,
+	"TimeoutPacket":        EventTypeTimeoutPacket,
+	"TimeoutOnClosePacket": EventTypeTimeoutPacket,
+}
+
+type substrateHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+type substratePacket struct {
+	Sequence           uint64          `json:"sequence"`
+	SourcePort         string          `json:"source_port"`
+	SourceChannel      string          `json:"source_channel"`
+	DestinationPort    string          `json:"destination_port"`
+	DestinationChannel string          `json:"destination_channel"`
+	Data               string          `json:"data"`
+	TimeoutHeight      substrateHeight `json:"timeout_height"`
+	TimeoutTimestamp   struct {
+		Time *time.Time `json:"time"`
+	} `json:"timeout_timestamp"`
+}
+
+type substrateEvent struct {
+	Packet substratePacket `json:"packet"`
+	Ack    *string         `json:"ack"`
+}
+
+// FromSubstrateEvents converts the result of the ibc_queryEvents parachain RPC into normalized events,
+// skipping anything not packet related. Each entry is a single-key object of event name to payload.
+func FromSubstrateEvents(events []map[string]interface{}) ([]Event, error) {
+	var found []Event
+	for _, raw := range events {
+		for name, payload := range raw {
+			eventType, ok := substrateEventTypes[name]
+			if !ok {
+				continue
+			}
+			bz, err := json.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("marshal %s payload: %w", name, err)
+			}
+			var ev substrateEvent
+			if err := json.Unmarshal(bz, &ev); err != nil {
+				return nil, fmt.Errorf("unmarshal %s payload: %w", name, err)
+			}
+			found = append(found, Event{Type: eventType, Attributes: ev.attributes()})
+		}
+	}
+	return found, nil
+}
+
+func (ev substrateEvent) attributes() Attributes {
+	p := ev.Packet
+	attrs := Attributes{
+		AttributeKeySequence:      strconv.FormatUint(p.Sequence, 10),
+		AttributeKeySrcPort:       p.SourcePort,
+		AttributeKeySrcChannel:    p.SourceChannel,
+		AttributeKeyDstPort:       p.DestinationPort,
+		AttributeKeyDstChannel:    p.DestinationChannel,
+		AttributeKeyTimeoutHeight: fmt.Sprintf("%d-%d", p.TimeoutHeight.RevisionNumber, p.TimeoutHeight.RevisionHeight),
+	}
+
+	var timeoutNano int64
+	if t := p.TimeoutTimestamp.Time; t != nil {
+		timeoutNano = t.UnixNano()
+	}
+	attrs[AttributeKeyTimeoutTimestamp] = strconv.FormatInt(timeoutNano, 10)
+
+	// pallet-ibc serializes byte fields as hex, but fall back to raw bytes for anything that isn't.
+	if _, err := hex.DecodeString(p.Data); err == nil {
+		attrs[AttributeKeyDataHex] = p.Data
+	} else {
+		attrs[AttributeKeyData] = p.Data
+	}
+	if ev.Ack != nil {
+		if _, err := hex.DecodeString(*ev.Ack); err == nil {
+			attrs[AttributeKeyAckHex] = *ev.Ack
+		} else {
+			attrs[AttributeKeyAckHex] = hex.EncodeToString([]byte(*ev.Ack))
+		}
+	}
+	return attrs
+}
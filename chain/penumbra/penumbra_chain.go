@@ -20,6 +20,7 @@ import (
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/ibcevents"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
@@ -43,6 +44,8 @@ type PenumbraChain struct {
 	numFullNodes  int
 	PenumbraNodes PenumbraNodes
 	keyring       keyring.Keyring
+
+	counterparties ibcevents.Counterparties
 }
 
 type PenumbraValidatorDefinition struct {
@@ -89,12 +92,90 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 	}
 }
 
+// AddCounterparty adds a chain on the other end of an IBC link,
+// whose write_acknowledgement events are searched for the acknowledgement bytes.
+// Interchain.AddLink adds the counterparties of its links.
+func (c *PenumbraChain) AddCounterparty(chain ibc.Chain) {
+	c.counterparties.Add(chain)
+}
+
+// Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height.
+// Penumbra's acknowledge_packet events include neither the packet data nor the acknowledgement,
+// so they are backfilled from the send_packet event and from the counterparty's write_acknowledgement event.
 func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
+	events, err := c.packetEvents(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	acks, err := ibcevents.Acknowledgements(events)
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	if len(acks) == 0 {
+		return acks, nil
+	}
+	counterparties, err := c.counterparties.TxSearchers()
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	for i := range acks {
+		if err := c.backfillPacketData(ctx, &acks[i].Packet); err != nil {
+			return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+		}
+		if err := ibcevents.BackfillAcknowledgement(ctx, &acks[i], counterparties); err != nil {
+			return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+		}
+	}
+	return acks, nil
 }
 
+// Timeouts implements ibc.Chain, returning all timeouts in block at height.
 func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
+	events, err := c.packetEvents(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	timeouts, err := ibcevents.Timeouts(events)
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	for i := range timeouts {
+		if err := c.backfillPacketData(ctx, &timeouts[i].Packet); err != nil {
+			return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+		}
+	}
+	return timeouts, nil
+}
+
+// packetEvents returns all IBC packet events in the block at height.
+func (c *PenumbraChain) packetEvents(ctx context.Context, height uint64) ([]ibcevents.Event, error) {
+	h := int64(height)
+	res, err := c.getRelayerNode().TendermintNode.Client.BlockResults(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("block results: %w", err)
+	}
+	events := ibcevents.FromABCIEvents(res.BeginBlockEvents)
+	for _, tx := range res.TxsResults {
+		events = append(events, ibcevents.FromABCIEvents(tx.Events)...)
+	}
+	return append(events, ibcevents.FromABCIEvents(res.EndBlockEvents)...), nil
+}
+
+// backfillPacketData populates the packet data from the send_packet event that committed it.
+func (c *PenumbraChain) backfillPacketData(ctx context.Context, packet *ibc.Packet) error {
+	if len(packet.Data) > 0 {
+		return nil
+	}
+	sent, err := ibcevents.SearchPacket(ctx, c.getRelayerNode().TendermintNode.Client, ibcevents.EventTypeSendPacket, *packet)
+	if err != nil {
+		return err
+	}
+	p, err := sent.Attributes.Packet()
+	if err != nil {
+		return err
+	}
+	packet.Data = p.Data
+	return nil
 }
 
 // Implements Chain interface
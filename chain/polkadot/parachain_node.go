@@ -16,6 +16,8 @@ import (
 	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/ibcevents"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"go.uber.org/zap"
@@ -318,6 +320,15 @@ func (pn *ParachainNode) GetIbcBalance(ctx context.Context, address string, deno
 	return res, nil
 }
 
+// IbcEvents returns the IBC events pallet-ibc deposited in the block at height.
+func (pn *ParachainNode) IbcEvents(ctx context.Context, height uint64) ([]ibcevents.Event, error) {
+	res, err := pn.api.RPC.IBC.QueryIbcEvents(ctx, []gstypes.BlockNumberOrHash{{Number: uint32(height)}})
+	if err != nil {
+		return nil, err
+	}
+	return ibcevents.FromSubstrateEvents(res)
+}
+
 // SendFunds sends funds to a wallet from a user account.
 // Implements Chain interface.
 func (pn *ParachainNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
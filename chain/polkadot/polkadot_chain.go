@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	crand "crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/misko9/go-substrate-rpc-client/v4/signature"
 	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/ibcevents"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
@@ -42,6 +44,8 @@ type PolkadotChain struct {
 	RelayChainNodes    RelayChainNodes
 	ParachainNodes     []ParachainNodes
 	keyring            keyring.Keyring
+
+	counterparties ibcevents.Counterparties
 }
 
 // PolkadotAuthority is used when constructing the validator authorities in the substrate chain spec.
@@ -808,16 +812,105 @@ func (c *PolkadotChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
 	panic("[GetGasFeesInNativeDenom] not implemented yet")
 }
 
+// sendPacketSearchBlocks bounds how many blocks are searched back for the SendPacket event of a packet.
+const sendPacketSearchBlocks = 1000
+
+// AddCounterparty adds a chain on the other end of an IBC link,
+// whose write_acknowledgement events are searched for the acknowledgement bytes.
+// Interchain.AddLink adds the counterparties of its links.
+func (c *PolkadotChain) AddCounterparty(chain ibc.Chain) {
+	c.counterparties.Add(chain)
+}
+
+// ibcNode returns the parachain node serving pallet-ibc to the relayer, see GetRPCAddress.
+func (c *PolkadotChain) ibcNode() (*ParachainNode, error) {
+	if len(c.ParachainNodes) == 0 || len(c.ParachainNodes[0]) == 0 {
+		return nil, errors.New("no parachain nodes, only parachains emit IBC events")
+	}
+	return c.ParachainNodes[0][0], nil
+}
+
 // Acknowledgements returns all acknowledgements in a block at height.
+// pallet-ibc's AcknowledgePacket events do not include the acknowledgement, so it is backfilled
+// from the counterparty's write_acknowledgement event, along with the packet data if missing.
 // Implements Chain interface.
 func (c *PolkadotChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("[Acknowledgements] not implemented yet")
+	node, err := c.ibcNode()
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	events, err := node.IbcEvents(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	acks, err := ibcevents.Acknowledgements(events)
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	if len(acks) == 0 {
+		return acks, nil
+	}
+	counterparties, err := c.counterparties.TxSearchers()
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	for i := range acks {
+		if err := ibcevents.BackfillAcknowledgement(ctx, &acks[i], counterparties); err != nil {
+			return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+		}
+		if err := backfillPacketData(ctx, node, height, &acks[i].Packet); err != nil {
+			return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+		}
+	}
+	return acks, nil
 }
 
 // Timeouts returns all timeouts in a block at height.
 // Implements Chain interface.
 func (c *PolkadotChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("[Timeouts] not implemented yet")
+	node, err := c.ibcNode()
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	events, err := node.IbcEvents(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	timeouts, err := ibcevents.Timeouts(events)
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	for i := range timeouts {
+		if err := backfillPacketData(ctx, node, height, &timeouts[i].Packet); err != nil {
+			return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+		}
+	}
+	return timeouts, nil
+}
+
+// backfillPacketData populates the packet data from the SendPacket event that committed it,
+// searching back from height as pallet-ibc events cannot be queried by attribute.
+func backfillPacketData(ctx context.Context, node *ParachainNode, height uint64, packet *ibc.Packet) error {
+	if len(packet.Data) > 0 {
+		return nil
+	}
+	for h := height; h > 0 && height-h < sendPacketSearchBlocks; h-- {
+		events, err := node.IbcEvents(ctx, h)
+		if err != nil {
+			return fmt.Errorf("search SendPacket for sequence %d at height %d: %w", packet.Sequence, h, err)
+		}
+		sent, err := ibcevents.FindPacket(events, ibcevents.EventTypeSendPacket, *packet)
+		if err != nil {
+			continue
+		}
+		p, err := sent.Attributes.Packet()
+		if err != nil {
+			return err
+		}
+		packet.Data = p.Data
+		return nil
+	}
+	return fmt.Errorf("SendPacket for sequence %d on %s/%s: %w", packet.Sequence, packet.SourcePort, packet.SourceChannel, ibcevents.ErrNotFound)
 }
 
 // GetKeyringPair returns the keyring pair from the keyring using keyName
@@ -12,6 +12,8 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -49,13 +51,19 @@ func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
 // Initialize concurrently calls Initialize against each chain in the set.
 // Each chain may run a docker pull command,
 // so with a cold image cache, running concurrently may save some time.
-func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) (err error) {
+	ctx, span := tracing.Start(ctx, "chainSet.Initialize")
+	defer func() { tracing.End(span, err) }()
+
 	var eg errgroup.Group
 
 	for c := range cs.chains {
 		c := c
 		eg.Go(func() error {
-			if err := c.Initialize(ctx, testName, cli, networkID); err != nil {
+			err := tracing.Run(ctx, "initialize chain", func(ctx context.Context) error {
+				return c.Initialize(ctx, testName, cli, networkID)
+			}, chainAttrs(c)...)
+			if err != nil {
 				return fmt.Errorf("failed to initialize chain %s: %w", c.Config().Name, err)
 			}
 
@@ -102,7 +110,10 @@ func (cs *chainSet) CreateCommonAccount(ctx context.Context, keyName string) (fa
 }
 
 // Start concurrently calls Start against each chain in the set.
-func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount) error {
+func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount) (err error) {
+	ctx, span := tracing.Start(ctx, "chainSet.Start")
+	defer func() { tracing.End(span, err) }()
+
 	eg, egCtx := errgroup.WithContext(ctx)
 
 	for c := range cs.chains {
@@ -111,7 +122,10 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 			// wait for provider chains to be started up first
 			continue
 		}
-		eg.Go(func() error {
+		eg.Go(func() (err error) {
+			egCtx, span := tracing.Start(egCtx, "start chain", chainAttrs(c)...)
+			defer func() { tracing.End(span, err) }()
+
 			chainCfg := c.Config()
 			if cosmosChain, ok := c.(*cosmos.CosmosChain); ok {
 				if len(cosmosChain.Consumers) > 0 {
@@ -141,7 +155,10 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 	for c := range cs.chains {
 		c := c
 		if cosmosChain, ok := c.(*cosmos.CosmosChain); ok && cosmosChain.Provider != nil {
-			eg.Go(func() error {
+			eg.Go(func() (err error) {
+				egCtx, span := tracing.Start(egCtx, "start consumer chain", chainAttrs(c)...)
+				defer func() { tracing.End(span, err) }()
+
 				// this is a consumer chain
 				if err := cosmosChain.StartConsumer(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
 					return fmt.Errorf("failed to start consumer chain %s: %w", c.Config().Name, err)
@@ -154,20 +171,30 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 	return eg.Wait()
 }
 
+// chainAttrs identifies c in spans.
+func chainAttrs(c ibc.Chain) []attribute.KeyValue {
+	cfg := c.Config()
+	return []attribute.KeyValue{
+		attribute.String("chain.name", cfg.Name),
+		attribute.String("chain.id", cfg.ChainID),
+	}
+}
+
 // TrackBlocks initializes database tables and polls for transactions to be saved in the database.
 // This method is a nop if dbPath is blank.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
+// It returns the test case saved in the database, or nil if dbPath is blank.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) (*blockdb.TestCase, error) {
 	if len(dbPath) == 0 {
 		// nop
-		return nil
+		return nil, nil
 	}
 
 	db, err := blockdb.ConnectDB(ctx, dbPath)
 	if err != nil {
-		return fmt.Errorf("connect to sqlite database %s: %w", dbPath, err)
+		return nil, fmt.Errorf("connect to sqlite database %s: %w", dbPath, err)
 	}
 	cs.db = db
 
@@ -176,13 +203,13 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 	}
 
 	if err := blockdb.Migrate(db, gitSha); err != nil {
-		return fmt.Errorf("migrate sqlite database %s; deleting file recommended: %w", dbPath, err)
+		return nil, fmt.Errorf("migrate sqlite database %s; deleting file recommended: %w", dbPath, err)
 	}
 
 	testCase, err := blockdb.CreateTestCase(ctx, db, testName, gitSha)
 	if err != nil {
 		_ = db.Close()
-		return fmt.Errorf("create test case in sqlite database: %w", err)
+		return nil, fmt.Errorf("create test case in sqlite database: %w", err)
 	}
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
@@ -195,7 +222,7 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		finder, ok := c.(blockdb.TxFinder)
 		if !ok {
 			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height uint64) ([][]byte, error)"`+"\n", id)
-			return nil
+			return testCase, nil
 		}
 		j := i // Avoid closure on loop variable.
 		cs.trackerEg.Go(func() error {
@@ -213,7 +240,7 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		i++
 	}
 
-	return nil
+	return testCase, nil
 }
 
 // Close frees any resources associated with the chainSet.
@@ -0,0 +1,143 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+)
+
+// The value of the compare subcommand flags.
+type compareFlags struct {
+	TestName string
+	BaseSha  string
+	HeadSha  string
+
+	Thresholds blockdb.Thresholds
+}
+
+// errRegressions is returned by runCompare if the head run regressed.
+var errRegressions = errors.New("regressions found")
+
+func runCompare(ctx context.Context, w io.Writer, dbPath string, opts compareFlags) error {
+	if opts.TestName == "" {
+		return errors.New("-test is required")
+	}
+
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
+	if _, err := os.Stat(dbPath); err != nil {
+		return err
+	}
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return fmt.Errorf("connect to database %s: %w", dbPath, err)
+	}
+	defer db.Close()
+
+	if err = blockdb.Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", dbPath, err)
+	}
+
+	querySvc := blockdb.NewQuery(db)
+
+	runs, err := querySvc.TestCaseRuns(ctx, opts.TestName)
+	if err != nil {
+		return fmt.Errorf("query runs of %s: %w", opts.TestName, err)
+	}
+	base, head, err := selectRuns(runs, opts.BaseSha, opts.HeadSha)
+	if err != nil {
+		return fmt.Errorf("test case %s: %w", opts.TestName, err)
+	}
+
+	baseStats, err := querySvc.RunStats(ctx, base.ID)
+	if err != nil {
+		return err
+	}
+	headStats, err := querySvc.RunStats(ctx, head.ID)
+	if err != nil {
+		return err
+	}
+
+	cmp := blockdb.Compare(baseStats, headStats, opts.Thresholds)
+	if err := printComparison(w, cmp); err != nil {
+		return err
+	}
+	if len(cmp.Regressions) > 0 {
+		return errRegressions
+	}
+	return nil
+}
+
+// selectRuns picks the most recent runs matching the git sha prefixes, runs must be sorted most recent first.
+// An empty headSha picks the most recent run. An empty baseSha picks the most recent run before head from a different sha.
+func selectRuns(runs []blockdb.TestCaseRun, baseSha, headSha string) (base, head blockdb.TestCaseRun, err error) {
+	if len(runs) == 0 {
+		return base, head, errors.New("no runs found")
+	}
+
+	find := func(match func(blockdb.TestCaseRun) bool) (blockdb.TestCaseRun, bool) {
+		for _, run := range runs {
+			if match(run) {
+				return run, true
+			}
+		}
+		return blockdb.TestCaseRun{}, false
+	}
+
+	head, ok := find(func(run blockdb.TestCaseRun) bool { return strings.HasPrefix(run.GitSha, headSha) })
+	if !ok {
+		return base, head, fmt.Errorf("no run found for head git sha %q", headSha)
+	}
+
+	if baseSha == "" {
+		base, ok = find(func(run blockdb.TestCaseRun) bool { return run.ID < head.ID && run.GitSha != head.GitSha })
+		if !ok {
+			return base, head, fmt.Errorf("no run found before head from a git sha other than %s", head.GitSha)
+		}
+		return base, head, nil
+	}
+
+	base, ok = find(func(run blockdb.TestCaseRun) bool {
+		return run.ID != head.ID && strings.HasPrefix(run.GitSha, baseSha)
+	})
+	if !ok {
+		return base, head, fmt.Errorf("no run found for base git sha %q", baseSha)
+	}
+	return base, head, nil
+}
+
+func printComparison(w io.Writer, cmp blockdb.Comparison) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Base:\t%s\t(run %d, %s)\n", cmp.Base.GitSha, cmp.Base.ID, cmp.Base.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(tw, "Head:\t%s\t(run %d, %s)\n", cmp.Head.GitSha, cmp.Head.ID, cmp.Head.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	for _, c := range cmp.Chains {
+		fmt.Fprintf(tw, "\nChain %s\tBase\tHead\n", c.ChainID)
+		fmt.Fprintf(tw, "Txs\t%d\t%d\n", c.Base.TxTotal, c.Head.TxTotal)
+		fmt.Fprintf(tw, "Failed txs\t%d\t%d\n", c.Base.FailedTxs, c.Head.FailedTxs)
+		fmt.Fprintf(tw, "Block time p50\t%s\t%s\n", c.Base.BlockTimes.P50, c.Head.BlockTimes.P50)
+		fmt.Fprintf(tw, "Block time p90\t%s\t%s\n", c.Base.BlockTimes.P90, c.Head.BlockTimes.P90)
+		fmt.Fprintf(tw, "Block time p99\t%s\t%s\n", c.Base.BlockTimes.P99, c.Head.BlockTimes.P99)
+		for _, m := range c.Messages {
+			fmt.Fprintf(tw, "%s\t%d msgs, %d avg gas\t%d msgs, %d avg gas\n", m.Type, m.Base.Count, m.Base.AvgGas(), m.Head.Count, m.Head.AvgGas())
+		}
+	}
+
+	if len(cmp.Regressions) == 0 {
+		fmt.Fprintln(tw, "\nNo regressions.")
+	} else {
+		fmt.Fprintln(tw, "\nRegressions:")
+		for _, r := range cmp.Regressions {
+			fmt.Fprintf(tw, "  %s\n", r)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,46 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectRuns(t *testing.T) {
+	runs := []blockdb.TestCaseRun{
+		{ID: 4, GitSha: "ccc111"},
+		{ID: 3, GitSha: "ccc111"},
+		{ID: 2, GitSha: "bbb222"},
+		{ID: 1, GitSha: "aaa333"},
+	}
+
+	for _, tt := range []struct {
+		Name             string
+		Base, Head       string
+		WantBase, WantHd int64
+	}{
+		{"defaults", "", "", 2, 4},
+		{"head only", "", "bbb", 1, 2},
+		{"base only", "aaa", "", 1, 4},
+		{"same sha", "ccc", "ccc", 3, 4},
+		{"both", "bbb222", "aaa", 2, 1},
+	} {
+		base, head, err := selectRuns(runs, tt.Base, tt.Head)
+		require.NoError(t, err, tt.Name)
+		require.Equal(t, tt.WantBase, base.ID, tt.Name)
+		require.Equal(t, tt.WantHd, head.ID, tt.Name)
+	}
+
+	_, _, err := selectRuns(nil, "", "")
+	require.Error(t, err)
+
+	_, _, err = selectRuns(runs, "", "ddd")
+	require.Error(t, err)
+
+	_, _, err = selectRuns(runs[:2], "", "")
+	require.Error(t, err)
+
+	_, _, err = selectRuns(runs, "ddd", "")
+	require.Error(t, err)
+}
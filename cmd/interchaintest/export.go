@@ -0,0 +1,86 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+)
+
+// The value of the export subcommand flags.
+type exportFlags struct {
+	TestName string
+	GitSha   string
+	Format   string
+	Dir      string
+}
+
+func runExport(ctx context.Context, w io.Writer, dbPath string, opts exportFlags) error {
+	if opts.TestName == "" {
+		return errors.New("-test is required")
+	}
+	format, err := blockdb.ParseExportFormat(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
+	if _, err := os.Stat(dbPath); err != nil {
+		return err
+	}
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return fmt.Errorf("connect to database %s: %w", dbPath, err)
+	}
+	defer db.Close()
+
+	if err = blockdb.Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", dbPath, err)
+	}
+
+	querySvc := blockdb.NewQuery(db)
+
+	runs, err := querySvc.TestCaseRuns(ctx, opts.TestName)
+	if err != nil {
+		return fmt.Errorf("query runs of %s: %w", opts.TestName, err)
+	}
+	run, err := latestRun(runs, opts.GitSha)
+	if err != nil {
+		return fmt.Errorf("test case %s: %w", opts.TestName, err)
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = fmt.Sprintf("blockdb-export-%d", run.ID)
+	}
+
+	paths, err := querySvc.Export(ctx, run.ID, format, dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Exported run %d of %s at %s:\n", run.ID, run.Name, run.GitSha)
+	for _, p := range paths {
+		fmt.Fprintf(w, "  %s\n", p)
+	}
+	return nil
+}
+
+// latestRun returns the most recent run whose git sha starts with gitSha, runs must be sorted most recent first.
+func latestRun(runs []blockdb.TestCaseRun, gitSha string) (blockdb.TestCaseRun, error) {
+	for _, run := range runs {
+		if strings.HasPrefix(run.GitSha, gitSha) {
+			return run, nil
+		}
+	}
+	if len(runs) == 0 {
+		return blockdb.TestCaseRun{}, errors.New("no runs found")
+	}
+	return blockdb.TestCaseRun{}, fmt.Errorf("no run found for git sha %q", gitSha)
+}
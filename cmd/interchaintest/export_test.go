@@ -0,0 +1,30 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestRun(t *testing.T) {
+	runs := []blockdb.TestCaseRun{
+		{ID: 3, GitSha: "bbb222"},
+		{ID: 2, GitSha: "aaa111"},
+		{ID: 1, GitSha: "aaa111"},
+	}
+
+	run, err := latestRun(runs, "")
+	require.NoError(t, err)
+	require.EqualValues(t, 3, run.ID)
+
+	run, err = latestRun(runs, "aaa")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, run.ID)
+
+	_, err = latestRun(runs, "ccc")
+	require.Error(t, err)
+
+	_, err = latestRun(nil, "")
+	require.Error(t, err)
+}
@@ -18,7 +18,11 @@ type mainFlags struct {
 	LogLevel          string
 	MatrixFile        string
 	ReportFile        string
+	ReportFormat      string
+	ArtifactsDir      string
+	TraceExporter     string
 	BlockDatabaseFile string
+	FollowBlocks      bool
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
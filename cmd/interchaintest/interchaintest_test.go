@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,14 @@ func init() {
 `)
 		debugFlagSet.PrintDefaults()
 		fmt.Fprint(out, `
+  compare  Compare two runs of a test case across git commits and report regressions.
+`)
+		compareFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  export  Export the chains, blocks, transactions and events of a test case to csv, jsonl or parquet files.
+`)
+		exportFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
   version  Prints git commit that produced executable.
 `)
 	}
@@ -48,7 +57,11 @@ var testMatrix struct {
 	ChainSets [][]*interchaintest.ChainSpec
 }
 
-var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
+var (
+	debugFlagSet   = flag.NewFlagSet("debug", flag.ExitOnError)
+	compareFlagSet = flag.NewFlagSet("compare", flag.ExitOnError)
+	exportFlagSet  = flag.NewFlagSet("export", flag.ExitOnError)
+)
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
@@ -64,6 +77,18 @@ func TestMain(m *testing.M) {
 			os.Exit(1)
 		}
 		os.Exit(0)
+	case "compare":
+		if err := runCompare(ctx, os.Stdout, extraFlags.BlockDatabaseFile, compareOpts); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to compare: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "export":
+		if err := runExport(ctx, os.Stdout, extraFlags.BlockDatabaseFile, exportOpts); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to export: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	case "version":
 		fmt.Fprintln(os.Stderr, version.GitSha)
 		os.Exit(0)
@@ -84,24 +109,41 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := interchaintest.SetupTracing(ctx, extraFlags.TraceExporter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failure setting up tracing: %v\n", err)
+		os.Exit(1)
+	}
+
 	code := m.Run()
 
+	if err := shutdownTracing(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failure flushing traces: %v\n", err)
+	}
+
 	if err := reporter.Close(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failure closing test reporter: %v\n", err)
 		// Don't os.Exit here, since we already have an exit code from running the tests.
+	} else if err := convertReport(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failure converting test report: %v\n", err)
 	}
 
 	os.Exit(code)
 }
 
-var extraFlags mainFlags
+var (
+	extraFlags  mainFlags
+	compareOpts compareFlags
+	exportOpts  exportFlags
+)
 
 // setUpTestMatrix populates the testMatrix singleton with
 // the parsed contents of the file referenced by the matrix flag,
-// or with a small reasonable default of rly against one gaia-osmosis set.
+// or with a small reasonable default of rly against one gaia-osmosis set,
+// and one gaia-juno-osmosis set for packet forwarding through juno.
 func setUpTestMatrix() error {
 	if extraFlags.MatrixFile == "" {
-		fmt.Fprintln(os.Stderr, "No matrix file provided, falling back to rly with gaia and osmosis")
+		fmt.Fprintln(os.Stderr, "No matrix file provided, falling back to rly with gaia and osmosis, and gaia, juno and osmosis")
 
 		testMatrix.Relayers = []string{"rly", "hermes"}
 		testMatrix.ChainSets = [][]*interchaintest.ChainSpec{
@@ -109,6 +151,12 @@ func setUpTestMatrix() error {
 				{Name: "gaia", Version: "v7.0.1"},
 				{Name: "osmosis", Version: "v7.2.0"},
 			},
+			{
+				{Name: "gaia", Version: "v7.0.1"},
+				// The middle chain must run packet forward middleware.
+				{Name: "juno", Version: "v16.0.0"},
+				{Name: "osmosis", Version: "v7.2.0"},
+			},
 		}
 
 		return nil
@@ -145,20 +193,33 @@ func validateTestMatrix() error {
 	return nil
 }
 
-var reporter *testreporter.Reporter
+var (
+	reporter      *testreporter.Reporter
+	reportPath    string
+	reportFormats []testreporter.Format
+)
 
 func configureTestReporter() error {
-	home, err := os.UserHomeDir()
+	formats, err := testreporter.ParseFormats(extraFlags.ReportFormat)
 	if err != nil {
-		return fmt.Errorf("failed to get user home dir: %w", err)
+		return err
+	}
+	reportFormats = formats
+
+	reportPath = extraFlags.ReportFile
+	if reportPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get user home dir: %w", err)
+		}
+		reportPath = filepath.Join(home, ".interchaintest", "reports", fmt.Sprintf("%d.json", time.Now().Unix()))
 	}
-	fpath := filepath.Join(home, ".interchaintest", "reports")
-	err = os.MkdirAll(fpath, 0755)
+	err = os.MkdirAll(filepath.Dir(reportPath), 0755)
 	if err != nil {
 		return fmt.Errorf("mkdirall: %w", err)
 	}
 
-	f, err := os.Create(filepath.Join(fpath, fmt.Sprintf("%d.json", time.Now().Unix())))
+	f, err := os.Create(reportPath)
 	if err != nil {
 		return err
 	}
@@ -166,6 +227,44 @@ func configureTestReporter() error {
 	fmt.Fprintf(os.Stderr, "Writing report to %s\n", f.Name())
 
 	reporter = testreporter.NewReporter(f)
+	interchaintest.CaptureContainerLogsOnFailure(reporter, extraFlags.ArtifactsDir)
+	return nil
+}
+
+// convertReport writes the closed JSON report in each of the -report-format formats,
+// next to the JSON report with the extension of the format.
+func convertReport() error {
+	for _, format := range reportFormats {
+		if format == testreporter.FormatJSON {
+			continue
+		}
+		if err := convertReportTo(format); err != nil {
+			return fmt.Errorf("convert report to %s: %w", format, err)
+		}
+	}
+	return nil
+}
+
+func convertReportTo(format testreporter.Format) error {
+	in, err := os.Open(reportPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	outPath := strings.TrimSuffix(reportPath, filepath.Ext(reportPath)) + format.Ext()
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	if err := testreporter.Convert(out, in, format); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s report to %s\n", format, outPath)
 	return nil
 }
 
@@ -181,8 +280,8 @@ func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerF
 }
 
 func getChainFactory(log *zap.Logger, chainSpecs []*interchaintest.ChainSpec) (interchaintest.ChainFactory, error) {
-	if len(chainSpecs) != 2 {
-		return nil, fmt.Errorf("chain specs must have length 2 (found a chain set of length %d)", len(chainSpecs))
+	if len(chainSpecs) != 2 && len(chainSpecs) != 3 {
+		return nil, fmt.Errorf("chain specs must have length 2 or 3 (found a chain set of length %d)", len(chainSpecs))
 	}
 	return interchaintest.NewBuiltinChainFactory(log, chainSpecs), nil
 }
@@ -247,8 +346,26 @@ func addFlags() {
 	flag.StringVar(&extraFlags.LogFormat, "log-format", "console", "Chain and relayer log format: console|json")
 	flag.StringVar(&extraFlags.LogLevel, "log-level", "info", "Chain and relayer log level: debug|info|error")
 	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.StringVar(&extraFlags.TraceExporter, "trace", "", "Write OpenTelemetry spans of interchain builds in the OTLP JSON format: stdout|file:$PATH. Defaults to $IBCTEST_TRACE.")
+	flag.StringVar(&extraFlags.ArtifactsDir, "artifacts-dir", "", "Directory to write the logs of the containers of failed tests to, one directory per test. The logs are always included in the test report.")
+	flag.StringVar(&extraFlags.ReportFormat, "report-format", "json", "Comma separated report formats: json|junit|html. The JSON report is always written; junit and html reports are written next to it with .xml and .html extensions.")
 
 	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	debugFlagSet.BoolVar(&extraFlags.FollowBlocks, "follow", false, "Start in follow mode, refreshing the UI as running tests save new blocks and transactions. Toggle with f.")
+
+	compareFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	compareFlagSet.StringVar(&compareOpts.TestName, "test", "", "Name of the test case to compare, e.g. TestConformance/gaia_v7.0.1+osmosis_v7.2.0/rly/relay. Required.")
+	compareFlagSet.StringVar(&compareOpts.BaseSha, "base", "", "Git sha (or prefix) of the base run. Defaults to the most recent run from a different sha than head.")
+	compareFlagSet.StringVar(&compareOpts.HeadSha, "head", "", "Git sha (or prefix) of the head run. Defaults to the most recent run.")
+	compareFlagSet.Float64Var(&compareOpts.Thresholds.GasIncrease, "max-gas-increase", blockdb.DefaultThresholds.GasIncrease, "Fraction the average gas of a message type may increase before reporting a regression.")
+	compareFlagSet.Float64Var(&compareOpts.Thresholds.BlockTimeIncrease, "max-block-time-increase", blockdb.DefaultThresholds.BlockTimeIncrease, "Fraction the p50 and p90 block times may increase before reporting a regression.")
+	compareFlagSet.Int64Var(&compareOpts.Thresholds.FailedTxIncrease, "max-failed-tx-increase", blockdb.DefaultThresholds.FailedTxIncrease, "Number of additional failed transactions per chain before reporting a regression.")
+
+	exportFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	exportFlagSet.StringVar(&exportOpts.TestName, "test", "", "Name of the test case to export. Required.")
+	exportFlagSet.StringVar(&exportOpts.GitSha, "sha", "", "Git sha (or prefix) of the run to export. Defaults to the most recent run.")
+	exportFlagSet.StringVar(&exportOpts.Format, "format", string(blockdb.ExportCSV), "File format: csv|jsonl|parquet")
+	exportFlagSet.StringVar(&exportOpts.Dir, "out", "", "Directory to write one file per table to. Defaults to blockdb-export-$RUN_ID.")
 }
 
 func parseFlags() {
@@ -257,6 +374,10 @@ func parseFlags() {
 	case "debug":
 		// Ignore errors because configured with flag.ExitOnError.
 		_ = debugFlagSet.Parse(os.Args[2:])
+	case "compare":
+		_ = compareFlagSet.Parse(os.Args[2:])
+	case "export":
+		_ = exportFlagSet.Parse(os.Args[2:])
 	}
 }
 
@@ -289,7 +410,7 @@ func runDebugTerminalUI(ctx context.Context) error {
 		return fmt.Errorf("query schema version: %w", err)
 	}
 
-	testCases, err := querySvc.RecentTestCases(ctx, 100)
+	testCases, err := querySvc.RecentTestCases(ctx, blockdbtui.RecentTestCasesLimit)
 	if err != nil {
 		return fmt.Errorf("query recent test cases: %w", err)
 	}
@@ -299,6 +420,24 @@ func runDebugTerminalUI(ctx context.Context) error {
 
 	app := tview.NewApplication()
 	model := blockdbtui.NewModel(blockdb.NewQuery(db), dbPath, schemaInfo.GitSha, schemaInfo.CreatedAt, testCases)
+	model.SetFollow(extraFlags.FollowBlocks)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		// Refresh is a no-op unless follow mode is on.
+		tick := time.NewTicker(time.Second)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+				app.QueueUpdateDraw(func() { model.Refresh(ctx) })
+			}
+		}
+	}()
+
 	return app.
 		SetInputCapture(model.Update(ctx)).
 		SetRoot(model.RootView(), true).
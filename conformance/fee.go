@@ -0,0 +1,157 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	recvFeeAmount    = int64(1_000)
+	ackFeeAmount     = int64(500)
+	timeoutFeeAmount = int64(250)
+)
+
+// FeeChannelOpts returns the settings for creating an ics20 transfer channel wrapped by ICS-29 fee middleware.
+func FeeChannelOpts() ibc.CreateChannelOptions {
+	opts := ibc.DefaultChannelOpts()
+	opts.Version = `{"fee_version":"ics29-1","app_version":"ics20-1"}`
+	return opts
+}
+
+// TestRelayerFeeMiddleware opens a fee enabled transfer channel, registers a payee for the relayer on both chains
+// and asserts the payee collects the receive and acknowledgement fees once the relayer delivers an incentivized packet.
+func TestRelayerFeeMiddleware(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.FeeMiddleware)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	src, ok := c0.(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "fee middleware requires cosmos chains, got %T", c0)
+	}
+	dst, ok := c1.(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "fee middleware requires cosmos chains, got %T", c1)
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: FeeChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "fee", userFaucetFund, c0, c0, c1)
+	sender, payee, receiver := users[0], users[1], users[2]
+	req.NoError(testutil.WaitForBlocks(ctx, 2, c0, c1))
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	srcRelayer, ok := r.GetWallet(c0.Config().ChainID)
+	req.True(ok, "relayer wallet not found on %s", c0.Config().ChainID)
+	dstRelayer, ok := r.GetWallet(c1.Config().ChainID)
+	req.True(ok, "relayer wallet not found on %s", c1.Config().ChainID)
+
+	// Payee registration must be signed by the relayer, so import its keys into the chains' keyrings.
+	const relayerKeyName = "fee-relayer"
+	req.NoError(src.RecoverKey(ctx, relayerKeyName, srcRelayer.Mnemonic()))
+	req.NoError(dst.RecoverKey(ctx, relayerKeyName, dstRelayer.Mnemonic()))
+
+	// Acknowledgement and timeout fees are paid on the source chain to the registered payee.
+	req.NoError(src.GetNode().RegisterPayee(ctx, relayerKeyName,
+		channel.PortID, channel.ChannelID, srcRelayer.FormattedAddress(), payee.FormattedAddress()))
+	// Receive fees are paid on the source chain to the counterparty payee registered on the destination chain.
+	req.NoError(dst.GetNode().RegisterCounterpartyPayee(ctx, relayerKeyName,
+		channel.Counterparty.PortID, channel.Counterparty.ChannelID, dstRelayer.FormattedAddress(), payee.FormattedAddress()))
+
+	denom := c0.Config().Denom
+	payeeInitialBalance, err := c0.GetBalance(ctx, payee.FormattedAddress(), denom)
+	req.NoError(err)
+
+	tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, sender.KeyName(), ibc.WalletAmount{
+		Address: receiver.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(c1.Config().Bech32Prefix),
+		Denom:   denom,
+		Amount:  math.NewInt(testCoinAmount),
+	}, ibc.TransferOptions{})
+	req.NoError(err)
+	req.NoError(tx.Validate())
+
+	req.NoError(src.GetNode().PayPacketFee(ctx, sender.KeyName(), channel.PortID, channel.ChannelID, tx.Packet.Sequence,
+		fmt.Sprintf("%d%s", recvFeeAmount, denom),
+		fmt.Sprintf("%d%s", ackFeeAmount, denom),
+		fmt.Sprintf("%d%s", timeoutFeeAmount, denom),
+	))
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	t.Run("relayer rewards", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		afterTransferHeight, err := c0.Height(ctx)
+		req.NoError(err)
+
+		ack, err := testutil.PollForAck(ctx, c0, tx.Height, afterTransferHeight+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement on source chain")
+		req.NoError(ack.Validate(), "invalid acknowledgement on source chain")
+
+		// Fees are distributed in the same block as the acknowledgement, but give the query node a moment to catch up.
+		req.NoError(testutil.WaitForBlocks(ctx, 2, c0))
+
+		payeeFinalBalance, err := c0.GetBalance(ctx, payee.FormattedAddress(), denom)
+		req.NoError(err)
+
+		// The timeout fee is refunded to the sender when the packet is acknowledged.
+		req.Truef(payeeFinalBalance.Equal(payeeInitialBalance.AddRaw(recvFeeAmount+ackFeeAmount)),
+			"expected payee balance %s, got %s", payeeInitialBalance.AddRaw(recvFeeAmount+ackFeeAmount), payeeFinalBalance)
+	})
+}
@@ -0,0 +1,201 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// ForwardMetadata is the packet forward middleware payload carried in an ICS-20 memo.
+type ForwardMetadata struct {
+	Receiver string `json:"receiver"`
+	Port     string `json:"port"`
+	Channel  string `json:"channel"`
+	Timeout  string `json:"timeout,omitempty"`
+	Retries  *uint8 `json:"retries,omitempty"`
+}
+
+// ForwardMemo returns the ICS-20 memo instructing packet forward middleware to forward the transfer over the next hop.
+func ForwardMemo(metadata ForwardMetadata) (string, error) {
+	memo, err := json.Marshal(map[string]ForwardMetadata{"forward": metadata})
+	if err != nil {
+		return "", err
+	}
+	return string(memo), nil
+}
+
+// TestPacketForwarding sends ICS-20 transfers from the first to the third chain through the second,
+// using packet forward middleware memos. It asserts that the funds arrive with the multi-hop denom trace,
+// and that the sender is refunded when the second hop times out.
+func TestPacketForwarding(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.PacketForward)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 3 {
+		panic(fmt.Errorf("expected 3 chains, got %d", len(chains)))
+	}
+
+	chainA, chainB, chainC := chains[0], chains[1], chains[2]
+
+	r := rf.Build(t, client, network)
+
+	const pathAB, pathBC = "ab", "bc"
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddChain(chainC).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+
+			Path:              pathAB,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainB,
+			Chain2:  chainC,
+			Relayer: r,
+
+			Path:              pathBC,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	abChannels, err := r.GetChannels(ctx, eRep, chainA.Config().ChainID)
+	req.NoError(err)
+	req.Len(abChannels, 1)
+	abChan := abChannels[0]
+
+	bChannels, err := r.GetChannels(ctx, eRep, chainB.Config().ChainID)
+	req.NoError(err)
+	req.Len(bChannels, 2)
+	bcChan := bChannels[0]
+	if bcChan.ChannelID == abChan.Counterparty.ChannelID {
+		bcChan = bChannels[1]
+	}
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "pfm", userFaucetFund, chainA, chainB, chainC)
+	userA, userB, userC := users[0], users[1], users[2]
+	req.NoError(testutil.WaitForBlocks(ctx, 2, chainA, chainB, chainC))
+
+	srcDenom := chainA.Config().Denom
+	// Denom of chain A's native token after travelling A -> B -> C.
+	dstIbcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(bcChan.Counterparty.PortID, bcChan.Counterparty.ChannelID,
+			transfertypes.GetPrefixedDenom(abChan.Counterparty.PortID, abChan.Counterparty.ChannelID, srcDenom)),
+	).IBCDenom()
+
+	receiverC := userC.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(chainC.Config().Bech32Prefix)
+
+	sendForward := func(timeout string) ibc.Tx {
+		var noRetries uint8
+		memo, err := ForwardMemo(ForwardMetadata{
+			Receiver: receiverC,
+			Port:     bcChan.PortID,
+			Channel:  bcChan.ChannelID,
+			Timeout:  timeout,
+			Retries:  &noRetries,
+		})
+		req.NoError(err)
+
+		tx, err := chainA.SendIBCTransfer(ctx, abChan.ChannelID, userA.KeyName(), ibc.WalletAmount{
+			Address: userB.(*cosmos.CosmosWallet).FormattedAddressWithPrefix(chainB.Config().Bech32Prefix),
+			Denom:   srcDenom,
+			Amount:  math.NewInt(testCoinAmount),
+		}, ibc.TransferOptions{Memo: memo})
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		return tx
+	}
+
+	// Only relay the first hop, so that the forwarded packet times out before it can be delivered.
+	req.NoError(r.StartRelayer(ctx, eRep, pathAB))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	})
+
+	timeoutTx := sendForward("1s")
+
+	// Give the relayer time to deliver the first hop and for the forward timeout to elapse.
+	req.NoError(testutil.WaitForBlocks(ctx, 10, chainA, chainB))
+
+	req.NoError(r.StopRelayer(ctx, eRep))
+	req.NoError(r.StartRelayer(ctx, eRep, pathAB, pathBC))
+	time.Sleep(5 * time.Second)
+
+	t.Run("refund on forward timeout", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		height, err := chainA.Height(ctx)
+		req.NoError(err)
+
+		// The first hop is acknowledged with an error once the forwarded packet times out on the second hop.
+		_, err = testutil.PollForAck(ctx, chainA, timeoutTx.Height, height+pollHeightMax, timeoutTx.Packet)
+		req.NoError(err, "failed to get acknowledgement on first chain")
+
+		req.NoError(testutil.WaitForBlocks(ctx, 2, chainA))
+
+		balanceA, err := chainA.GetBalance(ctx, userA.FormattedAddress(), srcDenom)
+		req.NoError(err)
+		expected := math.NewInt(userFaucetFund).SubRaw(chainA.GetGasFeesInNativeDenom(timeoutTx.GasSpent))
+		req.Truef(balanceA.Equal(expected), "expected refunded balance %s, got %s", expected, balanceA)
+
+		balanceC, err := chainC.GetBalance(ctx, receiverC, dstIbcDenom)
+		req.NoError(err)
+		req.True(balanceC.IsZero(), "expected no funds on final chain, got %s", balanceC)
+	})
+
+	t.Run("multi-hop transfer", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		tx := sendForward("10m")
+
+		height, err := chainA.Height(ctx)
+		req.NoError(err)
+
+		ack, err := testutil.PollForAck(ctx, chainA, tx.Height, height+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement on first chain")
+		req.NoError(ack.Validate(), "invalid acknowledgement on first chain")
+
+		req.NoError(testutil.WaitForBlocks(ctx, 2, chainC))
+
+		balanceC, err := chainC.GetBalance(ctx, receiverC, dstIbcDenom)
+		req.NoError(err)
+		req.Truef(balanceC.Equal(math.NewInt(testCoinAmount)), "expected %d%s on final chain, got %s", testCoinAmount, dstIbcDenom, balanceC)
+	})
+}
@@ -0,0 +1,162 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const icaControllerPortPrefix = "icacontroller-"
+
+// TestInterchainAccounts registers an interchain account on the first chain's connection
+// and asserts the relayer completes the ordered channel handshake on both ends.
+// It then lets an interchain account packet time out, asserting the channel closes
+// and that registering again re-opens a channel to the same account.
+func TestInterchainAccounts(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	// Interchain account channels are ordered in ibc-go v7.
+	requireCapabilities(t, rep, rf, relayer.InterchainAccounts, relayer.OrderedChannels)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	controller, ok := c0.(*cosmos.CosmosChain)
+	if !ok {
+		rep.TrackSkip(t, "interchain accounts require a cosmos controller chain, got %T", c0)
+	}
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	connections, err := r.GetConnections(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.NotEmpty(connections)
+	connectionID := connections[0].ID
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "ica", userFaucetFund, c0)
+	owner := users[0]
+	req.NoError(testutil.WaitForBlocks(ctx, 2, c0))
+
+	_, err = controller.GetNode().RegisterInterchainAccount(ctx, owner.KeyName(), connectionID, cosmos.ICAOptions{})
+	req.NoError(err, "failed to register interchain account")
+
+	var icaChannel ibc.ChannelOutput
+
+	t.Run("channel open", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		channel, err := controller.WaitForICAChannelOpen(ctx, connectionID, owner.FormattedAddress(), 2*time.Minute)
+		req.NoError(err)
+		icaChannel = channel
+
+		req.Equal(icaControllerPortPrefix+owner.FormattedAddress(), channel.PortID)
+		req.Equal("ORDER_ORDERED", channel.Ordering)
+		req.Equal("icahost", channel.Counterparty.PortID)
+		req.True(strings.Contains(channel.Version, "ics27-1"), "unexpected channel version %s", channel.Version)
+	})
+
+	t.Run("channel closes on timeout and re-opens", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+		req.NotEmpty(icaChannel.ChannelID, "interchain account channel never opened")
+
+		icaAddr, err := controller.GetNode().QueryInterchainAccount(ctx, connectionID, owner.FormattedAddress())
+		req.NoError(err)
+		req.NotEmpty(icaAddr)
+
+		// Hold back the relayer so the packet cannot be delivered before it times out.
+		req.NoError(r.StopRelayer(ctx, eRep))
+
+		// The message is never executed, it only needs to be valid for the host chain.
+		msg := &banktypes.MsgSend{
+			FromAddress: icaAddr,
+			ToAddress:   icaAddr,
+			Amount:      types.NewCoins(types.NewInt64Coin(c1.Config().Denom, 1)),
+		}
+		_, err = cosmos.SendICATx(ctx, cosmos.NewBroadcaster(t, controller), owner, connectionID, time.Second, "", msg)
+		req.NoError(err, "failed to send interchain account tx")
+
+		req.NoError(testutil.WaitForBlocks(ctx, 5, c0, c1))
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+
+		// A timeout on an ordered channel closes it.
+		req.NoError(testutil.WaitForCondition(2*time.Minute, 5*time.Second, func() (bool, error) {
+			channels, err := controller.InterchainAccountChannels(ctx, connectionID, owner.FormattedAddress())
+			if err != nil {
+				return false, err
+			}
+			for _, c := range channels {
+				if c.ChannelID == icaChannel.ChannelID {
+					return c.State == "STATE_CLOSED", nil
+				}
+			}
+			return false, nil
+		}), "interchain account channel never closed after timeout")
+
+		// Registering again opens a new channel to the same account.
+		_, err = controller.GetNode().RegisterInterchainAccount(ctx, owner.KeyName(), connectionID, cosmos.ICAOptions{})
+		req.NoError(err, "failed to re-register interchain account")
+
+		reopened, err := controller.WaitForICAChannelOpen(ctx, connectionID, owner.FormattedAddress(), 2*time.Minute)
+		req.NoError(err)
+		req.NotEqual(icaChannel.ChannelID, reopened.ChannelID)
+
+		reopenedAddr, err := controller.GetNode().QueryInterchainAccount(ctx, connectionID, owner.FormattedAddress())
+		req.NoError(err)
+		req.Equal(icaAddr, reopenedAddr, "interchain account address changed after re-opening the channel")
+	})
+}
@@ -66,12 +66,16 @@ type RelayerTestCase struct {
 	Users []ibc.Wallet
 	// temp storage in between test phases
 	TxCache TxCache
+	// why the started chains do not support this test, see RelayerTestCaseConfig.RequiredChainSupport
+	unsupported error
 }
 
 type RelayerTestCaseConfig struct {
 	Name string
 	// which relayer capabilities are required to run this test
 	RequiredRelayerCapabilities []relayer.Capability
+	// checks the started chains support this test, which is skipped with the returned error otherwise
+	RequiredChainSupport func(ctx context.Context, srcChain, dstChain ibc.Chain) error
 	// function to run after the chains are started but before the relayer is started
 	// e.g. send a transfer and wait for it to timeout so that the relayer will handle it once it is timed out
 	PreRelayerStart func(context.Context, *testing.T, *RelayerTestCase, ibc.Chain, ibc.Chain, []ibc.ChannelOutput)
@@ -90,6 +94,12 @@ var relayerTestCaseConfigs = [...]RelayerTestCaseConfig{
 		PreRelayerStart: preRelayerStart_NoTimeout,
 		Test:            testPacketRelaySuccess,
 	},
+	{
+		Name:                 "relay packet with memo",
+		RequiredChainSupport: requireTransferMemo,
+		PreRelayerStart:      preRelayerStart_Memo,
+		Test:                 testPacketRelaySuccess,
+	},
 	{
 		Name:                        "height timeout",
 		RequiredRelayerCapabilities: []relayer.Capability{relayer.HeightTimeout},
@@ -115,6 +125,25 @@ func requireCapabilities(t *testing.T, rep *testreporter.Reporter, rf interchain
 	}
 }
 
+// requireTransferMemo checks the transfer command of cosmos chains accepts an ICS-20 memo,
+// which ibc-go added in v6 and backported to v3.4, v4.2 and v5.1.
+func requireTransferMemo(ctx context.Context, srcChain, dstChain ibc.Chain) error {
+	for _, chain := range []ibc.Chain{srcChain, dstChain} {
+		cosmosChain, ok := chain.(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
+		stdout, _, err := cosmosChain.GetNode().ExecBin(ctx, "tx", "ibc-transfer", "transfer", "--help")
+		if err != nil {
+			return fmt.Errorf("failed to get transfer help of %s: %w", chain.Config().ChainID, err)
+		}
+		if !strings.Contains(string(stdout), "--memo") {
+			return fmt.Errorf("%s does not support ICS-20 memos", chain.Config().ChainID)
+		}
+	}
+	return nil
+}
+
 func missingCapabilities(rf interchaintest.RelayerFactory, reqCaps ...relayer.Capability) []relayer.Capability {
 	caps := rf.Capabilities()
 	var missing []relayer.Capability
@@ -134,6 +163,18 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 	dstChain ibc.Chain,
 	channels []ibc.ChannelOutput,
 	timeout *ibc.IBCTimeout,
+) {
+	sendIBCTransfersFromBothChains(ctx, t, testCase, srcChain, dstChain, channels, ibc.TransferOptions{Timeout: timeout})
+}
+
+func sendIBCTransfersFromBothChains(
+	ctx context.Context,
+	t *testing.T,
+	testCase *RelayerTestCase,
+	srcChain ibc.Chain,
+	dstChain ibc.Chain,
+	channels []ibc.ChannelOutput,
+	opts ibc.TransferOptions,
 ) {
 	srcChainCfg := srcChain.Config()
 	srcUser := testCase.Users[0]
@@ -161,7 +202,7 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 	eg.Go(func() (err error) {
 		for i, channel := range channels {
 			srcChannelID := channel.ChannelID
-			srcTxs[i], err = srcChain.SendIBCTransfer(ctx, srcChannelID, srcUser.KeyName(), testCoinSrcToDst, ibc.TransferOptions{Timeout: timeout})
+			srcTxs[i], err = srcChain.SendIBCTransfer(ctx, srcChannelID, srcUser.KeyName(), testCoinSrcToDst, opts)
 			if err != nil {
 				return fmt.Errorf("failed to send ibc transfer from source: %w", err)
 			}
@@ -175,7 +216,7 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 	eg.Go(func() (err error) {
 		for i, channel := range channels {
 			dstChannelID := channel.Counterparty.ChannelID
-			dstTxs[i], err = dstChain.SendIBCTransfer(ctx, dstChannelID, dstUser.KeyName(), testCoinDstToSrc, ibc.TransferOptions{Timeout: timeout})
+			dstTxs[i], err = dstChain.SendIBCTransfer(ctx, dstChannelID, dstUser.KeyName(), testCoinDstToSrc, opts)
 			if err != nil {
 				return fmt.Errorf("failed to send ibc transfer from destination: %w", err)
 			}
@@ -207,12 +248,14 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 // so that it can properly group subtests in a single invocation.
 // If the subtest configuration does not meet your needs,
 // you can directly call one of the other exported Test functions, such as TestChainPair.
+// Chain factories of 2 chains run the chain pair tests, and of 3 chains the packet forwarding test,
+// which is reported as skipped without one.
 func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory, rfs []interchaintest.RelayerFactory, rep *testreporter.Reporter) {
 	// Validate chain factory counts up front.
 	counts := make(map[int]bool)
 	for _, cf := range cfs {
 		switch count := cf.Count(); count {
-		case 2:
+		case 2, 3:
 			counts[count] = true
 		default:
 			panic(fmt.Errorf("cannot accept chain factory with count=%d", cf.Count()))
@@ -263,6 +306,56 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 
 								TestRelayerFlushing(t, ctx, cf, rf, rep)
 							})
+
+							t.Run("interchain accounts", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestInterchainAccounts(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("fee middleware", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerFeeMiddleware(t, ctx, cf, rf, rep)
+							})
+						})
+					}
+				})
+			}
+		})
+	}
+
+	// Any chain triples present?
+	if !counts[3] {
+		t.Run("chain triples", func(t *testing.T) {
+			rep.TrackTest(t)
+			rep.TrackSkip(t, "skipping packet forwarding: no chain factory with 3 chains, the second running packet forward middleware")
+		})
+	} else {
+		t.Run("chain triples", func(t *testing.T) {
+			for _, cf := range cfs {
+				cf := cf
+				if cf.Count() != 3 {
+					continue
+				}
+
+				t.Run(cf.Name(), func(t *testing.T) {
+					for _, rf := range rfs {
+						rf := rf
+
+						t.Run(rf.Name(), func(t *testing.T) {
+							// Record the labels for this nested test.
+							rep.TrackTest(t)
+							rep.TrackParallel(t)
+
+							t.Run("packet forwarding", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestPacketForwarding(t, ctx, cf, rf, rep)
+							})
 						})
 					}
 				})
@@ -279,6 +372,7 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 // 2. Proper handling of no timeout from A -> B and B -> A.
 // 3. Proper handling of height timeout from A -> B and B -> A.
 // 4. Proper handling of timestamp timeout from A -> B and B -> A.
+// 5. Successful IBC transfer carrying an ICS-20 memo from A -> B and B -> A, if both chains support memos.
 // If a non-nil relayerImpl is passed, it is assumed that the chains are already started.
 func TestChainPair(
 	t *testing.T,
@@ -313,6 +407,12 @@ func TestChainPair(
 			continue
 		}
 		preRelayerStartFunc := func(channels []ibc.ChannelOutput) {
+			// the chains are only started now, so their support is checked here and the test skipped later
+			if testCase.Config.RequiredChainSupport != nil {
+				if testCase.unsupported = testCase.Config.RequiredChainSupport(ctx, srcChain, dstChain); testCase.unsupported != nil {
+					return
+				}
+			}
 			// fund a user wallet on both chains, save on test case
 			testCase.Users = interchaintest.GetAndFundTestUsers(t, ctx, strings.ReplaceAll(testCase.Config.Name, " ", "-")+"-"+randomSuffix, userFaucetFund, srcChain, dstChain)
 			// run test specific pre relayer start action
@@ -350,6 +450,9 @@ func TestChainPair(
 			t.Run(testCase.Config.Name, func(t *testing.T) {
 				rep.TrackTest(t)
 				requireCapabilities(t, rep, rf, testCase.Config.RequiredRelayerCapabilities...)
+				if testCase.unsupported != nil {
+					rep.TrackSkip(t, "skipping due to missing chain support: %v", testCase.unsupported)
+				}
 				rep.TrackParallel(t)
 				testCase.Config.Test(ctx, t, testCase, rep, srcChain, dstChain, channels)
 			})
@@ -370,6 +473,11 @@ func preRelayerStart_NoTimeout(ctx context.Context, t *testing.T, testCase *Rela
 	// would need to shorten the chain default timeouts somehow to make that a feasible test
 }
 
+func preRelayerStart_Memo(ctx context.Context, t *testing.T, testCase *RelayerTestCase, srcChain ibc.Chain, dstChain ibc.Chain, channels []ibc.ChannelOutput) {
+	// A JSON memo without a "forward" key is carried in the ICS-20 packet data but ignored by packet forward middleware.
+	sendIBCTransfersFromBothChains(ctx, t, testCase, srcChain, dstChain, channels, ibc.TransferOptions{Memo: `{"conformance":"memo"}`})
+}
+
 func preRelayerStart_HeightTimeout(ctx context.Context, t *testing.T, testCase *RelayerTestCase, srcChain ibc.Chain, dstChain ibc.Chain, channels []ibc.ChannelOutput) {
 	ibcTimeoutHeight := ibc.IBCTimeout{Height: 10}
 	sendIBCTransfersFromBothChainsWithTimeout(ctx, t, testCase, srcChain, dstChain, channels, &ibcTimeoutHeight)
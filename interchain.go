@@ -8,7 +8,11 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -42,6 +46,10 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Set during Build if tracking blocks. The relayer commands are saved to the test case in the Close method.
+	blockTestCase *blockdb.TestCase
+	execRep       *testreporter.RelayerExecReporter
 }
 
 type interchainLink struct {
@@ -227,9 +235,23 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		createChannelOpts: link.CreateChannelOpts,
 		createClientOpts:  link.CreateClientOpts,
 	}
+	addCounterparty(link.Chain1, link.Chain2)
+	addCounterparty(link.Chain2, link.Chain1)
 	return ic
 }
 
+// counterpartyTracker is implemented by chains looking up the acknowledgements of their packets
+// in the events of the chains they are linked to, such as Penumbra and Polkadot.
+type counterpartyTracker interface {
+	AddCounterparty(chain ibc.Chain)
+}
+
+func addCounterparty(c, counterparty ibc.Chain) {
+	if tracker, ok := c.(counterpartyTracker); ok {
+		tracker.AddCounterparty(counterparty)
+	}
+}
+
 // AddLink adds the given link to the Interchain.
 // If any validation fails, AddLink panics.
 func (ic *Interchain) AddProviderConsumerLink(link ProviderConsumerLink) *Interchain {
@@ -291,12 +313,15 @@ type InterchainBuildOptions struct {
 // It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
 //
 // Calling Build more than once will cause a panic.
-func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
+func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) (err error) {
 	if ic.built {
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
 	ic.built = true
 
+	ctx, span := tracing.Start(ctx, "Interchain.Build", attribute.String("test", opts.TestName))
+	defer func() { tracing.End(span, err) }()
+
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
 		chains = append(chains, chain)
@@ -317,7 +342,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return fmt.Errorf("failed to initialize chains: %w", err)
 	}
 
-	err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
+	err = tracing.Run(ctx, "generate relayer wallets", ic.generateRelayerWallets) // Build the relayer wallet mapping.
 	if err != nil {
 		return err
 	}
@@ -332,11 +357,20 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
-	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+	var testCase *blockdb.TestCase
+	err = tracing.Run(ctx, "track blocks", func(ctx context.Context) (err error) {
+		testCase, err = ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha)
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
+	ic.blockTestCase, ic.execRep = testCase, rep
 
-	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
+	err = tracing.Run(ctx, "configure relayer keys", func(ctx context.Context) error {
+		return ic.configureRelayerKeys(ctx, rep)
+	})
+	if err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
 	}
@@ -347,35 +381,14 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return nil
 	}
 
-	// For every relayer link, teach the relayer about the link and create the link.
-	for rp, link := range ic.links {
-		rp := rp
-		link := link
-		c0 := link.chains[0]
-		c1 := link.chains[1]
-
-		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
-			return fmt.Errorf(
-				"failed to generate path %s on relayer %s between chains %s and %s: %w",
-				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
-			)
-		}
+	if err := tracing.Run(ctx, "generate paths", func(ctx context.Context) error {
+		return ic.generatePaths(ctx, rep)
+	}); err != nil {
+		return err
 	}
 
-	// For every provider consumer link, teach the relayer about the link and create the link.
-	for rp, link := range ic.providerConsumerLinks {
-		rp := rp
-		link := link
-		p := link.provider
-		c := link.consumer
-
-		if err := rp.Relayer.GeneratePath(ctx, rep, c.Config().ChainID, p.Config().ChainID, rp.Path); err != nil {
-			return fmt.Errorf(
-				"failed to generate path %s on relayer %s between chains %s and %s: %w",
-				rp.Path, rp.Relayer, ic.chains[p], ic.chains[c], err,
-			)
-		}
-	}
+	ctx, linkSpan := tracing.Start(ctx, "link paths")
+	defer func() { tracing.End(linkSpan, err) }()
 
 	var eg errgroup.Group
 
@@ -386,7 +399,11 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		link := link
 		p := link.provider
 		c := link.consumer
-		eg.Go(func() error {
+		eg.Go(func() (err error) {
+			ctx, span := tracing.Start(ctx, "link provider consumer path",
+				attribute.String("path", rp.Path), attribute.String("relayer", ic.relayers[rp.Relayer]))
+			defer func() { tracing.End(span, err) }()
+
 			// If the user specifies a zero value CreateClientOptions struct then we fall back to the default
 			// client options.
 			if link.createClientOpts == (ibc.CreateClientOptions{}) {
@@ -496,7 +513,11 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		link := link
 		c0 := link.chains[0]
 		c1 := link.chains[1]
-		eg.Go(func() error {
+		eg.Go(func() (err error) {
+			ctx, span := tracing.Start(ctx, "link path",
+				attribute.String("path", rp.Path), attribute.String("relayer", ic.relayers[rp.Relayer]))
+			defer func() { tracing.End(span, err) }()
+
 			// If the user specifies a zero value CreateClientOptions struct then we fall back to the default
 			// client options.
 			if link.createClientOpts == (ibc.CreateClientOptions{}) {
@@ -532,6 +553,41 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	return eg.Wait()
 }
 
+// generatePaths teaches the relayers about every link.
+func (ic *Interchain) generatePaths(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+	// For every relayer link, teach the relayer about the link and create the link.
+	for rp, link := range ic.links {
+		rp := rp
+		link := link
+		c0 := link.chains[0]
+		c1 := link.chains[1]
+
+		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
+			return fmt.Errorf(
+				"failed to generate path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
+			)
+		}
+	}
+
+	// For every provider consumer link, teach the relayer about the link and create the link.
+	for rp, link := range ic.providerConsumerLinks {
+		rp := rp
+		link := link
+		p := link.provider
+		c := link.consumer
+
+		if err := rp.Relayer.GeneratePath(ctx, rep, c.Config().ChainID, p.Config().ChainID, rp.Path); err != nil {
+			return fmt.Errorf(
+				"failed to generate path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, rp.Relayer, ic.chains[p], ic.chains[c], err,
+			)
+		}
+	}
+
+	return nil
+}
+
 // WithLog sets the logger on the interchain object.
 // Usually the default nop logger is fine, but sometimes it can be helpful
 // to see more verbose logs, typically by passing zaptest.NewLogger(t).
@@ -542,8 +598,40 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
+// It is a no-op if Build was never called.
 func (ic *Interchain) Close() error {
-	return ic.cs.Close()
+	if ic.cs == nil {
+		return nil
+	}
+	err := ic.saveRelayerExecs()
+	return multierr.Append(err, ic.cs.Close())
+}
+
+// saveRelayerExecs saves the relayer commands tracked during the test to the block database, if tracking blocks.
+func (ic *Interchain) saveRelayerExecs() error {
+	if ic.blockTestCase == nil || ic.execRep == nil {
+		return nil
+	}
+	msgs := ic.execRep.Execs()
+	execs := make([]blockdb.RelayerExec, len(msgs))
+	for i, msg := range msgs {
+		execs[i] = blockdb.RelayerExec{
+			ContainerName: msg.ContainerName,
+			Command:       msg.Command,
+			Stdout:        msg.Stdout,
+			Stderr:        msg.Stderr,
+			ExitCode:      msg.ExitCode,
+			Error:         msg.Error,
+			StartedAt:     msg.StartedAt,
+			FinishedAt:    msg.FinishedAt,
+		}
+	}
+	// Only save once, in case Close is called more than once.
+	ic.execRep = nil
+	if err := ic.blockTestCase.SaveRelayerExecs(context.Background(), execs); err != nil {
+		return fmt.Errorf("failed to save relayer execs: %w", err)
+	}
+	return nil
 }
 
 func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
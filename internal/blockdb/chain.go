@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"hash/fnv"
+	"time"
 
 	"golang.org/x/sync/singleflight"
 )
@@ -26,25 +27,34 @@ func (txs transactions) Hash() []byte {
 	return h.Sum(nil)
 }
 
-// SaveBlock tracks a block at height with its transactions.
+// SaveBlock tracks a block at height with its header and transactions. An empty header is saved as NULL columns.
 // This method is idempotent and can be safely called multiple times with the same arguments.
 // The txs should be human-readable.
-func (chain *Chain) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
+func (chain *Chain) SaveBlock(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error {
 	k := fmt.Sprintf("%d-%x", height, transactions(txs).Hash())
 	_, err, _ := chain.single.Do(k, func() (any, error) {
-		return nil, chain.saveBlock(ctx, height, txs)
+		return nil, chain.saveBlock(ctx, height, header, txs)
 	})
 	return err
 }
 
-func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactions) error {
+func (chain *Chain) saveBlock(ctx context.Context, height uint64, header BlockHeader, txs transactions) error {
 	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dbTx.Rollback() }()
 
-	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at) VALUES (?, ?, ?)`, height, chain.id, nowRFC3339())
+	var blockTime, numTxs any
+	if !header.IsZero() {
+		numTxs = header.NumTxs
+		if !header.Time.IsZero() {
+			blockTime = header.Time.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at, block_time, proposer, app_hash, num_txs)
+VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		height, chain.id, nowRFC3339(), blockTime, nullString(header.Proposer), nullString(header.AppHash), numTxs)
 	if err != nil {
 		return fmt.Errorf("insert into block: %w", err)
 	}
@@ -54,7 +64,9 @@ func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactio
 		return err
 	}
 	for _, tx := range txs {
-		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id) VALUES (?, ?)`, string(tx.Data), blockID)
+		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id, hash, decode_error, gas_wanted, gas_used, code, codespace, fee)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			string(tx.Data), blockID, nullString(tx.Hash), nullString(tx.DecodeError), tx.GasWanted, tx.GasUsed, tx.Code, nullString(tx.Codespace), nullString(tx.Fee))
 		if err != nil {
 			return fmt.Errorf("insert into tx: %w", err)
 		}
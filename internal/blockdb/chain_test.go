@@ -52,7 +52,7 @@ func TestChain_SaveBlock(t *testing.T) {
 
 		chain := validChain(t, db)
 
-		err := chain.SaveBlock(ctx, 5, []Tx{tx1, tx2})
+		err := chain.SaveBlock(ctx, 5, BlockHeader{}, []Tx{tx1, tx2})
 		require.NoError(t, err)
 
 		row := db.QueryRow(`SELECT height, fk_chain_id, created_at FROM block LIMIT 1`)
@@ -118,15 +118,69 @@ ORDER BY tendermint_event_attr.id`)
 		}
 	})
 
+	t.Run("header and gas", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+
+		header := BlockHeader{
+			Time:     time.Date(2023, 1, 2, 3, 4, 5, 600, time.FixedZone("EST", -5*3600)),
+			Proposer: "C1A4F5A5",
+			AppHash:  "E3B0C442",
+			NumTxs:   1,
+		}
+		tx := Tx{Data: []byte(`{}`), GasWanted: 200000, GasUsed: 81234, Code: 5, Codespace: "sdk", Fee: "500uatom"}
+		require.NoError(t, chain.SaveBlock(ctx, 5, header, []Tx{tx}))
+
+		var (
+			gotTime, gotProposer, gotAppHash string
+			gotNumTxs                        int
+		)
+		row := db.QueryRow(`SELECT block_time, proposer, app_hash, num_txs FROM block`)
+		require.NoError(t, row.Scan(&gotTime, &gotProposer, &gotAppHash, &gotNumTxs))
+		require.Equal(t, "2023-01-02T08:04:05.0000006Z", gotTime)
+		require.Equal(t, "C1A4F5A5", gotProposer)
+		require.Equal(t, "E3B0C442", gotAppHash)
+		require.Equal(t, 1, gotNumTxs)
+
+		var (
+			gotGasWanted, gotGasUsed int64
+			gotCode                  uint32
+			gotCodespace, gotFee     string
+		)
+		row = db.QueryRow(`SELECT gas_wanted, gas_used, code, codespace, fee FROM tx`)
+		require.NoError(t, row.Scan(&gotGasWanted, &gotGasUsed, &gotCode, &gotCodespace, &gotFee))
+		require.Equal(t, Tx{GasWanted: 200000, GasUsed: 81234, Code: 5, Codespace: "sdk", Fee: "500uatom"},
+			Tx{GasWanted: gotGasWanted, GasUsed: gotGasUsed, Code: gotCode, Codespace: gotCodespace, Fee: gotFee})
+	})
+
+	t.Run("unknown header", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+		require.NoError(t, chain.SaveBlock(ctx, 5, BlockHeader{}, []Tx{tx1}))
+
+		var blockTime, proposer, numTxs, codespace, fee any
+		row := db.QueryRow(`SELECT block_time, proposer, num_txs, codespace, fee FROM block JOIN tx ON tx.fk_block_id = block.id`)
+		require.NoError(t, row.Scan(&blockTime, &proposer, &numTxs, &codespace, &fee))
+		require.Nil(t, blockTime)
+		require.Nil(t, proposer)
+		require.Nil(t, numTxs)
+		require.Nil(t, codespace)
+		require.Nil(t, fee)
+	})
+
 	t.Run("idempotent", func(t *testing.T) {
 		db := migratedDB()
 		defer db.Close()
 
 		chain := validChain(t, db)
 
-		err := chain.SaveBlock(ctx, 1, []Tx{tx2})
+		err := chain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{tx2})
 		require.NoError(t, err)
-		err = chain.SaveBlock(ctx, 1, []Tx{tx2})
+		err = chain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{tx2})
 		require.NoError(t, err)
 
 		row := db.QueryRow(`SELECT count(*) FROM block`)
@@ -157,7 +211,7 @@ ORDER BY tendermint_event_attr.id`)
 
 		chain := validChain(t, db)
 
-		err := chain.SaveBlock(ctx, 5, nil)
+		err := chain.SaveBlock(ctx, 5, BlockHeader{}, nil)
 		require.NoError(t, err)
 
 		row := db.QueryRow(`SELECT height FROM block LIMIT 1`)
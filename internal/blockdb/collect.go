@@ -14,8 +14,35 @@ type Tx struct {
 	// Otherwise, this should be a human-readable format if possible.
 	Data []byte
 
+	// Hash of the transaction as hex, if applicable.
+	Hash string
+
+	// Error decoding the transaction, if Data could only be encoded from the raw bytes.
+	DecodeError string
+
 	// Events associated with the transaction, if applicable.
 	Events []Event
+
+	// Execution result of the transaction, if applicable.
+	GasWanted int64
+	GasUsed   int64
+	Code      uint32
+	Codespace string
+	// Fee paid by the transaction, e.g. "500uatom".
+	Fee string
+}
+
+// BlockHeader is metadata of a block. It is left empty by chains which only find transactions.
+type BlockHeader struct {
+	Time     time.Time
+	Proposer string // Address of the proposer, as reported by the chain.
+	AppHash  string
+	NumTxs   int // Number of transactions in the block, not counting artificial begin and end block transactions.
+}
+
+// IsZero reports whether the header is unknown.
+func (h BlockHeader) IsZero() bool {
+	return h == BlockHeader{}
 }
 
 // Event is an alternative representation of tendermint/abci/types.Event,
@@ -39,9 +66,15 @@ type TxFinder interface {
 	FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 }
 
+// BlockFinder finds the header and transactions of block at height.
+// A TxFinder given to a Collector may implement BlockFinder to also save block headers.
+type BlockFinder interface {
+	FindBlock(ctx context.Context, height uint64) (BlockHeader, []Tx, error)
+}
+
 // BlockSaver saves transactions for block at height.
 type BlockSaver interface {
-	SaveBlock(ctx context.Context, height uint64, txs []Tx) error
+	SaveBlock(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error
 }
 
 // Collector saves block transactions at regular intervals.
@@ -103,11 +136,20 @@ func (p *Collector) Stop() {
 }
 
 func (p *Collector) saveTxsForHeight(ctx context.Context, height uint64) error {
-	txs, err := p.finder.FindTxs(ctx, height)
+	var (
+		header BlockHeader
+		txs    []Tx
+		err    error
+	)
+	if bf, ok := p.finder.(BlockFinder); ok {
+		header, txs, err = bf.FindBlock(ctx, height)
+	} else {
+		txs, err = p.finder.FindTxs(ctx, height)
+	}
 	if err != nil {
 		return fmt.Errorf("find txs: %w", err)
 	}
-	err = p.saver.SaveBlock(ctx, height, txs)
+	err = p.saver.SaveBlock(ctx, height, header, txs)
 	if err != nil {
 		return fmt.Errorf("save block: %w", err)
 	}
@@ -21,10 +21,20 @@ func (f mockTxFinder) FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 	return f(ctx, height)
 }
 
-type mockBlockSaver func(ctx context.Context, height uint64, txs []Tx) error
+type mockBlockSaver func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error
 
-func (f mockBlockSaver) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
-	return f(ctx, height, txs)
+func (f mockBlockSaver) SaveBlock(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error {
+	return f(ctx, height, header, txs)
+}
+
+type mockBlockFinder func(ctx context.Context, height uint64) (BlockHeader, []Tx, error)
+
+func (f mockBlockFinder) FindTxs(ctx context.Context, height uint64) ([]Tx, error) {
+	panic("FindBlock should be preferred")
+}
+
+func (f mockBlockFinder) FindBlock(ctx context.Context, height uint64) (BlockHeader, []Tx, error) {
+	return f(ctx, height)
 }
 
 func TestCollector_Collect(t *testing.T) {
@@ -51,7 +61,7 @@ func TestCollector_Collect(t *testing.T) {
 			savedHeights  []int
 			savedTxs      [][]Tx
 		)
-		saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error {
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -87,6 +97,40 @@ func TestCollector_Collect(t *testing.T) {
 		require.Equal(t, "3", string(savedTxs[2][0].Data))
 	})
 
+	t.Run("block finder", func(t *testing.T) {
+		blockTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		finder := mockBlockFinder(func(ctx context.Context, height uint64) (BlockHeader, []Tx, error) {
+			header := BlockHeader{Time: blockTime.Add(time.Duration(height) * time.Second), Proposer: "ABC", NumTxs: 1}
+			return header, []Tx{{Data: []byte("tx"), GasUsed: 100}}, nil
+		})
+
+		ch := make(chan BlockHeader)
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error {
+			require.Len(t, txs, 1)
+			require.EqualValues(t, 100, txs[0].GasUsed)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ch <- header:
+			}
+			return nil
+		})
+
+		collector := NewCollector(nopLog, finder, saver, time.Nanosecond)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			collector.Collect(context.Background())
+		}()
+
+		require.Equal(t, BlockHeader{Time: blockTime.Add(time.Second), Proposer: "ABC", NumTxs: 1}, <-ch)
+		require.Equal(t, blockTime.Add(2*time.Second), (<-ch).Time)
+
+		// Wait for Collect to return, so it does not change the goroutine count of TestCollector_Stop.
+		collector.Stop()
+		<-done
+	})
+
 	t.Run("find error", func(t *testing.T) {
 		ch := make(chan int)
 		finder := mockTxFinder(func(ctx context.Context, height uint64) ([]Tx, error) {
@@ -96,7 +140,7 @@ func TestCollector_Collect(t *testing.T) {
 			}
 			return nil, errors.New("boom")
 		})
-		saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error { return nil })
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error { return nil })
 
 		collector := NewCollector(nopLog, finder, saver, time.Nanosecond)
 		defer collector.Stop()
@@ -113,7 +157,7 @@ func TestCollector_Collect(t *testing.T) {
 			defer func() { ch <- int(height) }()
 			return nil, nil
 		})
-		saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error {
+		saver := mockBlockSaver(func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error {
 			if height == 1 {
 				return nil
 			}
@@ -145,7 +189,7 @@ func TestCollector_Stop(t *testing.T) {
 		})
 		return nil, nil
 	})
-	saver := mockBlockSaver(func(ctx context.Context, height uint64, txs []Tx) error { return nil })
+	saver := mockBlockSaver(func(ctx context.Context, height uint64, header BlockHeader, txs []Tx) error { return nil })
 
 	c := NewCollector(zap.NewNop(), finder, saver, time.Millisecond)
 	defer c.Stop() // Will be stopped explicitly in a few lines, but defer anyway for cleanup just in case.
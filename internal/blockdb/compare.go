@@ -0,0 +1,371 @@
+package blockdb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// TestCaseRun is a single run of a test case.
+type TestCaseRun struct {
+	ID     int64
+	Name   string
+	GitSha string
+	// Always set to user's local time zone.
+	CreatedAt time.Time
+}
+
+// TestCaseRuns returns the runs of the test case name, most recent first.
+func (q *Query) TestCaseRuns(ctx context.Context, name string) ([]TestCaseRun, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT id, name, git_sha, created_at FROM test_case
+    WHERE name = ?
+    ORDER BY id DESC`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TestCaseRun
+	for rows.Next() {
+		var (
+			res       TestCaseRun
+			createdAt string
+		)
+		if err := rows.Scan(&res.ID, &res.Name, &res.GitSha, &createdAt); err != nil {
+			return nil, err
+		}
+		t, err := timeToLocal(createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse createdAt: %w", err)
+		}
+		res.CreatedAt = t
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// RunStats summarizes the transactions and blocks of each chain in a test case run.
+type RunStats struct {
+	TestCaseRun
+	Chains []ChainStats // Sorted by chain id.
+}
+
+// ChainStats summarizes the transactions and blocks of a chain.
+// Only cosmos transactions, which have messages, are counted.
+type ChainStats struct {
+	ChainID    string
+	TxTotal    int64
+	FailedTxs  int64 // Transactions with a non-zero code.
+	Messages   []MessageStats
+	BlockTimes BlockTimes
+}
+
+// MessageStats summarizes the messages of a type, e.g. /ibc.core.client.v1.MsgUpdateClient.
+type MessageStats struct {
+	Type      string
+	Count     int64
+	FailedTxs int64 // Failed transactions which include a message of this type.
+	// GasUsed by the transactions, split evenly between the messages of each transaction.
+	GasUsed int64
+}
+
+// AvgGas is the average gas used per message.
+func (m MessageStats) AvgGas() int64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.GasUsed / m.Count
+}
+
+// BlockTimes are percentiles of the time between consecutive blocks.
+type BlockTimes struct {
+	Blocks        int // Number of intervals the percentiles are computed from.
+	P50, P90, P99 time.Duration
+}
+
+// RunStats returns the stats of the test case with primary key testCaseID.
+func (q *Query) RunStats(ctx context.Context, testCaseID int64) (RunStats, error) {
+	var stats RunStats
+
+	row := q.db.QueryRowContext(ctx, `SELECT id, name, git_sha, created_at FROM test_case WHERE id = ?`, testCaseID)
+	var createdAt string
+	if err := row.Scan(&stats.ID, &stats.Name, &stats.GitSha, &createdAt); err != nil {
+		return stats, fmt.Errorf("query test case %d: %w", testCaseID, err)
+	}
+	t, err := timeToLocal(createdAt)
+	if err != nil {
+		return stats, fmt.Errorf("parse createdAt: %w", err)
+	}
+	stats.CreatedAt = t
+
+	chains := make(map[string]*ChainStats)
+	chain := func(chainID string) *ChainStats {
+		if c, ok := chains[chainID]; ok {
+			return c
+		}
+		c := &ChainStats{ChainID: chainID}
+		chains[chainID] = c
+		return c
+	}
+
+	rows, err := q.db.QueryContext(ctx, `SELECT chain.chain_id
+        , COUNT(tx.id)
+        , COALESCE(SUM(CASE WHEN tx.code != 0 THEN 1 ELSE 0 END), 0)
+    FROM chain
+    LEFT JOIN block ON block.fk_chain_id = chain.id
+    LEFT JOIN tx ON tx.fk_block_id = block.id
+        AND json_valid(tx.data) AND json_type(tx.data, '$.body.messages') = 'array'
+    WHERE chain.fk_test_id = ?
+    GROUP BY chain.id`, testCaseID)
+	if err != nil {
+		return stats, fmt.Errorf("query tx totals: %w", err)
+	}
+	for rows.Next() {
+		var chainID string
+		var total, failed int64
+		if err := rows.Scan(&chainID, &total, &failed); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		c := chain(chainID)
+		c.TxTotal, c.FailedTxs = total, failed
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	rows, err = q.db.QueryContext(ctx, `SELECT msg.chain_id
+        , msg.type
+        , COUNT(*)
+        , COUNT(DISTINCT CASE WHEN tx.code != 0 THEN tx.id END)
+        , CAST(ROUND(COALESCE(SUM(CAST(tx.gas_used AS REAL) / json_array_length(tx.data, '$.body.messages')), 0)) AS INTEGER)
+    FROM v_cosmos_messages AS msg
+    INNER JOIN tx ON tx.id = msg.tx_id
+    WHERE msg.test_case_id = ?
+    GROUP BY msg.chain_id, msg.type
+    ORDER BY msg.chain_id, msg.type`, testCaseID)
+	if err != nil {
+		return stats, fmt.Errorf("query message stats: %w", err)
+	}
+	for rows.Next() {
+		var (
+			chainID string
+			msg     MessageStats
+		)
+		if err := rows.Scan(&chainID, &msg.Type, &msg.Count, &msg.FailedTxs, &msg.GasUsed); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		c := chain(chainID)
+		c.Messages = append(c.Messages, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	rows, err = q.db.QueryContext(ctx, `SELECT v_block.chain_id, v_block.block_interval_ms FROM v_block
+    INNER JOIN chain ON chain.id = v_block.chain_kid
+    WHERE chain.fk_test_id = ? AND v_block.block_interval_ms IS NOT NULL`, testCaseID)
+	if err != nil {
+		return stats, fmt.Errorf("query block intervals: %w", err)
+	}
+	intervals := make(map[string][]time.Duration)
+	for rows.Next() {
+		var (
+			chainID string
+			ms      int64
+		)
+		if err := rows.Scan(&chainID, &ms); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		intervals[chainID] = append(intervals[chainID], time.Duration(ms)*time.Millisecond)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+	for chainID, durs := range intervals {
+		chain(chainID).BlockTimes = blockTimes(durs)
+	}
+
+	for _, c := range chains {
+		stats.Chains = append(stats.Chains, *c)
+	}
+	sort.Slice(stats.Chains, func(i, j int) bool { return stats.Chains[i].ChainID < stats.Chains[j].ChainID })
+	return stats, nil
+}
+
+func blockTimes(durs []time.Duration) BlockTimes {
+	sort.Slice(durs, func(i, j int) bool { return durs[i] < durs[j] })
+	return BlockTimes{
+		Blocks: len(durs),
+		P50:    percentile(durs, 0.5),
+		P90:    percentile(durs, 0.9),
+		P99:    percentile(durs, 0.99),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted durs.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Thresholds are how much worse a head run may be than its base run before Compare reports a regression.
+type Thresholds struct {
+	// GasIncrease is the fraction the average gas of a message type may grow by, e.g. 0.1 for 10%.
+	GasIncrease float64
+	// BlockTimeIncrease is the fraction the p50 and p90 block times may grow by.
+	BlockTimeIncrease float64
+	// FailedTxIncrease is how many more failed transactions a chain may have.
+	FailedTxIncrease int64
+}
+
+// DefaultThresholds tolerate noise in gas and block times but no new failed transactions.
+var DefaultThresholds = Thresholds{
+	GasIncrease:       0.1,
+	BlockTimeIncrease: 0.2,
+	FailedTxIncrease:  0,
+}
+
+// Comparison is the difference between two runs of a test case.
+type Comparison struct {
+	Base, Head  TestCaseRun
+	Chains      []ChainComparison
+	Regressions []Regression
+}
+
+// ChainComparison compares a chain across runs. Base or Head only has a ChainID if the chain is missing from that run.
+type ChainComparison struct {
+	ChainID    string
+	Base, Head ChainStats
+	Messages   []MessageComparison
+}
+
+// MessageComparison compares a message type across runs. Base or Head is zero if the type is missing from that run.
+type MessageComparison struct {
+	Type       string
+	Base, Head MessageStats
+}
+
+// Regression is a metric which grew beyond its threshold.
+type Regression struct {
+	ChainID    string
+	Metric     string
+	Base, Head float64
+}
+
+func (r Regression) String() string {
+	if r.Base == 0 {
+		return fmt.Sprintf("%s: %s %g -> %g", r.ChainID, r.Metric, r.Base, r.Head)
+	}
+	return fmt.Sprintf("%s: %s %g -> %g (%+.1f%%)", r.ChainID, r.Metric, r.Base, r.Head, (r.Head-r.Base)/r.Base*100)
+}
+
+// Compare diffs the chains and message types of two runs and reports regressions of head beyond th.
+func Compare(base, head RunStats, th Thresholds) Comparison {
+	cmp := Comparison{Base: base.TestCaseRun, Head: head.TestCaseRun}
+
+	baseChains := make(map[string]ChainStats, len(base.Chains))
+	for _, c := range base.Chains {
+		baseChains[c.ChainID] = c
+	}
+	headChains := make(map[string]ChainStats, len(head.Chains))
+	for _, c := range head.Chains {
+		headChains[c.ChainID] = c
+	}
+
+	for _, chainID := range unionKeys(baseChains, headChains) {
+		b, bOK := baseChains[chainID]
+		h, hOK := headChains[chainID]
+		b.ChainID, h.ChainID = chainID, chainID
+		cc := ChainComparison{ChainID: chainID, Base: b, Head: h, Messages: compareMessages(b.Messages, h.Messages)}
+		cmp.Chains = append(cmp.Chains, cc)
+
+		// A chain missing from either run has nothing to regress against.
+		if !bOK || !hOK {
+			continue
+		}
+
+		regress := func(metric string, base, head float64) {
+			cmp.Regressions = append(cmp.Regressions, Regression{ChainID: chainID, Metric: metric, Base: base, Head: head})
+		}
+
+		if h.FailedTxs-b.FailedTxs > th.FailedTxIncrease {
+			regress("failed txs", float64(b.FailedTxs), float64(h.FailedTxs))
+		}
+
+		for _, m := range cc.Messages {
+			if m.Base.Count == 0 || m.Head.Count == 0 {
+				continue
+			}
+			if exceeds(float64(m.Base.AvgGas()), float64(m.Head.AvgGas()), th.GasIncrease) {
+				regress("avg gas "+m.Type, float64(m.Base.AvgGas()), float64(m.Head.AvgGas()))
+			}
+		}
+
+		if b.BlockTimes.Blocks == 0 || h.BlockTimes.Blocks == 0 {
+			continue
+		}
+		if exceeds(b.BlockTimes.P50.Seconds(), h.BlockTimes.P50.Seconds(), th.BlockTimeIncrease) {
+			regress("block time p50 (s)", b.BlockTimes.P50.Seconds(), h.BlockTimes.P50.Seconds())
+		}
+		if exceeds(b.BlockTimes.P90.Seconds(), h.BlockTimes.P90.Seconds(), th.BlockTimeIncrease) {
+			regress("block time p90 (s)", b.BlockTimes.P90.Seconds(), h.BlockTimes.P90.Seconds())
+		}
+	}
+
+	return cmp
+}
+
+func compareMessages(base, head []MessageStats) []MessageComparison {
+	baseMsgs := make(map[string]MessageStats, len(base))
+	for _, m := range base {
+		baseMsgs[m.Type] = m
+	}
+	headMsgs := make(map[string]MessageStats, len(head))
+	for _, m := range head {
+		headMsgs[m.Type] = m
+	}
+
+	var msgs []MessageComparison
+	for _, typ := range unionKeys(baseMsgs, headMsgs) {
+		b, h := baseMsgs[typ], headMsgs[typ]
+		b.Type, h.Type = typ, typ
+		msgs = append(msgs, MessageComparison{Type: typ, Base: b, Head: h})
+	}
+	return msgs
+}
+
+// exceeds reports whether head grew from base by more than the fraction max.
+func exceeds(base, head, max float64) bool {
+	if base <= 0 {
+		return false
+	}
+	return (head-base)/base > max
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,169 @@
+package blockdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func msgTx(gasUsed int64, code uint32, types ...string) Tx {
+	var msgs string
+	for i, typ := range types {
+		if i > 0 {
+			msgs += ","
+		}
+		msgs += fmt.Sprintf(`{"@type":%q}`, typ)
+	}
+	return Tx{
+		Data:    []byte(fmt.Sprintf(`{"body":{"messages":[%s]}}`, msgs)),
+		GasUsed: gasUsed,
+		Code:    code,
+	}
+}
+
+const (
+	msgSend     = "/cosmos.bank.v1beta1.MsgSend"
+	msgTransfer = "/ibc.applications.transfer.v1.MsgTransfer"
+)
+
+func TestQuery_RunStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestTransfer", "sha1")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, chainA.SaveBlock(ctx, 1, BlockHeader{Time: start}, []Tx{
+		msgTx(100, 0, msgSend),
+		msgTx(300, 0, msgSend, msgTransfer),
+	}))
+	require.NoError(t, chainA.SaveBlock(ctx, 2, BlockHeader{Time: start.Add(time.Second)}, []Tx{
+		msgTx(50, 5, msgTransfer),
+		{Data: []byte(`{"data":"end_block"}`)},
+	}))
+	require.NoError(t, chainA.SaveBlock(ctx, 3, BlockHeader{Time: start.Add(3 * time.Second)}, nil))
+
+	// Another run of the same test must not be counted. Runs are unique by name and creation time in seconds.
+	_, err = db.Exec(`INSERT INTO test_case(name, git_sha, created_at) VALUES ('TestTransfer', 'sha2', '2099-01-01T00:00:00Z')`)
+	require.NoError(t, err)
+	other := &TestCase{db: db, id: tc.id + 1}
+	otherChain, err := other.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{msgTx(1, 1, msgSend)}))
+
+	stats, err := NewQuery(db).RunStats(ctx, tc.id)
+	require.NoError(t, err)
+
+	require.Equal(t, tc.id, stats.ID)
+	require.Equal(t, "TestTransfer", stats.Name)
+	require.Equal(t, "sha1", stats.GitSha)
+	require.Len(t, stats.Chains, 2)
+
+	a := stats.Chains[0]
+	require.Equal(t, "chain-a", a.ChainID)
+	require.EqualValues(t, 3, a.TxTotal)
+	require.EqualValues(t, 1, a.FailedTxs)
+	require.Equal(t, []MessageStats{
+		{Type: msgSend, Count: 2, GasUsed: 250},
+		{Type: msgTransfer, Count: 2, FailedTxs: 1, GasUsed: 200},
+	}, a.Messages)
+	require.Equal(t, BlockTimes{Blocks: 2, P50: time.Second, P90: 2 * time.Second, P99: 2 * time.Second}, a.BlockTimes)
+
+	require.Equal(t, ChainStats{ChainID: "chain-b"}, stats.Chains[1])
+
+	runs, err := NewQuery(db).TestCaseRuns(ctx, "TestTransfer")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, "sha2", runs[0].GitSha)
+	require.Equal(t, "sha1", runs[1].GitSha)
+
+	_, err = NewQuery(db).RunStats(ctx, 1000)
+	require.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	base := RunStats{
+		TestCaseRun: TestCaseRun{ID: 1, GitSha: "base"},
+		Chains: []ChainStats{
+			{
+				ChainID:    "chain-a",
+				TxTotal:    10,
+				FailedTxs:  1,
+				Messages:   []MessageStats{{Type: msgSend, Count: 4, GasUsed: 400}, {Type: msgTransfer, Count: 2, GasUsed: 200}},
+				BlockTimes: BlockTimes{Blocks: 10, P50: time.Second, P90: 2 * time.Second},
+			},
+			{ChainID: "chain-old"},
+		},
+	}
+	head := RunStats{
+		TestCaseRun: TestCaseRun{ID: 2, GitSha: "head"},
+		Chains: []ChainStats{
+			{
+				ChainID:   "chain-a",
+				TxTotal:   10,
+				FailedTxs: 2,
+				Messages: []MessageStats{
+					{Type: msgSend, Count: 4, GasUsed: 420},     // +5%
+					{Type: msgTransfer, Count: 1, GasUsed: 150}, // +50%
+					{Type: "/new.Msg", Count: 1, GasUsed: 1},
+				},
+				BlockTimes: BlockTimes{Blocks: 10, P50: 1100 * time.Millisecond, P90: 3 * time.Second},
+			},
+			{ChainID: "chain-new", FailedTxs: 5},
+		},
+	}
+
+	cmp := Compare(base, head, DefaultThresholds)
+
+	require.Equal(t, "base", cmp.Base.GitSha)
+	require.Equal(t, "head", cmp.Head.GitSha)
+
+	require.Len(t, cmp.Chains, 3)
+	require.Equal(t, "chain-a", cmp.Chains[0].ChainID)
+	require.Equal(t, "chain-new", cmp.Chains[1].ChainID)
+	require.Equal(t, "chain-new", cmp.Chains[1].Base.ChainID)
+	require.Equal(t, "chain-old", cmp.Chains[2].ChainID)
+
+	msgs := cmp.Chains[0].Messages
+	require.Len(t, msgs, 3)
+	require.Equal(t, msgSend, msgs[0].Type)
+	require.EqualValues(t, 105, msgs[0].Head.AvgGas())
+	require.Equal(t, "/new.Msg", msgs[2].Type)
+	require.Zero(t, msgs[2].Base.Count)
+
+	require.Equal(t, []Regression{
+		{ChainID: "chain-a", Metric: "failed txs", Base: 1, Head: 2},
+		{ChainID: "chain-a", Metric: "avg gas " + msgTransfer, Base: 100, Head: 150},
+		{ChainID: "chain-a", Metric: "block time p90 (s)", Base: 2, Head: 3},
+	}, cmp.Regressions)
+	require.Equal(t, "chain-a: avg gas /ibc.applications.transfer.v1.MsgTransfer 100 -> 150 (+50.0%)", cmp.Regressions[1].String())
+
+	cmp = Compare(base, head, Thresholds{GasIncrease: 1, BlockTimeIncrease: 1, FailedTxIncrease: 1})
+	require.Empty(t, cmp.Regressions)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	var durs []time.Duration
+	for i := 1; i <= 100; i++ {
+		durs = append(durs, time.Duration(i)*time.Millisecond)
+	}
+	got := blockTimes(durs)
+	require.Equal(t, BlockTimes{Blocks: 100, P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond}, got)
+
+	require.Equal(t, BlockTimes{}, blockTimes(nil))
+}
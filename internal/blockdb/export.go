@@ -0,0 +1,379 @@
+package blockdb
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// ExportFormat is a file format Export writes tables in.
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "csv"
+	ExportJSONL   ExportFormat = "jsonl"
+	ExportParquet ExportFormat = "parquet"
+)
+
+// ParseExportFormat returns the ExportFormat named s.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(s); f {
+	case ExportCSV, ExportJSONL, ExportParquet:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (valid formats: csv, jsonl, parquet)", s)
+}
+
+type exportKind int
+
+const (
+	exportInt exportKind = iota
+	exportString
+)
+
+type exportColumn struct {
+	name string
+	kind exportKind
+}
+
+// exportTable is a query selecting the columns of a table for a test case id.
+type exportTable struct {
+	name    string
+	columns []exportColumn
+	query   string
+	// Only write a file if the query returns rows.
+	optional bool
+}
+
+// exportTables keep the primary and foreign keys of each table so the files can be joined again.
+var exportTables = []exportTable{
+	{
+		name: "test_case",
+		columns: []exportColumn{
+			{"id", exportInt}, {"name", exportString}, {"git_sha", exportString}, {"created_at", exportString},
+		},
+		query: `SELECT id, name, git_sha, created_at FROM test_case WHERE id = ?`,
+	},
+	{
+		name: "chain",
+		columns: []exportColumn{
+			{"id", exportInt}, {"test_case_id", exportInt}, {"chain_id", exportString}, {"chain_type", exportString},
+		},
+		query: `SELECT id, fk_test_id, chain_id, chain_type FROM chain WHERE fk_test_id = ? ORDER BY id`,
+	},
+	{
+		name: "block",
+		columns: []exportColumn{
+			{"id", exportInt}, {"chain_kid", exportInt}, {"height", exportInt}, {"created_at", exportString},
+			{"block_time", exportString}, {"proposer", exportString}, {"app_hash", exportString}, {"num_txs", exportInt},
+		},
+		query: `SELECT block.id, block.fk_chain_id, block.height, block.created_at,
+  block.block_time, block.proposer, block.app_hash, block.num_txs
+FROM block
+  JOIN chain ON block.fk_chain_id = chain.id
+WHERE chain.fk_test_id = ?
+ORDER BY block.id`,
+	},
+	{
+		name: "tx",
+		columns: []exportColumn{
+			{"id", exportInt}, {"block_id", exportInt}, {"hash", exportString}, {"data", exportString}, {"decode_error", exportString},
+			{"gas_wanted", exportInt}, {"gas_used", exportInt}, {"code", exportInt}, {"codespace", exportString}, {"fee", exportString},
+		},
+		query: `SELECT tx.id, tx.fk_block_id, tx.hash, tx.data, tx.decode_error, tx.gas_wanted, tx.gas_used, tx.code, tx.codespace, tx.fee
+FROM tx
+  JOIN block ON tx.fk_block_id = block.id
+  JOIN chain ON block.fk_chain_id = chain.id
+WHERE chain.fk_test_id = ?
+ORDER BY tx.id`,
+	},
+	{
+		name: "event",
+		columns: []exportColumn{
+			{"id", exportInt}, {"tx_id", exportInt}, {"type", exportString},
+		},
+		query: `SELECT tendermint_event.id, tendermint_event.fk_tx_id, tendermint_event.type
+FROM tendermint_event
+  JOIN tx ON tendermint_event.fk_tx_id = tx.id
+  JOIN block ON tx.fk_block_id = block.id
+  JOIN chain ON block.fk_chain_id = chain.id
+WHERE chain.fk_test_id = ?
+ORDER BY tendermint_event.id`,
+	},
+	{
+		name: "event_attr",
+		columns: []exportColumn{
+			{"id", exportInt}, {"event_id", exportInt}, {"key", exportString}, {"value", exportString},
+		},
+		query: `SELECT tendermint_event_attr.id, tendermint_event_attr.fk_event_id, tendermint_event_attr.key, tendermint_event_attr.value
+FROM tendermint_event_attr
+  JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+  JOIN tx ON tendermint_event.fk_tx_id = tx.id
+  JOIN block ON tx.fk_block_id = block.id
+  JOIN chain ON block.fk_chain_id = chain.id
+WHERE chain.fk_test_id = ?
+ORDER BY tendermint_event_attr.id`,
+	},
+	{
+		// EVM chains built on ethermint emit each log as the txLog attribute of a tx_log event.
+		name: "evm_log",
+		columns: []exportColumn{
+			{"event_id", exportInt}, {"tx_id", exportInt}, {"address", exportString}, {"topics", exportString},
+			{"data", exportString}, {"log_index", exportInt}, {"log", exportString},
+		},
+		query: `SELECT tendermint_event.id, tendermint_event.fk_tx_id,
+  json_extract(tendermint_event_attr.value, '$.address'),
+  json_extract(tendermint_event_attr.value, '$.topics'),
+  json_extract(tendermint_event_attr.value, '$.data'),
+  json_extract(tendermint_event_attr.value, '$.index'),
+  tendermint_event_attr.value
+FROM tendermint_event_attr
+  JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+  JOIN tx ON tendermint_event.fk_tx_id = tx.id
+  JOIN block ON tx.fk_block_id = block.id
+  JOIN chain ON block.fk_chain_id = chain.id
+WHERE chain.fk_test_id = ?
+  AND tendermint_event.type = 'tx_log'
+  AND tendermint_event_attr.key = 'txLog'
+  AND json_valid(tendermint_event_attr.value)
+ORDER BY tendermint_event_attr.id`,
+		optional: true,
+	},
+}
+
+// Export writes the test case with primary key testCaseID, and its chains, blocks, txs, events and event attributes,
+// to one file per table in dir. EVM logs are written to evm_log if any were recorded.
+// Returns the paths of the written files.
+func (q *Query) Export(ctx context.Context, testCaseID int64, format ExportFormat, dir string) ([]string, error) {
+	var exists bool
+	if err := q.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM test_case WHERE id = ?)`, testCaseID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("query test case %d: %w", testCaseID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("test case %d not found", testCaseID)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, table := range exportTables {
+		path := filepath.Join(dir, table.name+"."+string(format))
+		n, err := q.exportTable(ctx, table, testCaseID, format, path)
+		if err != nil {
+			return paths, fmt.Errorf("export %s: %w", table.name, err)
+		}
+		if n == 0 && table.optional {
+			if err := os.Remove(path); err != nil {
+				return paths, err
+			}
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (q *Query) exportTable(ctx context.Context, table exportTable, testCaseID int64, format ExportFormat, path string) (n int, err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	w, err := newExportWriter(f, format, table)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := q.db.QueryContext(ctx, table.query, testCaseID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var (
+		ints    = make([]sql.NullInt64, len(table.columns))
+		strs    = make([]sql.NullString, len(table.columns))
+		dest    = make([]any, len(table.columns))
+		values  = make([]any, len(table.columns))
+		columns = table.columns
+	)
+	for i, col := range columns {
+		switch col.kind {
+		case exportInt:
+			dest[i] = &ints[i]
+		case exportString:
+			dest[i] = &strs[i]
+		}
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return n, err
+		}
+		for i, col := range columns {
+			values[i] = nil
+			switch {
+			case col.kind == exportInt && ints[i].Valid:
+				values[i] = ints[i].Int64
+			case col.kind == exportString && strs[i].Valid:
+				values[i] = strs[i].String
+			}
+		}
+		if err := w.Write(values); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, w.Close()
+}
+
+// exportWriter writes rows of int64, string or nil values in the order of the table columns.
+type exportWriter interface {
+	Write(values []any) error
+	// Close flushes buffered rows. It does not close the underlying writer.
+	Close() error
+}
+
+func newExportWriter(w io.Writer, format ExportFormat, table exportTable) (exportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return newCSVWriter(w, table.columns)
+	case ExportJSONL:
+		return newJSONLWriter(w, table.columns), nil
+	case ExportParquet:
+		return newParquetWriter(w, table), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// csvWriter writes a header row then one record per row. NULL is written as an empty field.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer, columns []exportColumn) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, col := range columns {
+		cw.record[i] = col.name
+	}
+	return cw, cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Write(values []any) error {
+	for i, v := range values {
+		switch v := v.(type) {
+		case int64:
+			cw.record[i] = strconv.FormatInt(v, 10)
+		case string:
+			cw.record[i] = v
+		default:
+			cw.record[i] = ""
+		}
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// jsonlWriter writes one JSON object per line.
+type jsonlWriter struct {
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	columns []exportColumn
+}
+
+func newJSONLWriter(w io.Writer, columns []exportColumn) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &jsonlWriter{buf: buf, enc: enc, columns: columns}
+}
+
+func (jw *jsonlWriter) Write(values []any) error {
+	obj := make(map[string]any, len(values))
+	for i, v := range values {
+		obj[jw.columns[i].name] = v
+	}
+	return jw.enc.Encode(obj)
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.buf.Flush()
+}
+
+// parquetWriter writes every column as optional, since most columns were added by later migrations and may be NULL.
+type parquetWriter struct {
+	w *parquet.Writer
+	// The parquet column index of each table column. Parquet orders the columns of a group by name.
+	index []int
+	row   parquet.Row
+}
+
+func newParquetWriter(w io.Writer, table exportTable) *parquetWriter {
+	group := make(parquet.Group, len(table.columns))
+	names := make([]string, len(table.columns))
+	for i, col := range table.columns {
+		names[i] = col.name
+		switch col.kind {
+		case exportInt:
+			group[col.name] = parquet.Optional(parquet.Int(64))
+		case exportString:
+			group[col.name] = parquet.Optional(parquet.String())
+		}
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	index := make([]int, len(names))
+	for i, name := range names {
+		index[i] = sort.SearchStrings(sorted, name)
+	}
+
+	return &parquetWriter{
+		w:     parquet.NewWriter(w, parquet.NewSchema(table.name, group)),
+		index: index,
+		row:   make(parquet.Row, len(names)),
+	}
+}
+
+func (pw *parquetWriter) Write(values []any) error {
+	for i, v := range values {
+		col := pw.index[i]
+		if v == nil {
+			pw.row[col] = parquet.NullValue().Level(0, 0, col)
+			continue
+		}
+		pw.row[col] = parquet.ValueOf(v).Level(0, 1, col)
+	}
+	_, err := pw.w.WriteRows([]parquet.Row{pw.row})
+	return err
+}
+
+func (pw *parquetWriter) Close() error {
+	return pw.w.Close()
+}
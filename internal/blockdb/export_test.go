@@ -0,0 +1,177 @@
+package blockdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func exportFixture(t *testing.T) (*Query, int64) {
+	t.Helper()
+
+	ctx := context.Background()
+	db := migratedDB()
+	t.Cleanup(func() { _ = db.Close() })
+
+	tc, err := CreateTestCase(ctx, db, "TestExport", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, chain.SaveBlock(ctx, 1, BlockHeader{Time: start, Proposer: "AAA"}, []Tx{
+		{
+			Data:    []byte(`{"body":{}}`),
+			GasUsed: 100,
+			Events: []Event{
+				{Type: "transfer", Attributes: []EventAttribute{{Key: "amount", Value: "5uatom"}}},
+				{Type: "tx_log", Attributes: []EventAttribute{{Key: "txLog", Value: `{"address":"0xabc","topics":["0x01"],"data":"AQ==","index":3}`}}},
+			},
+		},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 2, BlockHeader{}, []Tx{{Data: []byte(`2`), Code: 5}}))
+
+	// Rows of another test case must not be exported.
+	other, err := CreateTestCase(ctx, db, "TestOther", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{{Data: []byte(`other`)}}))
+
+	return NewQuery(db), tc.id
+}
+
+func TestQuery_Export(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("csv", func(t *testing.T) {
+		q, id := exportFixture(t)
+		dir := t.TempDir()
+
+		paths, err := q.Export(ctx, id, ExportCSV, dir)
+		require.NoError(t, err)
+
+		var names []string
+		for _, p := range paths {
+			names = append(names, filepath.Base(p))
+		}
+		require.Equal(t, []string{"test_case.csv", "chain.csv", "block.csv", "tx.csv", "event.csv", "event_attr.csv", "evm_log.csv"}, names)
+
+		f, err := os.Open(filepath.Join(dir, "tx.csv"))
+		require.NoError(t, err)
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+
+		require.Equal(t, [][]string{
+			{"id", "block_id", "hash", "data", "decode_error", "gas_wanted", "gas_used", "code", "codespace", "fee"},
+			{"1", "1", "", `{"body":{}}`, "", "0", "100", "0", "", ""},
+			{"2", "2", "", "2", "", "0", "0", "5", "", ""},
+		}, records)
+
+		f, err = os.Open(filepath.Join(dir, "evm_log.csv"))
+		require.NoError(t, err)
+		defer f.Close()
+		records, err = csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, []string{"2", "1", "0xabc", `["0x01"]`, "AQ==", "3"}, records[1][:6])
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		q, id := exportFixture(t)
+		dir := t.TempDir()
+
+		_, err := q.Export(ctx, id, ExportJSONL, dir)
+		require.NoError(t, err)
+
+		f, err := os.Open(filepath.Join(dir, "block.jsonl"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		var blocks []map[string]any
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var block map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &block))
+			blocks = append(blocks, block)
+		}
+		require.NoError(t, scanner.Err())
+
+		require.Len(t, blocks, 2)
+		require.EqualValues(t, 1, blocks[0]["height"])
+		require.Equal(t, "2023-01-02T03:04:05Z", blocks[0]["block_time"])
+		require.Equal(t, "AAA", blocks[0]["proposer"])
+		require.Contains(t, blocks[1], "block_time")
+		require.Nil(t, blocks[1]["block_time"])
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		q, id := exportFixture(t)
+		dir := t.TempDir()
+
+		_, err := q.Export(ctx, id, ExportParquet, dir)
+		require.NoError(t, err)
+
+		type block struct {
+			ID        *int64  `parquet:"id,optional"`
+			Height    *int64  `parquet:"height,optional"`
+			BlockTime *string `parquet:"block_time,optional"`
+			Proposer  *string `parquet:"proposer,optional"`
+		}
+		blocks, err := parquet.ReadFile[block](filepath.Join(dir, "block.parquet"))
+		require.NoError(t, err)
+
+		require.Len(t, blocks, 2)
+		require.EqualValues(t, 1, *blocks[0].Height)
+		require.Equal(t, "2023-01-02T03:04:05Z", *blocks[0].BlockTime)
+		require.Equal(t, "AAA", *blocks[0].Proposer)
+		require.EqualValues(t, 2, *blocks[1].Height)
+		require.Nil(t, blocks[1].BlockTime)
+	})
+
+	t.Run("no evm logs", func(t *testing.T) {
+		ctx := context.Background()
+		db := migratedDB()
+		defer db.Close()
+
+		tc, err := CreateTestCase(ctx, db, "TestExport", "abc123")
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		paths, err := NewQuery(db).Export(ctx, tc.id, ExportJSONL, dir)
+		require.NoError(t, err)
+		require.Len(t, paths, 6)
+		require.NoFileExists(t, filepath.Join(dir, "evm_log.jsonl"))
+	})
+
+	t.Run("unknown test case", func(t *testing.T) {
+		q, _ := exportFixture(t)
+
+		_, err := q.Export(ctx, 1000, ExportCSV, t.TempDir())
+		require.Error(t, err)
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"csv", "jsonl", "parquet"} {
+		f, err := ParseExportFormat(s)
+		require.NoError(t, err)
+		require.EqualValues(t, s, f)
+	}
+
+	_, err := ParseExportFormat("xlsx")
+	require.Error(t, err)
+}
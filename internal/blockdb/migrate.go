@@ -148,6 +148,57 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS relayer_exec (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    container_name TEXT,
+    command TEXT NOT NULL, -- JSON array of the command arguments
+    stdout TEXT NOT NULL,
+    stderr TEXT NOT NULL,
+    exit_code INTEGER NOT NULL,
+    error TEXT,
+    started_at TEXT NOT NULL CHECK (length(started_at) > 0), -- RFC3339 with nanoseconds
+    finished_at TEXT NOT NULL CHECK (length(finished_at) > 0),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table relayer_exec: %w", err)
+	}
+
+	for _, col := range []struct{ table, name, def string }{
+		{"block", "block_time", "TEXT"}, // RFC3339 with nanoseconds, the time in the block header.
+		{"block", "proposer", "TEXT"},
+		{"block", "app_hash", "TEXT"},
+		{"block", "num_txs", "INTEGER"},
+		{"tx", "gas_wanted", "INTEGER"},
+		{"tx", "gas_used", "INTEGER"},
+		{"tx", "code", "INTEGER"},
+		{"tx", "codespace", "TEXT"},
+		{"tx", "fee", "TEXT"},
+		{"tx", "hash", "TEXT"}, // Upper case hex.
+		{"tx", "decode_error", "TEXT"},
+	} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, col.table, col.name, col.def))
+		if errIgnoreDuplicateColumn(err, col.name) != nil {
+			return fmt.Errorf("alter table %s add %s: %w", col.table, col.name, err)
+		}
+	}
+
+	// Indexes for searching txs by hash, event type and event attributes, and relayer execs by test case.
+	for _, idx := range []struct{ name, def string }{
+		{"idx_tx_hash", "tx(hash)"},
+		{"idx_tendermint_event_type", "tendermint_event(type)"},
+		{"idx_tendermint_event_fk_tx_id", "tendermint_event(fk_tx_id)"},
+		{"idx_tendermint_event_attr_key_value", "tendermint_event_attr(key, value)"},
+		{"idx_tendermint_event_attr_fk_event_id", "tendermint_event_attr(fk_event_id)"},
+		{"idx_relayer_exec_fk_test_id", "relayer_exec(fk_test_id)"},
+	} {
+		_, err = tx.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s`, idx.name, idx.def))
+		if err != nil {
+			return fmt.Errorf("create index %s: %w", idx.name, err)
+		}
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
@@ -183,8 +234,17 @@ SELECT
   , block.id as block_id
   , block.created_at as block_created_at
   , block.height as block_height
+  , block.block_time as block_time
+  , block.proposer as block_proposer
   , tx.id as tx_id
   , tx.data as tx
+  , tx.gas_wanted as tx_gas_wanted
+  , tx.gas_used as tx_gas_used
+  , tx.code as tx_code
+  , tx.codespace as tx_codespace
+  , tx.fee as tx_fee
+  , tx.hash as tx_hash
+  , tx.decode_error as tx_decode_error
 FROM tx
 LEFT JOIN block ON tx.fk_block_id = block.id
 LEFT JOIN chain ON block.fk_chain_id = chain.id
@@ -259,6 +319,7 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
      , chain.chain_type AS chain_type
      , MAX(COALESCE(block.height, 0)) AS chain_height
      , COUNT(tx.data) AS tx_total
+     , SUM(tx.gas_used) AS gas_used_total
     FROM test_case
 	LEFT JOIN chain ON chain.fk_test_id = test_case.id
 	LEFT JOIN block ON block.fk_chain_id = chain.id
@@ -269,6 +330,34 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_tx_agg view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_block`)
+	if err != nil {
+		return fmt.Errorf("drop old v_block view: %w", err)
+	}
+
+	// block_interval_ms is the time since the previous saved block of the chain, NULL without block times.
+	_, err = tx.Exec(`CREATE VIEW v_block AS
+SELECT
+  chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , block.block_time AS block_time
+  , CAST(ROUND((julianday(block.block_time) - julianday(
+      LAG(block.block_time) OVER (PARTITION BY block.fk_chain_id ORDER BY block.height)
+    )) * 86400000) AS INTEGER) AS block_interval_ms
+  , block.proposer AS proposer
+  , block.app_hash AS app_hash
+  , block.num_txs AS num_txs
+  , (SELECT SUM(tx.gas_wanted) FROM tx WHERE tx.fk_block_id = block.id) AS gas_wanted
+  , (SELECT SUM(tx.gas_used) FROM tx WHERE tx.fk_block_id = block.id) AS gas_used
+FROM block
+INNER JOIN chain ON block.fk_chain_id = chain.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_block view: %w", err)
+	}
+
 	return nil
 }
 
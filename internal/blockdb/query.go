@@ -59,12 +59,13 @@ type TestCaseResult struct {
 	ChainType   string // E.g. cosmos, penumbra
 	ChainHeight sql.NullInt64
 	TxTotal     sql.NullInt64
+	GasUsed     sql.NullInt64 // Total gas used by the chain's transactions.
 }
 
 // RecentTestCases returns aggregated data for each test case and chain combination.
 func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResult, error) {
 	rows, err := q.db.QueryContext(ctx, `SELECT 
-        test_case_id, test_case_created_at, test_case_name, test_case_git_sha, chain_kid, chain_id, chain_type, chain_height, tx_total
+        test_case_id, test_case_created_at, test_case_name, test_case_git_sha, chain_kid, chain_id, chain_type, chain_height, tx_total, gas_used_total
     FROM v_tx_agg 
     WHERE chain_kid IS NOT NULL
     ORDER BY test_case_id DESC, chain_id ASC LIMIT ?`, limit)
@@ -88,6 +89,7 @@ func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResul
 			&res.ChainType,
 			&res.ChainHeight,
 			&res.TxTotal,
+			&res.GasUsed,
 		); err != nil {
 			return nil, err
 		}
@@ -172,12 +174,21 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 type TxResult struct {
 	Height int64
 	Tx     []byte
+	Hash   sql.NullString
+	// Set if the tx could not be decoded, in which case Tx holds the raw bytes.
+	DecodeError sql.NullString
+
+	GasWanted sql.NullInt64
+	GasUsed   sql.NullInt64
+	Code      sql.NullInt64
+	Codespace sql.NullString
+	Fee       sql.NullString
 }
 
 // Transactions returns TxResults only for blocks with transactions present.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
+	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data, tx.hash, tx.decode_error, tx.gas_wanted, tx.gas_used, tx.code, tx.codespace, tx.fee FROM tx 
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
     WHERE chain.id = ?
@@ -190,7 +201,7 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 	var results []TxResult
 	for rows.Next() {
 		var res TxResult
-		if err := rows.Scan(&res.Height, &res.Tx); err != nil {
+		if err := rows.Scan(&res.Height, &res.Tx, &res.Hash, &res.DecodeError, &res.GasWanted, &res.GasUsed, &res.Code, &res.Codespace, &res.Fee); err != nil {
 			return nil, err
 		}
 		results = append(results, res)
@@ -198,3 +209,59 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 
 	return results, nil
 }
+
+// BlockResult is a block's header and the gas of its transactions.
+// The header columns are null for chains which do not report them.
+type BlockResult struct {
+	Height    int64
+	Time      sql.NullTime  // Always set to user's local time zone.
+	Interval  sql.NullInt64 // Milliseconds since the previous saved block.
+	Proposer  sql.NullString
+	AppHash   sql.NullString
+	NumTxs    sql.NullInt64
+	GasWanted sql.NullInt64
+	GasUsed   sql.NullInt64
+}
+
+// Blocks returns every saved block of the chain.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Blocks(ctx context.Context, chainPkey int64) ([]BlockResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        block_height, block_time, block_interval_ms, proposer, app_hash, num_txs, gas_wanted, gas_used
+    FROM v_block
+    WHERE chain_kid = ?
+    ORDER BY block_height ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []BlockResult
+	for rows.Next() {
+		var (
+			res       BlockResult
+			blockTime sql.NullString
+		)
+		if err := rows.Scan(
+			&res.Height,
+			&blockTime,
+			&res.Interval,
+			&res.Proposer,
+			&res.AppHash,
+			&res.NumTxs,
+			&res.GasWanted,
+			&res.GasUsed,
+		); err != nil {
+			return nil, err
+		}
+		if blockTime.Valid {
+			t, err := time.Parse(time.RFC3339Nano, blockTime.String)
+			if err != nil {
+				return nil, fmt.Errorf("parse block time: %w", err)
+			}
+			res.Time = sql.NullTime{Time: t.In(time.Local), Valid: true}
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
@@ -45,8 +45,8 @@ func TestQuery_RecentTestCases(t *testing.T) {
 		require.NoError(t, err)
 		c, err := tc.AddChain(ctx, "chain-b", "cosmos")
 		require.NoError(t, err)
-		require.NoError(t, c.SaveBlock(ctx, 10, []Tx{{Data: []byte("tx1")}, {Data: []byte("tx2")}}))
-		require.NoError(t, c.SaveBlock(ctx, 11, []Tx{{Data: []byte("tx3")}}))
+		require.NoError(t, c.SaveBlock(ctx, 10, BlockHeader{}, []Tx{{Data: []byte("tx1"), GasUsed: 10}, {Data: []byte("tx2"), GasUsed: 20}}))
+		require.NoError(t, c.SaveBlock(ctx, 11, BlockHeader{}, []Tx{{Data: []byte("tx3")}}))
 
 		_, err = tc.AddChain(ctx, "chain-a", "cosmos")
 		require.NoError(t, err)
@@ -70,6 +70,7 @@ func TestQuery_RecentTestCases(t *testing.T) {
 		require.EqualValues(t, 2, got.ChainPKey)
 		require.Zero(t, got.ChainHeight.Int64)
 		require.Zero(t, got.TxTotal.Int64)
+		require.False(t, got.GasUsed.Valid)
 
 		// With blocks and txs.
 		got = results[1]
@@ -81,6 +82,7 @@ func TestQuery_RecentTestCases(t *testing.T) {
 		require.EqualValues(t, 1, got.ChainPKey)
 		require.EqualValues(t, 11, got.ChainHeight.Int64)
 		require.EqualValues(t, 3, got.TxTotal.Int64)
+		require.EqualValues(t, 30, got.GasUsed.Int64)
 	})
 
 	t.Run("limit", func(t *testing.T) {
@@ -133,7 +135,7 @@ func TestQuery_CosmosMessages(t *testing.T) {
 
 	for i, tx := range txs {
 		require.NotEmpty(t, tx.Raw)
-		err = chain.SaveBlock(ctx, uint64(i+1), []Tx{{Data: []byte(tx.Raw)}})
+		err = chain.SaveBlock(ctx, uint64(i+1), BlockHeader{}, []Tx{{Data: []byte(tx.Raw)}})
 		require.NoError(t, err)
 	}
 
@@ -183,8 +185,8 @@ func TestQuery_Transactions(t *testing.T) {
 		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
 		require.NoError(t, err)
 
-		require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{{Data: []byte(`1`)}}))
-		require.NoError(t, chain.SaveBlock(ctx, 14, []Tx{{Data: []byte(`2`)}, {Data: []byte(`3`)}}))
+		require.NoError(t, chain.SaveBlock(ctx, 12, BlockHeader{}, []Tx{{Data: []byte(`1`)}}))
+		require.NoError(t, chain.SaveBlock(ctx, 14, BlockHeader{}, []Tx{{Data: []byte(`2`), DecodeError: "unknown type"}, {Data: []byte(`3`), Hash: "ABC123", GasWanted: 200, GasUsed: 150, Code: 11, Codespace: "sdk", Fee: "5uatom"}}))
 
 		results, err := NewQuery(db).Transactions(ctx, chain.id)
 		require.NoError(t, err)
@@ -196,9 +198,18 @@ func TestQuery_Transactions(t *testing.T) {
 
 		require.EqualValues(t, 14, results[1].Height)
 		require.Equal(t, "2", string(results[1].Tx))
+		require.Equal(t, "unknown type", results[1].DecodeError.String)
 
 		require.EqualValues(t, 14, results[2].Height)
 		require.Equal(t, "3", string(results[2].Tx))
+		require.Equal(t, "ABC123", results[2].Hash.String)
+		require.EqualValues(t, 200, results[2].GasWanted.Int64)
+		require.EqualValues(t, 150, results[2].GasUsed.Int64)
+		require.EqualValues(t, 11, results[2].Code.Int64)
+		require.Equal(t, "sdk", results[2].Codespace.String)
+		require.Equal(t, "5uatom", results[2].Fee.String)
+		require.False(t, results[0].Fee.Valid)
+		require.False(t, results[2].DecodeError.Valid)
 	})
 
 	t.Run("no txs", func(t *testing.T) {
@@ -216,3 +227,46 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_Blocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, chain.SaveBlock(ctx, 1, BlockHeader{Time: start, Proposer: "AAA", AppHash: "FFF", NumTxs: 1}, []Tx{{Data: []byte(`1`), GasWanted: 20, GasUsed: 10}}))
+	require.NoError(t, chain.SaveBlock(ctx, 2, BlockHeader{Time: start.Add(2 * time.Second), Proposer: "BBB"}, nil))
+	require.NoError(t, chain.SaveBlock(ctx, 3, BlockHeader{}, nil))
+
+	results, err := NewQuery(db).Blocks(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	got := results[0]
+	require.EqualValues(t, 1, got.Height)
+	require.True(t, got.Time.Time.Equal(start))
+	require.Equal(t, time.Local, got.Time.Time.Location())
+	require.False(t, got.Interval.Valid)
+	require.Equal(t, "AAA", got.Proposer.String)
+	require.Equal(t, "FFF", got.AppHash.String)
+	require.EqualValues(t, 1, got.NumTxs.Int64)
+	require.EqualValues(t, 20, got.GasWanted.Int64)
+	require.EqualValues(t, 10, got.GasUsed.Int64)
+
+	got = results[1]
+	require.EqualValues(t, 2000, got.Interval.Int64)
+	require.False(t, got.AppHash.Valid)
+	require.False(t, got.GasUsed.Valid)
+
+	got = results[2]
+	require.False(t, got.Time.Valid)
+	require.False(t, got.Interval.Valid)
+	require.False(t, got.NumTxs.Valid)
+}
@@ -0,0 +1,262 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// RelayerExec is a relayer command executed during a test case,
+// an alternative representation of testreporter.RelayerExecMessage.
+type RelayerExec struct {
+	ContainerName string
+	Command       []string
+
+	Stdout, Stderr string
+	ExitCode       int
+	Error          string
+
+	StartedAt, FinishedAt time.Time
+}
+
+// SaveRelayerExecs tracks relayer commands executed during the test case.
+func (tc *TestCase) SaveRelayerExecs(ctx context.Context, execs []RelayerExec) error {
+	dbTx, err := tc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	for _, exec := range execs {
+		cmd, err := json.Marshal(exec.Command)
+		if err != nil {
+			return fmt.Errorf("marshal command: %w", err)
+		}
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO relayer_exec(fk_test_id, container_name, command, stdout, stderr, exit_code, error, started_at, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			tc.id, nullString(exec.ContainerName), string(cmd), exec.Stdout, exec.Stderr, exec.ExitCode, nullString(exec.Error),
+			exec.StartedAt.UTC().Format(time.RFC3339Nano), exec.FinishedAt.UTC().Format(time.RFC3339Nano))
+		if err != nil {
+			return fmt.Errorf("insert into relayer_exec: %w", err)
+		}
+	}
+	return dbTx.Commit()
+}
+
+// RelayerExecResult is a relayer command of a test case.
+type RelayerExecResult struct {
+	ID            int64
+	ContainerName sql.NullString
+	Command       []string
+
+	Stdout, Stderr string
+	ExitCode       int
+	Error          sql.NullString
+
+	// Always set to user's local time zone.
+	StartedAt, FinishedAt time.Time
+}
+
+// RelayerTxResult is a tx relaying packets, i.e. with a MsgRecvPacket or MsgAcknowledgement.
+type RelayerTxResult struct {
+	ChainID string
+	Height  int64
+	Time    sql.NullTime // Block time, always set to user's local time zone.
+	Code    sql.NullInt64
+
+	MsgTypes []string // Type urls of the relaying messages, without duplicates.
+	Signer   string   // Signer of the first relaying message.
+
+	// ExecID is the RelayerExecResult.ID of the relayer command which produced the tx, or 0 if unknown.
+	ExecID int64
+}
+
+// TimelineEntry is either a relayer command or a tx relaying packets.
+type TimelineEntry struct {
+	Exec *RelayerExecResult
+	Tx   *RelayerTxResult
+}
+
+// Time is when the relayer command started or the block time of the tx.
+func (e TimelineEntry) Time() time.Time {
+	if e.Exec != nil {
+		return e.Exec.StartedAt
+	}
+	return e.Tx.Time.Time
+}
+
+func (e TimelineEntry) hasTime() bool {
+	return e.Exec != nil || e.Tx.Time.Valid
+}
+
+// RelayerTxWindow is how long after a relayer command finished a tx may land in a block and still be attributed
+// to the command.
+const RelayerTxWindow = 5 * time.Second
+
+var relayerMsgTypes = []any{
+	"/ibc.core.channel.v1.MsgRecvPacket",
+	"/ibc.core.channel.v1.MsgAcknowledgement",
+}
+
+// RelayerTimeline returns the relayer commands of the test case with primary key testCaseID, interleaved with the
+// txs relaying packets on any chain of the test case, sorted by time.
+//
+// A tx is attributed to the latest command running when the tx landed in a block, allowing RelayerTxWindow for
+// the tx to be committed. Otherwise, it's attributed to the last command whose txs had the same signer,
+// e.g. a command that started a relayer which kept relaying in the background.
+// Txs without a block time are sorted last and never attributed.
+func (q *Query) RelayerTimeline(ctx context.Context, testCaseID int64) ([]TimelineEntry, error) {
+	execs, err := q.RelayerExecs(ctx, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query relayer execs: %w", err)
+	}
+	txs, err := q.relayerTxs(ctx, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query relayer txs: %w", err)
+	}
+	matchRelayerTxs(execs, txs)
+
+	entries := make([]TimelineEntry, 0, len(execs)+len(txs))
+	for i := range execs {
+		entries = append(entries, TimelineEntry{Exec: &execs[i]})
+	}
+	for i := range txs {
+		entries = append(entries, TimelineEntry{Tx: &txs[i]})
+	}
+	// Stable, so commands sort before txs at the same time.
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.hasTime() != b.hasTime() {
+			return a.hasTime()
+		}
+		return a.Time().Before(b.Time())
+	})
+	return entries, nil
+}
+
+// matchRelayerTxs sets the ExecID of txs. Execs must be sorted by start time and txs by block time.
+func matchRelayerTxs(execs []RelayerExecResult, txs []RelayerTxResult) {
+	lastExecBySigner := make(map[string]int64)
+	for i := range txs {
+		tx := &txs[i]
+		if !tx.Time.Valid {
+			continue
+		}
+		for _, exec := range execs {
+			if exec.StartedAt.After(tx.Time.Time) {
+				break
+			}
+			if !tx.Time.Time.After(exec.FinishedAt.Add(RelayerTxWindow)) {
+				tx.ExecID = exec.ID
+			}
+		}
+		if tx.Signer == "" {
+			continue
+		}
+		if tx.ExecID == 0 {
+			tx.ExecID = lastExecBySigner[tx.Signer]
+			continue
+		}
+		lastExecBySigner[tx.Signer] = tx.ExecID
+	}
+}
+
+// RelayerExecs returns the relayer commands of the test case with primary key testCaseID, sorted by start time.
+func (q *Query) RelayerExecs(ctx context.Context, testCaseID int64) ([]RelayerExecResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        id, container_name, command, stdout, stderr, exit_code, error, started_at, finished_at
+    FROM relayer_exec
+    WHERE fk_test_id = ?
+    ORDER BY id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []RelayerExecResult
+	for rows.Next() {
+		var (
+			res                   RelayerExecResult
+			cmd                   string
+			startedAt, finishedAt string
+		)
+		if err := rows.Scan(&res.ID, &res.ContainerName, &cmd, &res.Stdout, &res.Stderr, &res.ExitCode, &res.Error, &startedAt, &finishedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(cmd), &res.Command); err != nil {
+			return nil, fmt.Errorf("unmarshal command: %w", err)
+		}
+		if res.StartedAt, err = timeToLocal(startedAt); err != nil {
+			return nil, fmt.Errorf("parse started_at: %w", err)
+		}
+		if res.FinishedAt, err = timeToLocal(finishedAt); err != nil {
+			return nil, fmt.Errorf("parse finished_at: %w", err)
+		}
+		results = append(results, res)
+	}
+	// Times are sorted in Go because RFC3339 strings with fractional seconds do not sort lexically.
+	sort.SliceStable(results, func(i, j int) bool { return results[i].StartedAt.Before(results[j].StartedAt) })
+	return results, rows.Err()
+}
+
+// relayerTxs returns the txs relaying packets on any chain of the test case, sorted by block time.
+func (q *Query) relayerTxs(ctx context.Context, testCaseID int64) ([]RelayerTxResult, error) {
+	// json_each fails on invalid JSON, so those txs are replaced by an empty object.
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        tx.id, chain.chain_id, block.height, block.block_time, tx.code
+        , json_extract(msg.value, '$.@type'), COALESCE(json_extract(msg.value, '$.signer'), '')
+    FROM tx
+      INNER JOIN block ON tx.fk_block_id = block.id
+      INNER JOIN chain ON block.fk_chain_id = chain.id,
+      json_each(CASE WHEN json_valid(tx.data) THEN tx.data ELSE '{}' END, '$.body.messages') AS msg
+    WHERE chain.fk_test_id = ? AND json_extract(msg.value, '$.@type') IN (?, ?)
+    ORDER BY chain.chain_id ASC, block.height ASC, tx.id ASC, msg.key ASC`,
+		append([]any{testCaseID}, relayerMsgTypes...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		results []RelayerTxResult
+		lastID  int64
+	)
+	for rows.Next() {
+		var (
+			res       RelayerTxResult
+			txID      int64
+			blockTime sql.NullString
+			msgType   string
+			signer    string
+		)
+		if err := rows.Scan(&txID, &res.ChainID, &res.Height, &blockTime, &res.Code, &msgType, &signer); err != nil {
+			return nil, err
+		}
+		// Rows are per message, so group the messages of a tx.
+		if txID == lastID {
+			last := &results[len(results)-1]
+			if !slices.Contains(last.MsgTypes, msgType) {
+				last.MsgTypes = append(last.MsgTypes, msgType)
+			}
+			continue
+		}
+		lastID = txID
+
+		if blockTime.Valid {
+			t, err := time.Parse(time.RFC3339Nano, blockTime.String)
+			if err != nil {
+				return nil, fmt.Errorf("parse block_time: %w", err)
+			}
+			res.Time = sql.NullTime{Time: t.In(time.Local), Valid: true}
+		}
+		res.MsgTypes = []string{msgType}
+		res.Signer = signer
+		results = append(results, res)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Time.Time.Before(results[j].Time.Time) })
+	return results, rows.Err()
+}
@@ -0,0 +1,131 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func relayerMsgTx(msgType, signer string) Tx {
+	return Tx{Data: []byte(fmt.Sprintf(`{"body":{"messages":[{"@type":"/ibc.core.client.v1.MsgUpdateClient","signer":%[2]q},{"@type":%[1]q,"signer":%[2]q}]}}`, msgType, signer))}
+}
+
+func TestQuery_RelayerTimeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestRelayer", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	require.NoError(t, tc.SaveRelayerExecs(ctx, []RelayerExec{
+		{
+			ContainerName: "rly-1",
+			Command:       []string{"rly", "tx", "flush"},
+			Stdout:        "flushed",
+			StartedAt:     at(0),
+			FinishedAt:    at(2 * time.Second),
+		},
+		{
+			Command:    []string{"rly", "start"},
+			Stderr:     "boom",
+			ExitCode:   1,
+			Error:      "exit code 1",
+			StartedAt:  at(time.Minute),
+			FinishedAt: at(time.Minute + 500*time.Millisecond),
+		},
+	}))
+
+	recv := relayerMsgTx("/ibc.core.channel.v1.MsgRecvPacket", "cosmos1relayer")
+	ack := relayerMsgTx("/ibc.core.channel.v1.MsgAcknowledgement", "cosmos1relayer")
+	other := relayerMsgTx("/ibc.core.channel.v1.MsgRecvPacket", "cosmos1other")
+	// In the window of the flush command.
+	require.NoError(t, chainB.SaveBlock(ctx, 1, BlockHeader{Time: at(3 * time.Second)}, []Tx{recv, {Data: []byte(`not json`)}}))
+	// After the window, but the same signer.
+	require.NoError(t, chainA.SaveBlock(ctx, 1, BlockHeader{Time: at(30 * time.Second)}, []Tx{ack}))
+	// After the window with an unknown signer.
+	require.NoError(t, chainA.SaveBlock(ctx, 2, BlockHeader{Time: at(40 * time.Second)}, []Tx{other, {Data: []byte(`{"body":{"messages":[]}}`)}}))
+	// No block time.
+	require.NoError(t, chainA.SaveBlock(ctx, 3, BlockHeader{}, []Tx{recv}))
+
+	entries, err := NewQuery(db).RelayerTimeline(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+
+	flush := entries[0].Exec
+	require.NotNil(t, flush)
+	require.Equal(t, []string{"rly", "tx", "flush"}, flush.Command)
+	require.Equal(t, "rly-1", flush.ContainerName.String)
+	require.Equal(t, "flushed", flush.Stdout)
+	require.False(t, flush.Error.Valid)
+	require.True(t, at(0).Equal(flush.StartedAt))
+	require.True(t, at(2*time.Second).Equal(flush.FinishedAt))
+
+	tx := entries[1].Tx
+	require.NotNil(t, tx)
+	require.Equal(t, "chain-b", tx.ChainID)
+	require.EqualValues(t, 1, tx.Height)
+	require.Equal(t, []string{"/ibc.core.channel.v1.MsgRecvPacket"}, tx.MsgTypes)
+	require.Equal(t, "cosmos1relayer", tx.Signer)
+	require.Equal(t, flush.ID, tx.ExecID)
+
+	require.Equal(t, "chain-a", entries[2].Tx.ChainID)
+	require.Equal(t, flush.ID, entries[2].Tx.ExecID)
+
+	require.Equal(t, "cosmos1other", entries[3].Tx.Signer)
+	require.Zero(t, entries[3].Tx.ExecID)
+
+	start2 := entries[4].Exec
+	require.Equal(t, 1, start2.ExitCode)
+	require.Equal(t, "exit code 1", start2.Error.String)
+
+	require.False(t, entries[5].Tx.Time.Valid)
+	require.Zero(t, entries[5].Tx.ExecID)
+
+	// Execs of other test cases are never found.
+	other2, err := CreateTestCase(ctx, db, "TestOther", "abc123")
+	require.NoError(t, err)
+	entries, err = NewQuery(db).RelayerTimeline(ctx, other2.id)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestMatchRelayerTxs(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) sql.NullTime { return sql.NullTime{Time: start.Add(d), Valid: true} }
+
+	execs := []RelayerExecResult{
+		{ID: 1, StartedAt: start, FinishedAt: start.Add(10 * time.Second)},
+		// Overlaps the first.
+		{ID: 2, StartedAt: start.Add(time.Second), FinishedAt: start.Add(2 * time.Second)},
+	}
+	txs := []RelayerTxResult{
+		{Time: at(1500 * time.Millisecond), Signer: "a"},
+		{Time: at(12 * time.Second), Signer: "b"},
+		{Time: at(time.Minute), Signer: "a"},
+		{Time: at(time.Minute), Signer: "b"},
+	}
+	matchRelayerTxs(execs, txs)
+
+	var got []int64
+	for _, tx := range txs {
+		got = append(got, tx.ExecID)
+	}
+	// The latest running command wins.
+	require.Equal(t, []int64{2, 1, 2, 1}, got)
+}
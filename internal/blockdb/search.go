@@ -0,0 +1,116 @@
+package blockdb
+
+import (
+	"context"
+	"strings"
+)
+
+// TxFilter selects transactions by their messages, events and hash. Empty fields match any transaction.
+type TxFilter struct {
+	// MsgType is the type url, or a suffix of it, of any message in the tx, e.g. MsgSend.
+	MsgType string
+	// Sender is the sender, from_address or signer of any message, or the sender attribute of any event.
+	Sender string
+	// EventType is the type of any event of the tx, e.g. wasm.
+	EventType string
+	// AttrKey and AttrValue match an attribute of any event, or an event of EventType if set.
+	// An empty AttrValue matches any value.
+	AttrKey   string
+	AttrValue string
+	// Hash is the tx hash, or a prefix of it, in hex.
+	Hash string
+	// Text is a substring of the tx or of any event attribute value.
+	Text string
+}
+
+// IsZero reports whether the filter matches every transaction.
+func (f TxFilter) IsZero() bool {
+	return f == TxFilter{}
+}
+
+// TxSearchResult is a transaction of a chain in a test case.
+type TxSearchResult struct {
+	ChainID string
+	TxResult
+}
+
+// SearchTxs returns the transactions of all chains in the test case with primary key testCaseID which match filter,
+// sorted by chain id, height and position in the block.
+func (q *Query) SearchTxs(ctx context.Context, testCaseID int64, filter TxFilter) ([]TxSearchResult, error) {
+	var (
+		where = []string{"chain.fk_test_id = ?"}
+		args  = []any{testCaseID}
+	)
+	and := func(cond string, condArgs ...any) {
+		where = append(where, cond)
+		args = append(args, condArgs...)
+	}
+
+	if filter.MsgType != "" {
+		and(anyMessage(`json_extract(value, '$.@type') LIKE '%' || ?`), filter.MsgType)
+	}
+	if filter.Sender != "" {
+		and(`(`+anyMessage(`? IN (json_extract(value, '$.sender'), json_extract(value, '$.from_address'), json_extract(value, '$.signer'))`)+` OR tx.id IN (
+    SELECT tendermint_event.fk_tx_id FROM tendermint_event_attr
+      INNER JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+    WHERE tendermint_event_attr.key = 'sender' AND tendermint_event_attr.value = ?
+  ))`, filter.Sender, filter.Sender)
+	}
+	switch {
+	case filter.AttrKey != "":
+		cond := `tx.id IN (
+    SELECT tendermint_event.fk_tx_id FROM tendermint_event_attr
+      INNER JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+    WHERE tendermint_event_attr.key = ?`
+		condArgs := []any{filter.AttrKey}
+		if filter.AttrValue != "" {
+			cond += ` AND tendermint_event_attr.value = ?`
+			condArgs = append(condArgs, filter.AttrValue)
+		}
+		if filter.EventType != "" {
+			cond += ` AND tendermint_event.type = ?`
+			condArgs = append(condArgs, filter.EventType)
+		}
+		and(cond+`)`, condArgs...)
+	case filter.EventType != "":
+		and(`tx.id IN (SELECT fk_tx_id FROM tendermint_event WHERE type = ?)`, filter.EventType)
+	}
+	if filter.Hash != "" {
+		and(`tx.hash LIKE ? || '%'`, strings.ToUpper(filter.Hash))
+	}
+	if filter.Text != "" {
+		and(`(instr(tx.data, ?) > 0 OR tx.id IN (
+    SELECT tendermint_event.fk_tx_id FROM tendermint_event_attr
+      INNER JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+    WHERE instr(tendermint_event_attr.value, ?) > 0
+  ))`, filter.Text, filter.Text)
+	}
+
+	rows, err := q.db.QueryContext(ctx, `SELECT chain.chain_id, block.height, tx.data, tx.hash, tx.decode_error, tx.gas_wanted, tx.gas_used, tx.code, tx.codespace, tx.fee FROM tx
+  INNER JOIN block ON tx.fk_block_id = block.id
+  INNER JOIN chain ON block.fk_chain_id = chain.id
+WHERE `+strings.Join(where, "\n  AND ")+`
+ORDER BY chain.chain_id ASC, block.height ASC, tx.id ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TxSearchResult
+	for rows.Next() {
+		var res TxSearchResult
+		if err := rows.Scan(&res.ChainID, &res.Height, &res.Tx, &res.Hash, &res.DecodeError, &res.GasWanted, &res.GasUsed, &res.Code, &res.Codespace, &res.Fee); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// anyMessage is a condition true if cond holds for any message of the tx.
+// Messages are only searched in valid JSON since json_each fails otherwise. CASE guarantees the order of evaluation.
+func anyMessage(cond string) string {
+	return `CASE WHEN json_valid(tx.data) THEN EXISTS(
+    SELECT 1 FROM json_each(tx.data, '$.body.messages') WHERE ` + cond + `
+  ) ELSE 0 END`
+}
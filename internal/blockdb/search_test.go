@@ -0,0 +1,112 @@
+package blockdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_SearchTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestSearch", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	send := Tx{
+		Data: []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1alice"}]}}`),
+		Hash: "AA11",
+		Events: []Event{
+			{Type: "transfer", Attributes: []EventAttribute{{Key: "sender", Value: "cosmos1alice"}, {Key: "amount", Value: "5uatom"}}},
+		},
+	}
+	wasm := Tx{
+		Data: []byte(`{"body":{"messages":[{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","sender":"cosmos1bob"}]}}`),
+		Hash: "BB22",
+		Events: []Event{
+			{Type: "wasm", Attributes: []EventAttribute{{Key: "merkle", Value: "deadbeef"}}},
+			{Type: "message", Attributes: []EventAttribute{{Key: "merkle", Value: "other"}}},
+		},
+	}
+	require.NoError(t, chainA.SaveBlock(ctx, 1, BlockHeader{}, []Tx{send, {Data: []byte(`not json`)}}))
+	require.NoError(t, chainB.SaveBlock(ctx, 5, BlockHeader{}, []Tx{wasm}))
+
+	// Txs of other test cases are never found.
+	other, err := CreateTestCase(ctx, db, "TestOther", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{send}))
+
+	for _, tt := range []struct {
+		Name   string
+		Filter TxFilter
+		Want   []string // Hashes of the found txs.
+	}{
+		{"all", TxFilter{}, []string{"AA11", "", "BB22"}},
+		{"msg type suffix", TxFilter{MsgType: "MsgSend"}, []string{"AA11"}},
+		{"msg type url", TxFilter{MsgType: "/cosmwasm.wasm.v1.MsgExecuteContract"}, []string{"BB22"}},
+		{"sender in message", TxFilter{Sender: "cosmos1bob"}, []string{"BB22"}},
+		{"sender in event", TxFilter{Sender: "cosmos1alice"}, []string{"AA11"}},
+		{"event type", TxFilter{EventType: "wasm"}, []string{"BB22"}},
+		{"attr key", TxFilter{AttrKey: "merkle"}, []string{"BB22"}},
+		{"attr key value", TxFilter{AttrKey: "merkle", AttrValue: "deadbeef"}, []string{"BB22"}},
+		{"attr of event type", TxFilter{EventType: "message", AttrKey: "merkle", AttrValue: "deadbeef"}, nil},
+		{"hash prefix", TxFilter{Hash: "bb"}, []string{"BB22"}},
+		{"text in tx", TxFilter{Text: "not js"}, []string{""}},
+		{"text in attr", TxFilter{Text: "5uatom"}, []string{"AA11"}},
+		{"combined", TxFilter{MsgType: "MsgSend", Sender: "cosmos1bob"}, nil},
+		{"no match", TxFilter{MsgType: "MsgTransfer"}, nil},
+	} {
+		results, err := NewQuery(db).SearchTxs(ctx, tc.id, tt.Filter)
+		require.NoError(t, err, tt.Name)
+
+		var got []string
+		for _, res := range results {
+			got = append(got, res.Hash.String)
+		}
+		require.Equal(t, tt.Want, got, tt.Name)
+	}
+
+	results, err := NewQuery(db).SearchTxs(ctx, tc.id, TxFilter{Hash: "BB22"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "chain-b", results[0].ChainID)
+	require.EqualValues(t, 5, results[0].Height)
+	require.JSONEq(t, string(wasm.Data), string(results[0].Tx))
+
+	require.True(t, TxFilter{}.IsZero())
+	require.False(t, TxFilter{Text: "x"}.IsZero())
+}
+
+func TestSearchIndexes(t *testing.T) {
+	t.Parallel()
+
+	db := migratedDB()
+	defer db.Close()
+
+	rows, err := db.Query(`EXPLAIN QUERY PLAN SELECT fk_event_id FROM tendermint_event_attr WHERE key = 'merkle' AND value = 'deadbeef'`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var (
+			id, parent, notUsed int
+			detail              string
+		)
+		require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+		plan = append(plan, detail)
+	}
+	require.NoError(t, rows.Err())
+	require.Contains(t, strings.Join(plan, "\n"), "idx_tendermint_event_attr_key_value")
+}
@@ -39,3 +39,8 @@ func ConnectDB(ctx context.Context, databasePath string) (*sql.DB, error) {
 func nowRFC3339() string {
 	return time.Now().UTC().Format(time.RFC3339)
 }
+
+// nullString stores empty strings as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// RecentTestCasesLimit is the number of recent test cases shown and refreshed by the model.
+const RecentTestCasesLimit = 100
+
+// SetFollow turns follow mode on or off. While following, Refresh re-queries the current view.
+func (m *Model) SetFollow(follow bool) {
+	m.following = follow
+	m.updateStatus()
+}
+
+// Refresh re-queries the test cases and the current main content if the model is following the database,
+// e.g. while a test suite writes new blocks and txs. The selected row or tx stays the same.
+// Refresh must be called from the main goroutine, e.g. by (*tview.Application).QueueUpdateDraw.
+func (m *Model) Refresh(ctx context.Context) {
+	if !m.following {
+		return
+	}
+	// Errors are shown in the status bar instead of a modal, because a modal would be pushed at every refresh.
+	m.refreshErr = m.refresh(ctx)
+	m.refreshedAt = time.Now()
+	m.updateStatus()
+}
+
+func (m *Model) toggleFollow(ctx context.Context) {
+	m.SetFollow(!m.following)
+	m.Refresh(ctx)
+}
+
+func (m *Model) refresh(ctx context.Context) error {
+	testCases, err := m.querySvc.RecentTestCases(ctx, RecentTestCasesLimit)
+	if err != nil {
+		return fmt.Errorf("query recent test cases: %w", err)
+	}
+	m.testCases = testCases
+	// Test case ID and chain identify a row. New test cases are inserted at the top.
+	refreshTable(m.testCasesTable, testCasesView(m), 0, 4)
+
+	switch m.stack.Current() {
+	case cosmosMessagesMain:
+		results, err := m.querySvc.CosmosMessages(ctx, m.chain.ChainPKey)
+		if err != nil {
+			return fmt.Errorf("query cosmos messages: %w", err)
+		}
+		refreshTable(m.frontTable(), cosmosMessagesView(m.chain, results), 0, 1)
+
+	case blocksMain:
+		results, err := m.querySvc.Blocks(ctx, m.chain.ChainPKey)
+		if err != nil {
+			return fmt.Errorf("query blocks: %w", err)
+		}
+		refreshTable(m.frontTable(), blocksView(m.chain, results), 0)
+
+	case relayerTimelineMain:
+		entries, err := m.querySvc.RelayerTimeline(ctx, m.chain.ID)
+		if err != nil {
+			return fmt.Errorf("query relayer timeline: %w", err)
+		}
+		m.timeline = entries
+		// Time, source, height and action identify an entry.
+		refreshTable(m.frontTable(), relayerTimelineView(m.chain, entries), 0, 1, 2, 3)
+
+	case searchMain:
+		view := m.searchView()
+		if view.filter == nil || view.Input.HasFocus() {
+			return nil
+		}
+		results, err := m.querySvc.SearchTxs(ctx, view.testCase.ID, *view.filter)
+		if err != nil {
+			return fmt.Errorf("search txs: %w", err)
+		}
+		view.Results = results
+		refreshTable(view.Table, view.resultsTable(), 0, 1, 2)
+
+	case txDetailMain:
+		// Txs found by a search are not refreshed, so they stay in sync with the search results.
+		if m.stack[len(m.stack)-2] != testCasesMain {
+			return nil
+		}
+		detail := m.txDetailView()
+		if detail.Search.HasFocus() {
+			return nil
+		}
+		results, err := m.querySvc.Transactions(ctx, m.chain.ChainPKey)
+		if err != nil {
+			return fmt.Errorf("query transactions: %w", err)
+		}
+		detail.Refresh(m.chain.ChainID, results)
+	}
+	return nil
+}
+
+func (m *Model) frontTable() *tview.Table {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*tview.Table)
+}
+
+// refreshTable replaces the title and cells of tbl with those of fresh.
+// The selection moves to the row with the same text in keyCols, so it stays stable as rows are inserted.
+func refreshTable(tbl, fresh *tview.Table, keyCols ...int) {
+	row, _ := tbl.GetSelection()
+	rowOffset, colOffset := tbl.GetOffset()
+	key := rowKey(tbl, row, keyCols)
+
+	tbl.Clear()
+	tbl.SetTitle(fresh.GetTitle())
+	for r := 0; r < fresh.GetRowCount(); r++ {
+		for c := 0; c < fresh.GetColumnCount(); c++ {
+			tbl.SetCell(r, c, fresh.GetCell(r, c))
+		}
+	}
+
+	selected := row
+	for r := 1; r < tbl.GetRowCount(); r++ {
+		if rowKey(tbl, r, keyCols) == key {
+			selected = r
+			break
+		}
+	}
+	// Row 0 is the header.
+	selected = max(min(selected, tbl.GetRowCount()-1), 1)
+	tbl.Select(selected, 0)
+	tbl.SetOffset(max(rowOffset+selected-row, 0), colOffset)
+}
+
+func rowKey(tbl *tview.Table, row int, cols []int) string {
+	texts := make([]string, len(cols))
+	for i, col := range cols {
+		texts[i] = tbl.GetCell(row, col).Text
+	}
+	return strings.Join(texts, "\x00")
+}
+
+func statusView() *tview.TextView {
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBackgroundColor(backgroundColor).SetBorderPadding(0, 0, 1, 1)
+	return view
+}
+
+func (m *Model) updateStatus() {
+	m.layout.GetItem(2).(*tview.TextView).SetText(statusText(m.following, m.testCases, m.refreshedAt, m.refreshErr))
+}
+
+// statusText shows whether the model is following and the latest height of each chain of the most recent test case.
+func statusText(following bool, testCases []blockdb.TestCaseResult, refreshedAt time.Time, err error) string {
+	var b strings.Builder
+	if following {
+		b.WriteString("[green]FOLLOWING[-]")
+	} else {
+		b.WriteString("[gray]PAUSED <f> to follow[-]")
+	}
+
+	if len(testCases) > 0 {
+		// Test cases are sorted by most recent first.
+		latest := testCases[0]
+		fmt.Fprintf(&b, "  %s:", tview.Escape(latest.Name))
+		for _, tc := range testCases {
+			if tc.ID != latest.ID {
+				break
+			}
+			fmt.Fprintf(&b, " %s@%d", tview.Escape(tc.ChainID), tc.ChainHeight.Int64)
+		}
+	}
+
+	if !refreshedAt.IsZero() {
+		fmt.Fprintf(&b, "  updated %s", refreshedAt.Format("15:04:05"))
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "  [red]refresh failed: %s[-]", tview.Escape(err.Error()))
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("paused", func(t *testing.T) {
+		querySvc := &mockQueryService{}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 1}})
+
+		model.Refresh(ctx)
+
+		require.Zero(t, querySvc.GotLimit)
+		require.Contains(t, model.layout.GetItem(2).(*tview.TextView).GetText(true), "PAUSED")
+	})
+
+	t.Run("test cases", func(t *testing.T) {
+		querySvc := &mockQueryService{}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 1, ChainPKey: 1, ChainID: "chain-a"},
+			{ID: 1, ChainPKey: 2, ChainID: "chain-b"},
+		})
+		draw(model.RootView())
+
+		model.testCasesTable.Select(2, 0)
+		update := model.Update(ctx)
+
+		// A new test case is inserted at the top.
+		querySvc.TestCases = []blockdb.TestCaseResult{
+			{ID: 2, Name: "TestNew", ChainPKey: 3, ChainID: "chain-a", ChainHeight: sql.NullInt64{Int64: 7, Valid: true}},
+			{ID: 1, ChainPKey: 1, ChainID: "chain-a"},
+			{ID: 1, ChainPKey: 2, ChainID: "chain-b"},
+		}
+		update(runeKey('f'))
+
+		require.Equal(t, RecentTestCasesLimit, querySvc.GotLimit)
+		require.Equal(t, 4, model.testCasesTable.GetRowCount())
+		// Selection stays on chain-b of test case 1.
+		require.Equal(t, 2, model.selectedRow())
+
+		status := model.layout.GetItem(2).(*tview.TextView).GetText(true)
+		require.Contains(t, status, "FOLLOWING")
+		require.Contains(t, status, "TestNew: chain-a@7")
+
+		// Pause again.
+		update(runeKey('f'))
+		querySvc.GotLimit = 0
+		model.Refresh(ctx)
+		require.Zero(t, querySvc.GotLimit)
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		tc := blockdb.TestCaseResult{ID: 1, ChainPKey: 5, ChainID: "chain-a"}
+		querySvc := &mockQueryService{
+			TestCases: []blockdb.TestCaseResult{tc},
+			BlockList: []blockdb.BlockResult{{Height: 1}, {Height: 2}},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), querySvc.TestCases)
+		model.SetFollow(true)
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('b'))
+		model.frontTable().Select(2, 0)
+
+		querySvc.GotChainPkey = 0
+		querySvc.BlockList = append(querySvc.BlockList, blockdb.BlockResult{Height: 3})
+		model.Refresh(ctx)
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+		table := model.frontTable()
+		require.Equal(t, 4, table.GetRowCount())
+		row, _ := table.GetSelection()
+		require.Equal(t, "2", table.GetCell(row, 0).Text)
+	})
+
+	t.Run("tx detail", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			TestCases: []blockdb.TestCaseResult{{ID: 1, ChainPKey: 5, ChainID: "chain-a"}},
+			Txs:       []blockdb.TxResult{{Height: 1, Tx: []byte(`{"tx":1}`)}, {Height: 2, Tx: []byte(`{"tx":2}`)}},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), querySvc.TestCases)
+		model.SetFollow(true)
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		update(runeKey(']'))
+
+		querySvc.Txs = append(querySvc.Txs, blockdb.TxResult{Height: 3, Tx: []byte(`{"tx":3}`), Code: sql.NullInt64{Int64: 5, Valid: true}})
+		model.Refresh(ctx)
+
+		detail := model.txDetailView()
+		require.Equal(t, 3, detail.Pages.GetPageCount())
+		_, primitive := detail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "[Tx 2 of 3]")
+	})
+
+	t.Run("search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			TestCases: []blockdb.TestCaseResult{{ID: 9, ChainPKey: 5, ChainID: "chain-a"}},
+			Found:     []blockdb.TxSearchResult{{ChainID: "chain-a", TxResult: blockdb.TxResult{Height: 3}}},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), querySvc.TestCases)
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('s'))
+
+		// Nothing to refresh before the first search.
+		model.SetFollow(true)
+		model.Refresh(ctx)
+		require.Zero(t, querySvc.GotTestCase)
+
+		model.searchView().Input.SetText("type:MsgSend")
+		update(enterKey)
+
+		querySvc.GotFilter = blockdb.TxFilter{}
+		querySvc.Found = append(querySvc.Found, blockdb.TxSearchResult{
+			ChainID:  "chain-a",
+			TxResult: blockdb.TxResult{Height: 4, Code: sql.NullInt64{Int64: 5, Valid: true}},
+		})
+		model.Refresh(ctx)
+
+		require.Equal(t, blockdb.TxFilter{MsgType: "MsgSend"}, querySvc.GotFilter)
+		table := model.searchView().Table
+		require.Equal(t, 3, table.GetRowCount())
+		require.Len(t, model.searchView().Results, 2)
+		// Failed txs are highlighted.
+		require.Equal(t, textColor, table.GetCell(1, 0).Color)
+		require.Equal(t, errorTextColor, table.GetCell(2, 0).Color)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 1}})
+		draw(model.RootView())
+
+		model.Update(ctx)(runeKey('f'))
+
+		// Errors do not push a modal, since refresh repeats in the background.
+		require.Equal(t, 1, model.mainContentView().GetPageCount())
+		require.Contains(t, model.layout.GetItem(2).(*tview.TextView).GetText(true), "refresh failed: query recent test cases: boom")
+	})
+}
@@ -14,6 +14,7 @@ type keyBinding struct {
 
 var baseHelpKeys = []keyBinding{
 	{"esc", "go back"},
+	{"f", "toggle follow"},
 	{"ctl+c", "exit"},
 }
 
@@ -40,8 +41,15 @@ var (
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
+		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"b", "blocks"}, {"s", "search txs"}, {"r", "relayer timeline"}, {"enter", "view txs"}}, tableNavKeys),
 		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		blocksMain:         bindingsWithBase(tableNavKeys),
+		searchMain: bindingsWithBase([]keyBinding{
+			{"/", "edit search"},
+			{"enter", "search or view tx"},
+		}, tableNavKeys),
+		relayerTimelineMain: bindingsWithBase([]keyBinding{{"enter", "view relayer command"}}, tableNavKeys),
+		relayerExecMain:     bindingsWithBase(textNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
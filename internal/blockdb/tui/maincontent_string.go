@@ -12,11 +12,15 @@ func _() {
 	_ = x[cosmosMessagesMain-1]
 	_ = x[txDetailMain-2]
 	_ = x[errorModalMain-3]
+	_ = x[blocksMain-4]
+	_ = x[searchMain-5]
+	_ = x[relayerTimelineMain-6]
+	_ = x[relayerExecMain-7]
 }
 
-const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMain"
+const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMainblocksMainsearchMainrelayerTimelineMainrelayerExecMain"
 
-var _mainContent_index = [...]uint8{0, 13, 31, 43, 57}
+var _mainContent_index = [...]uint8{0, 13, 31, 43, 57, 67, 77, 96, 111}
 
 func (i mainContent) String() string {
 	if i < 0 || i >= mainContent(len(_mainContent_index)-1) {
@@ -19,6 +19,10 @@ const (
 	cosmosMessagesMain
 	txDetailMain
 	errorModalMain
+	blocksMain
+	searchMain
+	relayerTimelineMain
+	relayerExecMain
 )
 
 type mainStack []mainContent
@@ -29,8 +33,12 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 
 // QueryService fetches data from a database.
 type QueryService interface {
+	RecentTestCases(ctx context.Context, limit int) ([]blockdb.TestCaseResult, error)
 	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
 	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	Blocks(ctx context.Context, chainPkey int64) ([]blockdb.BlockResult, error)
+	SearchTxs(ctx context.Context, testCaseID int64, filter blockdb.TxFilter) ([]blockdb.TxSearchResult, error)
+	RelayerTimeline(ctx context.Context, testCaseID int64) ([]blockdb.TimelineEntry, error)
 }
 
 // Model encapsulates state that updates a view.
@@ -41,7 +49,18 @@ type Model struct {
 	schemaDate    time.Time
 	testCases     []blockdb.TestCaseResult
 
-	layout *tview.Flex
+	// chain is the test case and chain of the main content pushed from the test cases view.
+	chain blockdb.TestCaseResult
+	// timeline is shown by the relayer timeline view.
+	timeline []blockdb.TimelineEntry
+
+	// follow mode state
+	following   bool
+	refreshedAt time.Time
+	refreshErr  error
+
+	layout         *tview.Flex
+	testCasesTable *tview.Table
 
 	// stack keeps tracks of primary content pushed and popped
 	stack mainStack
@@ -76,10 +95,13 @@ func NewModel(
 	// The primary view is a page view to act like a stack where we can push and pop views.
 	// Flex and grid views do not allow a "stack-like" behavior.
 	pages := tview.NewPages()
-	pages.AddAndSwitchToPage(m.stack[0].String(), testCasesView(m), true)
+	m.testCasesTable = testCasesView(m)
+	pages.AddAndSwitchToPage(m.stack[0].String(), m.testCasesTable, true)
 	flex.AddItem(pages, 0, 10, true)
+	flex.AddItem(statusView(), 1, 1, false)
 
 	m.layout = flex
+	m.updateStatus()
 
 	return m
 }
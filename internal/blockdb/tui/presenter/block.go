@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Block presents a blockdb.BlockResult.
+type Block struct {
+	Result blockdb.BlockResult
+}
+
+func (p Block) Height() string   { return strconv.FormatInt(p.Result.Height, 10) }
+func (p Block) Proposer() string { return p.Result.Proposer.String }
+func (p Block) AppHash() string  { return p.Result.AppHash.String }
+func (p Block) NumTxs() string   { return formatNullInt(p.Result.NumTxs) }
+
+// Time includes milliseconds, since blocks are usually produced within seconds of each other.
+func (p Block) Time() string {
+	if !p.Result.Time.Valid {
+		return ""
+	}
+	return p.Result.Time.Time.Format("01-02 15:04:05.000")
+}
+
+// Interval is the time since the previous block, e.g. 1.5s.
+func (p Block) Interval() string {
+	if !p.Result.Interval.Valid {
+		return ""
+	}
+	return (time.Duration(p.Result.Interval.Int64) * time.Millisecond).String()
+}
+
+// Gas is the gas used and wanted by the block's transactions, e.g. 81234/200000.
+func (p Block) Gas() string {
+	return formatGas(p.Result.GasUsed, p.Result.GasWanted)
+}
+
+func formatNullInt(n sql.NullInt64) string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatInt(n.Int64, 10)
+}
+
+func formatGas(used, wanted sql.NullInt64) string {
+	if !used.Valid {
+		return ""
+	}
+	return formatNullInt(used) + "/" + formatNullInt(wanted)
+}
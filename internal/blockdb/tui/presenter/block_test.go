@@ -0,0 +1,47 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		result := blockdb.BlockResult{
+			Height:    5,
+			Time:      sql.NullTime{Time: time.Date(2023, 1, 2, 15, 4, 5, 600_000_000, time.UTC), Valid: true},
+			Interval:  sql.NullInt64{Int64: 1500, Valid: true},
+			Proposer:  sql.NullString{String: "C1A4F5A5", Valid: true},
+			AppHash:   sql.NullString{String: "E3B0C442", Valid: true},
+			NumTxs:    sql.NullInt64{Int64: 2, Valid: true},
+			GasWanted: sql.NullInt64{Int64: 200000, Valid: true},
+			GasUsed:   sql.NullInt64{Int64: 81234, Valid: true},
+		}
+
+		pres := Block{result}
+		require.Equal(t, "5", pres.Height())
+		require.Equal(t, "01-02 15:04:05.600", pres.Time())
+		require.Equal(t, "1.5s", pres.Interval())
+		require.Equal(t, "C1A4F5A5", pres.Proposer())
+		require.Equal(t, "E3B0C442", pres.AppHash())
+		require.Equal(t, "2", pres.NumTxs())
+		require.Equal(t, "81234/200000", pres.Gas())
+	})
+
+	t.Run("zero state", func(t *testing.T) {
+		var pres Block
+
+		require.Equal(t, "0", pres.Height())
+		require.Empty(t, pres.Time())
+		require.Empty(t, pres.Interval())
+		require.Empty(t, pres.Proposer())
+		require.Empty(t, pres.NumTxs())
+		require.Empty(t, pres.Gas())
+	})
+}
@@ -0,0 +1,126 @@
+package presenter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// TimelineEntry presents a blockdb.TimelineEntry, either a relayer command or a tx relaying packets.
+type TimelineEntry struct {
+	Entry blockdb.TimelineEntry
+}
+
+// Time includes milliseconds to order commands and txs within the same second.
+func (p TimelineEntry) Time() string {
+	if p.Entry.Tx != nil && !p.Entry.Tx.Time.Valid {
+		return ""
+	}
+	return p.Entry.Time().Format("01-02 15:04:05.000")
+}
+
+// Source is the relayer container of a command or the chain of a tx.
+func (p TimelineEntry) Source() string {
+	if exec := p.Entry.Exec; exec != nil {
+		if exec.ContainerName.Valid {
+			return exec.ContainerName.String
+		}
+		return "relayer"
+	}
+	return p.Entry.Tx.ChainID
+}
+
+func (p TimelineEntry) Height() string {
+	if p.Entry.Tx == nil {
+		return ""
+	}
+	return strconv.FormatInt(p.Entry.Tx.Height, 10)
+}
+
+// Action is the command line of a command, or the short message types of a tx, e.g. "MsgRecvPacket".
+func (p TimelineEntry) Action() string {
+	if exec := p.Entry.Exec; exec != nil {
+		return strings.Join(exec.Command, " ")
+	}
+	types := make([]string, len(p.Entry.Tx.MsgTypes))
+	for i, typ := range p.Entry.Tx.MsgTypes {
+		types[i] = strings.TrimPrefix(path.Ext(typ), ".")
+	}
+	return strings.Join(types, ", ")
+}
+
+func (p TimelineEntry) Signer() string {
+	if p.Entry.Tx == nil {
+		return ""
+	}
+	return p.Entry.Tx.Signer
+}
+
+// Result is the exit code of a command, e.g. "exit 1", or the result code of a tx, e.g. "code 0".
+func (p TimelineEntry) Result() string {
+	if exec := p.Entry.Exec; exec != nil {
+		return "exit " + strconv.Itoa(exec.ExitCode)
+	}
+	if !p.Entry.Tx.Code.Valid {
+		return ""
+	}
+	return "code " + formatNullInt(p.Entry.Tx.Code)
+}
+
+// Exec identifies the command, or the command which produced the tx, e.g. "#3".
+func (p TimelineEntry) Exec() string {
+	id := p.execID()
+	if id == 0 {
+		return ""
+	}
+	return "#" + strconv.FormatInt(id, 10)
+}
+
+func (p TimelineEntry) execID() int64 {
+	if p.Entry.Exec != nil {
+		return p.Entry.Exec.ID
+	}
+	return p.Entry.Tx.ExecID
+}
+
+// Failed is true if the command exited with an error or the tx has a non-zero result code.
+func (p TimelineEntry) Failed() bool {
+	if exec := p.Entry.Exec; exec != nil {
+		return exec.ExitCode != 0 || exec.Error.Valid
+	}
+	return p.Entry.Tx.Code.Valid && p.Entry.Tx.Code.Int64 != 0
+}
+
+// RelayerExecDetail is the full output of the command with the given id and the txs it produced.
+func RelayerExecDetail(entries []blockdb.TimelineEntry, id int64) string {
+	var (
+		b   strings.Builder
+		txs []string
+	)
+	for _, entry := range entries {
+		pres := TimelineEntry{entry}
+		if pres.execID() != id {
+			continue
+		}
+		if exec := entry.Exec; exec != nil {
+			fmt.Fprintf(&b, "Command: %s\n", pres.Action())
+			fmt.Fprintf(&b, "Container: %s\n", pres.Source())
+			fmt.Fprintf(&b, "Started: %s\n", pres.Time())
+			fmt.Fprintf(&b, "Duration: %s\n", exec.FinishedAt.Sub(exec.StartedAt))
+			fmt.Fprintf(&b, "Exit code: %d\n", exec.ExitCode)
+			if exec.Error.Valid {
+				fmt.Fprintf(&b, "Error: %s\n", exec.Error.String)
+			}
+			fmt.Fprintf(&b, "\nStdout:\n%s\n\nStderr:\n%s\n", exec.Stdout, exec.Stderr)
+			continue
+		}
+		txs = append(txs, fmt.Sprintf("%s %s @ Height %s %s %s", pres.Time(), pres.Source(), pres.Height(), pres.Action(), pres.Result()))
+	}
+	if len(txs) > 0 {
+		fmt.Fprintf(&b, "\nTxs:\n%s\n", strings.Join(txs, "\n"))
+	}
+	return b.String()
+}
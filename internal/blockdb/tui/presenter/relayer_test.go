@@ -0,0 +1,81 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineEntry(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 2, 15, 4, 5, 600_000_000, time.UTC)
+	exec := &blockdb.RelayerExecResult{
+		ID:         3,
+		Command:    []string{"rly", "tx", "flush"},
+		Stdout:     "flushed",
+		Stderr:     "warning",
+		StartedAt:  start,
+		FinishedAt: start.Add(1500 * time.Millisecond),
+	}
+	tx := &blockdb.RelayerTxResult{
+		ChainID:  "chain-a",
+		Height:   12,
+		Time:     sql.NullTime{Time: start.Add(2 * time.Second), Valid: true},
+		Code:     sql.NullInt64{Int64: 5, Valid: true},
+		MsgTypes: []string{"/ibc.core.channel.v1.MsgRecvPacket", "/ibc.core.channel.v1.MsgAcknowledgement"},
+		Signer:   "cosmos1relayer",
+		ExecID:   3,
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		pres := TimelineEntry{blockdb.TimelineEntry{Exec: exec}}
+		require.Equal(t, "01-02 15:04:05.600", pres.Time())
+		require.Equal(t, "relayer", pres.Source())
+		require.Empty(t, pres.Height())
+		require.Equal(t, "rly tx flush", pres.Action())
+		require.Empty(t, pres.Signer())
+		require.Equal(t, "exit 0", pres.Result())
+		require.Equal(t, "#3", pres.Exec())
+		require.False(t, pres.Failed())
+
+		failed := *exec
+		failed.ContainerName = sql.NullString{String: "rly-1", Valid: true}
+		failed.ExitCode = 1
+		pres = TimelineEntry{blockdb.TimelineEntry{Exec: &failed}}
+		require.Equal(t, "rly-1", pres.Source())
+		require.True(t, pres.Failed())
+	})
+
+	t.Run("tx", func(t *testing.T) {
+		pres := TimelineEntry{blockdb.TimelineEntry{Tx: tx}}
+		require.Equal(t, "01-02 15:04:07.600", pres.Time())
+		require.Equal(t, "chain-a", pres.Source())
+		require.Equal(t, "12", pres.Height())
+		require.Equal(t, "MsgRecvPacket, MsgAcknowledgement", pres.Action())
+		require.Equal(t, "cosmos1relayer", pres.Signer())
+		require.Equal(t, "code 5", pres.Result())
+		require.Equal(t, "#3", pres.Exec())
+		require.True(t, pres.Failed())
+
+		pres = TimelineEntry{blockdb.TimelineEntry{Tx: &blockdb.RelayerTxResult{MsgTypes: []string{"/ibc.core.channel.v1.MsgRecvPacket"}}}}
+		require.Empty(t, pres.Time())
+		require.Empty(t, pres.Result())
+		require.Empty(t, pres.Exec())
+		require.False(t, pres.Failed())
+	})
+
+	t.Run("detail", func(t *testing.T) {
+		entries := []blockdb.TimelineEntry{{Exec: exec}, {Tx: tx}, {Tx: &blockdb.RelayerTxResult{ChainID: "chain-b", ExecID: 4}}}
+		detail := RelayerExecDetail(entries, 3)
+		require.Contains(t, detail, "Command: rly tx flush\n")
+		require.Contains(t, detail, "Duration: 1.5s\n")
+		require.Contains(t, detail, "Stdout:\nflushed\n")
+		require.Contains(t, detail, "Stderr:\nwarning\n")
+		require.Contains(t, detail, "Txs:\n01-02 15:04:07.600 chain-a @ Height 12 MsgRecvPacket, MsgAcknowledgement code 5\n")
+		require.NotContains(t, detail, "chain-b")
+	})
+}
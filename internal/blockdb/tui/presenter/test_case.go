@@ -30,3 +30,5 @@ func (p TestCase) TxTotal() string {
 	}
 	return strconv.FormatInt(p.Result.TxTotal.Int64, 10)
 }
+
+func (p TestCase) GasUsed() string { return formatNullInt(p.Result.GasUsed) }
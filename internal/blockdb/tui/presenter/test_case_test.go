@@ -23,6 +23,7 @@ func TestTestCase(t *testing.T) {
 			ChainType:   "cosmos",
 			ChainHeight: sql.NullInt64{Int64: 77, Valid: true},
 			TxTotal:     sql.NullInt64{Int64: 88, Valid: true},
+			GasUsed:     sql.NullInt64{Int64: 99, Valid: true},
 		}
 
 		pres := TestCase{result}
@@ -33,6 +34,7 @@ func TestTestCase(t *testing.T) {
 		require.Equal(t, "chain1", pres.ChainID())
 		require.Equal(t, "77", pres.Height())
 		require.Equal(t, "88", pres.TxTotal())
+		require.Equal(t, "99", pres.GasUsed())
 	})
 
 	t.Run("zero state", func(t *testing.T) {
@@ -40,5 +42,6 @@ func TestTestCase(t *testing.T) {
 
 		require.Empty(t, pres.Height())
 		require.Empty(t, pres.TxTotal())
+		require.Empty(t, pres.GasUsed())
 	})
 }
@@ -3,7 +3,9 @@ package presenter
 import (
 	"bytes"
 	"encoding/json"
+	"path"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
@@ -16,6 +18,34 @@ type Tx struct {
 }
 
 func (tx Tx) Height() string { return strconv.FormatInt(tx.Result.Height, 10) }
+func (tx Tx) Hash() string   { return tx.Result.Hash.String }
+func (tx Tx) Code() string   { return formatNullInt(tx.Result.Code) }
+
+// Failed is true if the chain reported a non-zero result code.
+func (tx Tx) Failed() bool { return tx.Result.Code.Valid && tx.Result.Code.Int64 != 0 }
+
+// Gas is the gas used and wanted, e.g. 81234/200000.
+func (tx Tx) Gas() string { return formatGas(tx.Result.GasUsed, tx.Result.GasWanted) }
+
+// MessageTypes is the short type of each message in a cosmos tx, e.g. "MsgSend, MsgTransfer".
+func (tx Tx) MessageTypes() string {
+	var body struct {
+		Body struct {
+			Messages []struct {
+				Type string `json:"@type"`
+			} `json:"messages"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(tx.Result.Tx, &body); err != nil {
+		return ""
+	}
+	types := make([]string, len(body.Body.Messages))
+	for i, msg := range body.Body.Messages {
+		// Type urls look like /cosmos.bank.v1beta1.MsgSend.
+		types[i] = strings.TrimPrefix(path.Ext(msg.Type), ".")
+	}
+	return strings.Join(types, ", ")
+}
 
 // Data attempts to pretty print JSON. If not valid JSON, returns tx data as-is which may not be human-readable.
 func (tx Tx) Data() string {
@@ -29,6 +59,28 @@ func (tx Tx) Data() string {
 	return buf.String()
 }
 
+// Summary summarizes the execution of the tx, e.g. "gas 81234/200000 code 5 (sdk) fee 500uatom",
+// followed by the decode error if the tx could not be decoded.
+// Empty when the chain does not report it.
+func (tx Tx) Summary() string {
+	r := tx.Result
+	var parts []string
+	if r.GasUsed.Valid {
+		s := "gas " + formatGas(r.GasUsed, r.GasWanted) + " code " + formatNullInt(r.Code)
+		if r.Codespace.Valid {
+			s += " (" + r.Codespace.String + ")"
+		}
+		if r.Fee.Valid {
+			s += " fee " + r.Fee.String
+		}
+		parts = append(parts, s)
+	}
+	if r.DecodeError.Valid {
+		parts = append(parts, "decode error: "+r.DecodeError.String)
+	}
+	return strings.Join(parts, " ")
+}
+
 type Txs []blockdb.TxResult
 
 // ToJSON always renders valid JSON given the blockdb.TxResult.
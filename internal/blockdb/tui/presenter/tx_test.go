@@ -1,6 +1,7 @@
 package presenter
 
 import (
+	"database/sql"
 	"encoding/json"
 	"testing"
 
@@ -29,6 +30,47 @@ func TestTx(t *testing.T) {
 		require.Equal(t, want, pres.Data())
 	})
 
+	t.Run("summary", func(t *testing.T) {
+		tx := blockdb.TxResult{
+			GasWanted: sql.NullInt64{Int64: 200000, Valid: true},
+			GasUsed:   sql.NullInt64{Int64: 81234, Valid: true},
+			Code:      sql.NullInt64{Int64: 5, Valid: true},
+			Codespace: sql.NullString{String: "sdk", Valid: true},
+			Fee:       sql.NullString{String: "500uatom", Valid: true},
+		}
+		require.Equal(t, "gas 81234/200000 code 5 (sdk) fee 500uatom", Tx{tx}.Summary())
+
+		tx.Codespace, tx.Fee = sql.NullString{}, sql.NullString{}
+		require.Equal(t, "gas 81234/200000 code 5", Tx{tx}.Summary())
+
+		require.Empty(t, Tx{}.Summary())
+
+		tx.DecodeError = sql.NullString{String: "unable to resolve type URL", Valid: true}
+		require.Equal(t, "gas 81234/200000 code 5 decode error: unable to resolve type URL", Tx{tx}.Summary())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		require.True(t, Tx{blockdb.TxResult{Code: sql.NullInt64{Int64: 5, Valid: true}}}.Failed())
+		require.False(t, Tx{blockdb.TxResult{Code: sql.NullInt64{Valid: true}}}.Failed())
+		require.False(t, Tx{}.Failed())
+	})
+
+	t.Run("message types", func(t *testing.T) {
+		tx := blockdb.TxResult{
+			Tx:   []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"},{"@type":"/ibc.applications.transfer.v1.MsgTransfer"}]}}`),
+			Hash: sql.NullString{String: "ABC", Valid: true},
+			Code: sql.NullInt64{Int64: 5, Valid: true},
+		}
+		pres := Tx{tx}
+		require.Equal(t, "MsgSend, MsgTransfer", pres.MessageTypes())
+		require.Equal(t, "ABC", pres.Hash())
+		require.Equal(t, "5", pres.Code())
+		require.Empty(t, pres.Gas())
+
+		require.Empty(t, Tx{blockdb.TxResult{Tx: []byte(`{"data":"end_block"}`)}}.MessageTypes())
+		require.Empty(t, Tx{blockdb.TxResult{Tx: []byte(`not json`)}}.MessageTypes())
+	})
+
 	t.Run("non-json", func(t *testing.T) {
 		tx := blockdb.TxResult{
 			Tx: []byte(`some data`),
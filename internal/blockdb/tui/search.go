@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb/tui/presenter"
+)
+
+const searchPlaceholder = "type:MsgSend sender:cosmos1... event:wasm attr:key=value hash:ABC12 or any text"
+
+// parseSearchQuery parses space separated terms of the form field:value into a filter.
+// Terms without a known field are searched as text.
+func parseSearchQuery(query string) (blockdb.TxFilter, error) {
+	var (
+		filter blockdb.TxFilter
+		text   []string
+	)
+	for _, term := range strings.Fields(query) {
+		field, value, ok := strings.Cut(term, ":")
+		if !ok {
+			text = append(text, term)
+			continue
+		}
+
+		var dst *string
+		switch field {
+		case "type":
+			dst = &filter.MsgType
+		case "sender":
+			dst = &filter.Sender
+		case "event":
+			dst = &filter.EventType
+		case "hash":
+			dst = &filter.Hash
+		case "attr":
+			key, val, _ := strings.Cut(value, "=")
+			if key == "" {
+				return filter, fmt.Errorf("attr requires a key, e.g. attr:key=value")
+			}
+			filter.AttrKey, filter.AttrValue = key, val
+			continue
+		default:
+			text = append(text, term)
+			continue
+		}
+		if value == "" {
+			return filter, fmt.Errorf("%s requires a value, e.g. %s:value", field, field)
+		}
+		*dst = value
+	}
+	filter.Text = strings.Join(text, " ")
+	return filter, nil
+}
+
+// searchView searches the txs of all chains in a test case.
+type searchView struct {
+	*tview.Flex
+
+	testCase blockdb.TestCaseResult
+	filter   *blockdb.TxFilter // Filter of the last search, if any.
+
+	Input   *tview.InputField
+	Table   *tview.Table
+	Results []blockdb.TxSearchResult
+}
+
+func newSearchView(tc blockdb.TestCaseResult) *searchView {
+	view := &searchView{testCase: tc}
+
+	view.Input = newSearchInput().
+		SetPlaceholder(searchPlaceholder).
+		SetPlaceholderStyle(tcell.Style{}.Foreground(tcell.ColorGray).Background(backgroundColor))
+	view.Table = view.resultsTable()
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorder(false)
+	flex.AddItem(view.Input, 3, 1, false)
+	flex.AddItem(view.Table, 0, 9, true)
+
+	view.Flex = flex
+	return view
+}
+
+// SetResults replaces the results table.
+func (view *searchView) SetResults(results []blockdb.TxSearchResult) {
+	view.Results = results
+	view.Flex.RemoveItem(view.Table)
+	view.Table = view.resultsTable()
+	view.Flex.AddItem(view.Table, 0, 9, true)
+}
+
+// SelectedResult is the index of the selected result, or -1 if there are none.
+func (view *searchView) SelectedResult() int {
+	if len(view.Results) == 0 {
+		return -1
+	}
+	row, _ := view.Table.GetSelection()
+	// Offset by 1 to account for header row.
+	return max(row-1, 0)
+}
+
+func (view *searchView) ActivateSearch() {
+	setSearchActive(view.Input, true)
+	view.Table.Blur()
+}
+
+func (view *searchView) DeactivateSearch() {
+	setSearchActive(view.Input, false)
+	view.Table.Focus(nil)
+}
+
+func (view *searchView) resultsTable() *tview.Table {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Hash",
+		"Messages",
+		"Code",
+		"Gas Used/Wanted",
+	}
+
+	rows := make([][]string, len(view.Results))
+	for i, res := range view.Results {
+		pres := presenter.Tx{Result: res.TxResult}
+		hash := pres.Hash()
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		rows[i] = []string{
+			res.ChainID,
+			pres.Height(),
+			hash,
+			pres.MessageTypes(),
+			pres.Code(),
+			pres.Gas(),
+		}
+	}
+
+	title := fmt.Sprintf("Search %s [%s] %d txs", view.testCase.Name, presenter.FormatTime(view.testCase.CreatedAt), len(view.Results))
+	tbl := detailTableView(title, headers, rows)
+	for i, res := range view.Results {
+		if (presenter.Tx{Result: res.TxResult}).Failed() {
+			highlightFailedRow(tbl, i+1)
+		}
+	}
+	return tbl
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Query string
+		Want  blockdb.TxFilter
+	}{
+		{"", blockdb.TxFilter{}},
+		{"type:MsgSend", blockdb.TxFilter{MsgType: "MsgSend"}},
+		{"sender:cosmos1abc event:wasm", blockdb.TxFilter{Sender: "cosmos1abc", EventType: "wasm"}},
+		{"attr:merkle=deadbeef", blockdb.TxFilter{AttrKey: "merkle", AttrValue: "deadbeef"}},
+		{"attr:merkle", blockdb.TxFilter{AttrKey: "merkle"}},
+		{"attr:url=http://x?a=b", blockdb.TxFilter{AttrKey: "url", AttrValue: "http://x?a=b"}},
+		{"hash:AB12", blockdb.TxFilter{Hash: "AB12"}},
+		{"  out of  gas ", blockdb.TxFilter{Text: "out of gas"}},
+		{"type:MsgTransfer channel-0 ibc:unknown", blockdb.TxFilter{MsgType: "MsgTransfer", Text: "channel-0 ibc:unknown"}},
+	} {
+		got, err := parseSearchQuery(tt.Query)
+		require.NoError(t, err, tt.Query)
+		require.Equal(t, tt.Want, got, tt.Query)
+	}
+
+	for _, query := range []string{"type:", "attr:", "attr:=value", "hash:"} {
+		_, err := parseSearchQuery(query)
+		require.Error(t, err, query)
+	}
+}
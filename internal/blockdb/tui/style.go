@@ -9,5 +9,6 @@ const (
 )
 
 var (
-	textStyle = tcell.Style{}.Foreground(textColor)
+	textStyle   = tcell.Style{}.Foreground(textColor)
+	failedStyle = tcell.Style{}.Foreground(errorTextColor)
 )
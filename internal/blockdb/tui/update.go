@@ -27,9 +27,22 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 				return nil
 			}
 
+		case m.stack.Current() == searchMain && m.searchView().Input.HasFocus():
+			if event.Key() != tcell.KeyEnter {
+				// Let the input handle typing.
+				return event
+			}
+			m.searchTxs(ctx)
+			return nil
+
+		case event.Rune() == 'f' && !(m.stack.Current() == txDetailMain && m.txDetailView().Search.HasFocus()):
+			m.toggleFollow(ctx)
+			return nil
+
 		case event.Key() == tcell.KeyEnter && m.stack.Current() == testCasesMain:
 			// Show tx detail.
 			tc := m.testCases[m.selectedRow()]
+			m.chain = tc
 			results, err := m.querySvc.Transactions(ctx, tc.ChainPKey)
 			if err != nil {
 				m.pushErrorModal(fmt.Errorf("query transactions: %w", err))
@@ -41,6 +54,7 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 		case event.Rune() == 'm' && m.stack.Current() == testCasesMain:
 			// Show cosmos messages.
 			tc := m.testCases[m.selectedRow()]
+			m.chain = tc
 			results, err := m.querySvc.CosmosMessages(ctx, tc.ChainPKey)
 			if err != nil {
 				m.pushErrorModal(fmt.Errorf("query cosmos messages: %w", err))
@@ -49,6 +63,70 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
 			return nil
 
+		case event.Rune() == 'b' && m.stack.Current() == testCasesMain:
+			// Show block headers.
+			tc := m.testCases[m.selectedRow()]
+			m.chain = tc
+			results, err := m.querySvc.Blocks(ctx, tc.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query blocks: %w", err))
+				return nil
+			}
+			m.pushMainView(blocksMain, blocksView(tc, results))
+			return nil
+
+		case event.Rune() == 'r' && m.stack.Current() == testCasesMain:
+			// Show relayer commands and the txs they produced.
+			tc := m.testCases[m.selectedRow()]
+			m.chain = tc
+			entries, err := m.querySvc.RelayerTimeline(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query relayer timeline: %w", err))
+				return nil
+			}
+			m.timeline = entries
+			m.pushMainView(relayerTimelineMain, relayerTimelineView(tc, entries))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == relayerTimelineMain:
+			// Show the relayer command of the selected entry.
+			row := m.selectedRow()
+			if row < 0 || row >= len(m.timeline) {
+				return nil
+			}
+			var id int64
+			if entry := m.timeline[row]; entry.Exec != nil {
+				id = entry.Exec.ID
+			} else {
+				id = entry.Tx.ExecID
+			}
+			if id == 0 {
+				return nil
+			}
+			m.pushMainView(relayerExecMain, relayerExecView(m.timeline, id))
+			return nil
+
+		case event.Rune() == 's' && m.stack.Current() == testCasesMain:
+			// Search txs of the test case.
+			view := newSearchView(m.testCases[m.selectedRow()])
+			m.pushMainView(searchMain, view)
+			view.ActivateSearch()
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == searchMain:
+			m.searchView().ActivateSearch()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == searchMain:
+			// Show tx detail of the search results.
+			view := m.searchView()
+			selected := view.SelectedResult()
+			if selected < 0 {
+				return nil
+			}
+			m.pushMainView(txDetailMain, newSearchTxDetailView(view.Results, selected))
+			return nil
+
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
 			goToPrevPage(m.txDetailView().Pages)
 			return nil
@@ -106,6 +184,29 @@ func (m *Model) selectedRow() int {
 	return row - 1
 }
 
+func (m *Model) searchView() *searchView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*searchView)
+}
+
+// searchTxs searches the txs of the test case in the search view.
+func (m *Model) searchTxs(ctx context.Context) {
+	view := m.searchView()
+	filter, err := parseSearchQuery(view.Input.GetText())
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("parse search: %w", err))
+		return
+	}
+	results, err := m.querySvc.SearchTxs(ctx, view.testCase.ID, filter)
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("search txs: %w", err))
+		return
+	}
+	view.filter = &filter
+	view.SetResults(results)
+	view.DeactivateSearch()
+}
+
 func (m *Model) txDetailView() *txDetailView {
 	_, primitive := m.mainContentView().GetFrontPage()
 	return primitive.(*txDetailView)
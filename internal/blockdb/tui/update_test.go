@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -29,12 +30,27 @@ func draw(view tview.Primitive) {
 }
 
 type mockQueryService struct {
+	TestCases    []blockdb.TestCaseResult
+	GotLimit     int
 	GotChainPkey int64
 	Messages     []blockdb.CosmosMessageResult
 	Txs          []blockdb.TxResult
+	BlockList    []blockdb.BlockResult
+	Found        []blockdb.TxSearchResult
+	Timeline     []blockdb.TimelineEntry
+	GotFilter    blockdb.TxFilter
+	GotTestCase  int64
 	Err          error
 }
 
+func (m *mockQueryService) RecentTestCases(ctx context.Context, limit int) ([]blockdb.TestCaseResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotLimit = limit
+	return m.TestCases, m.Err
+}
+
 func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
 	if ctx == nil {
 		panic("nil context")
@@ -51,6 +67,31 @@ func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64)
 	return m.Messages, m.Err
 }
 
+func (m *mockQueryService) Blocks(ctx context.Context, chainPkey int64) ([]blockdb.BlockResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.BlockList, m.Err
+}
+
+func (m *mockQueryService) SearchTxs(ctx context.Context, testCaseID int64, filter blockdb.TxFilter) ([]blockdb.TxSearchResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCase = testCaseID
+	m.GotFilter = filter
+	return m.Found, m.Err
+}
+
+func (m *mockQueryService) RelayerTimeline(ctx context.Context, testCaseID int64) ([]blockdb.TimelineEntry, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCase = testCaseID
+	return m.Timeline, m.Err
+}
+
 func TestModel_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,10 +136,47 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("blocks view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			BlockList: []blockdb.BlockResult{
+				{Height: 1},
+				{Height: 2},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('b'))
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, table := model.mainContentView().GetFrontPage()
+
+		// 3 rows: 1 header + 2 blockdb.BlockResult
+		require.Equal(t, 3, table.(*tview.Table).GetRowCount())
+		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1 blocks")
+	})
+
+	t.Run("blocks error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ChainPKey: 5}})
+
+		draw(model.RootView())
+		model.Update(ctx)(runeKey('b'))
+
+		name, _ := model.mainContentView().GetFrontPage()
+		require.Equal(t, errorModalMain.String(), name)
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
-				{Height: 12, Tx: []byte(`{"tx":1}`)},
+				{Height: 12, Tx: []byte(`{"tx":1}`), GasUsed: sql.NullInt64{Int64: 100, Valid: true}, GasWanted: sql.NullInt64{Int64: 200, Valid: true}, Code: sql.NullInt64{Valid: true}},
 				{Height: 13, Tx: []byte(`{"tx":2}`)},
 				{Height: 14, Tx: []byte(`{"tx":3}`)},
 			},
@@ -130,6 +208,7 @@ func TestModel_Update(t *testing.T) {
 
 		require.Contains(t, textView.GetTitle(), "Tx 1 of 3")
 		require.Contains(t, textView.GetTitle(), "my-chain1 @ Height 12")
+		require.Contains(t, textView.GetTitle(), "gas 100/200 code 0")
 		const wantFirstPage = `{
   "tx": 1
 }`
@@ -214,4 +293,134 @@ func TestModel_Update(t *testing.T) {
 		// properly with the nested flex views.
 		require.IsType(t, &tview.Modal{}, primative.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
 	})
+
+	t.Run("relayer timeline", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Timeline: []blockdb.TimelineEntry{
+				{Exec: &blockdb.RelayerExecResult{ID: 3, Command: []string{"rly", "tx", "flush"}, Stdout: "flushed", ExitCode: 1}},
+				{Tx: &blockdb.RelayerTxResult{ChainID: "chain-a", Height: 5, MsgTypes: []string{"/ibc.core.channel.v1.MsgRecvPacket"}, ExecID: 3}},
+				{Tx: &blockdb.RelayerTxResult{ChainID: "chain-b", Height: 6}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 9, Name: "TestRelay", ChainPKey: 5, ChainID: "chain-a"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('r'))
+
+		require.EqualValues(t, 9, querySvc.GotTestCase)
+		table := model.frontTable()
+		// 4 rows: 1 header + 3 entries
+		require.Equal(t, 4, table.GetRowCount())
+		require.Contains(t, table.GetTitle(), "TestRelay relayer timeline")
+		require.Equal(t, "rly tx flush", table.GetCell(1, 3).Text)
+		require.Equal(t, "#3", table.GetCell(2, 6).Text)
+		// Failed commands are highlighted.
+		require.Equal(t, errorTextColor, table.GetCell(1, 0).Color)
+
+		// Txs without a command have nothing to show.
+		table.Select(3, 0)
+		update(enterKey)
+		name, _ := model.mainContentView().GetFrontPage()
+		require.Equal(t, relayerTimelineMain.String(), name)
+
+		// Txs show the command which produced them.
+		table.Select(2, 0)
+		update(enterKey)
+		name, primitive := model.mainContentView().GetFrontPage()
+		require.Equal(t, relayerExecMain.String(), name)
+		textView := primitive.(*tview.TextView)
+		require.Equal(t, "Relayer command #3", textView.GetTitle())
+		require.Contains(t, textView.GetText(true), "flushed")
+		require.Contains(t, textView.GetText(true), "chain-a @ Height 5")
+	})
+
+	t.Run("relayer timeline error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 1}})
+
+		draw(model.RootView())
+		model.Update(ctx)(runeKey('r'))
+
+		name, _ := model.mainContentView().GetFrontPage()
+		require.Equal(t, errorModalMain.String(), name)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Found: []blockdb.TxSearchResult{
+				{ChainID: "chain-a", TxResult: blockdb.TxResult{Height: 3, Tx: []byte(`{"tx":1}`)}},
+				{ChainID: "chain-b", TxResult: blockdb.TxResult{Height: 7, Tx: []byte(`{"tx":2}`)}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 9, ChainPKey: 5, ChainID: "chain-a"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('s'))
+
+		name, _ := model.mainContentView().GetFrontPage()
+		require.Equal(t, searchMain.String(), name)
+		view := model.searchView()
+		require.True(t, view.Input.HasFocus())
+
+		// Keys go to the search input while it has focus.
+		require.NotNil(t, update(runeKey('b')))
+
+		view.Input.SetText("type:MsgSend merkle root")
+		update(enterKey)
+
+		require.EqualValues(t, 9, querySvc.GotTestCase)
+		require.Equal(t, blockdb.TxFilter{MsgType: "MsgSend", Text: "merkle root"}, querySvc.GotFilter)
+		require.False(t, view.Input.HasFocus())
+		// 3 rows: 1 header + 2 results
+		require.Equal(t, 3, view.Table.GetRowCount())
+
+		// View the selected result.
+		draw(model.RootView())
+		update(enterKey)
+
+		txDetail := model.txDetailView()
+		require.Equal(t, 2, txDetail.Pages.GetPageCount())
+		_, primitive := txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "chain-a @ Height 3 [Tx 1 of 2]")
+
+		update(runeKey(']'))
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "chain-b @ Height 7 [Tx 2 of 2]")
+
+		// Go back and edit the search.
+		update(escKey)
+		update(runeKey('/'))
+		require.True(t, model.searchView().Input.HasFocus())
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 1}})
+
+		draw(model.RootView())
+		update := model.Update(ctx)
+		update(runeKey('s'))
+		update(enterKey)
+
+		name, _ := model.mainContentView().GetFrontPage()
+		require.Equal(t, errorModalMain.String(), name)
+
+		// Invalid queries are not sent to the database.
+		update(escKey)
+		querySvc.Err, querySvc.GotTestCase = nil, 0
+		model.searchView().Input.SetText("attr:")
+		update(enterKey)
+
+		name, _ = model.mainContentView().GetFrontPage()
+		require.Equal(t, errorModalMain.String(), name)
+		require.Zero(t, querySvc.GotTestCase)
+	})
 }
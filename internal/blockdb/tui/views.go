@@ -102,6 +102,7 @@ func testCasesView(m *Model) *tview.Table {
 		"Chain",
 		"Height",
 		"Tx Total",
+		"Gas Used",
 	}
 
 	rows := make([][]string, len(m.testCases))
@@ -115,12 +116,42 @@ func testCasesView(m *Model) *tview.Table {
 			pres.ChainID(),
 			pres.Height(),
 			pres.TxTotal(),
+			pres.GasUsed(),
 		}
 	}
 
 	return detailTableView("Test Cases", headers, rows)
 }
 
+func blocksView(tc blockdb.TestCaseResult, blocks []blockdb.BlockResult) *tview.Table {
+	headers := []string{
+		"Height",
+		"Time",
+		"Interval",
+		"Proposer",
+		"Txs",
+		"Gas Used/Wanted",
+		"App Hash",
+	}
+
+	rows := make([][]string, len(blocks))
+	for i, block := range blocks {
+		pres := presenter.Block{Result: block}
+		rows[i] = []string{
+			pres.Height(),
+			pres.Time(),
+			pres.Interval(),
+			pres.Proposer(),
+			pres.NumTxs(),
+			pres.Gas(),
+			pres.AppHash(),
+		}
+	}
+
+	title := fmt.Sprintf("%s blocks [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, headers, rows)
+}
+
 func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageResult) *tview.Table {
 	headers := []string{
 		"Height",
@@ -150,6 +181,62 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 	return detailTableView(title, headers, rows)
 }
 
+// highlightFailedRow colors the row of a failed tx.
+func highlightFailedRow(tbl *tview.Table, row int) {
+	for col := 0; col < tbl.GetColumnCount(); col++ {
+		tbl.GetCell(row, col).SetStyle(failedStyle)
+	}
+}
+
+func relayerTimelineView(tc blockdb.TestCaseResult, entries []blockdb.TimelineEntry) *tview.Table {
+	headers := []string{
+		"Time",
+		"Source",
+		"Height",
+		"Action",
+		"Signer",
+		"Result",
+		"Exec",
+	}
+
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		pres := presenter.TimelineEntry{Entry: entry}
+		rows[i] = []string{
+			pres.Time(),
+			pres.Source(),
+			pres.Height(),
+			pres.Action(),
+			pres.Signer(),
+			pres.Result(),
+			pres.Exec(),
+		}
+	}
+
+	title := fmt.Sprintf("%s relayer timeline [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	tbl := detailTableView(title, headers, rows)
+	for i, entry := range entries {
+		if (presenter.TimelineEntry{Entry: entry}).Failed() {
+			highlightFailedRow(tbl, i+1)
+		}
+	}
+	return tbl
+}
+
+// relayerExecView shows the output of the relayer command with the given id and the txs it produced.
+func relayerExecView(entries []blockdb.TimelineEntry, id int64) *tview.TextView {
+	view := tview.NewTextView().
+		SetText(presenter.RelayerExecDetail(entries, id)).
+		SetTextColor(textColor).
+		SetWrap(true).
+		SetScrollable(true)
+	view.SetBorder(true).
+		SetBorderPadding(0, 0, 1, 1).
+		SetBorderAttributes(tcell.AttrDim)
+	view.SetTitle(fmt.Sprintf("Relayer command #%d", id))
+	return view
+}
+
 func errorModalView(err error) *tview.Flex {
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("Error: %v", err)).
@@ -176,7 +263,7 @@ const (
 type txDetailView struct {
 	*tview.Flex
 
-	chainID string
+	chainIDs []string // Chain of each tx.
 
 	Txs    []blockdb.TxResult
 	Pages  *tview.Pages
@@ -184,14 +271,33 @@ type txDetailView struct {
 }
 
 func newTxDetailView(chainID string, txs []blockdb.TxResult) *txDetailView {
+	chainIDs := make([]string, len(txs))
+	for i := range chainIDs {
+		chainIDs[i] = chainID
+	}
+	return buildTxDetailView(chainIDs, txs, 0)
+}
+
+// newSearchTxDetailView shows the txs found by a search, starting at the result with index selected.
+func newSearchTxDetailView(results []blockdb.TxSearchResult, selected int) *txDetailView {
+	chainIDs := make([]string, len(results))
+	txs := make([]blockdb.TxResult, len(results))
+	for i, res := range results {
+		chainIDs[i] = res.ChainID
+		txs[i] = res.TxResult
+	}
+	return buildTxDetailView(chainIDs, txs, selected)
+}
+
+func buildTxDetailView(chainIDs []string, txs []blockdb.TxResult, page int) *txDetailView {
 	detail := &txDetailView{
-		chainID: chainID,
-		Txs:     txs,
+		chainIDs: chainIDs,
+		Txs:      txs,
 	}
 
 	detail.Pages = tview.NewPages()
-	detail.replacePages("", "0")
-	detail.Search = detail.buildSearchInput()
+	detail.replacePages("", strconv.Itoa(page))
+	detail.Search = newSearchInput()
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetBorder(false)
@@ -202,6 +308,20 @@ func newTxDetailView(chainID string, txs []blockdb.TxResult) *txDetailView {
 	return detail
 }
 
+// Refresh replaces the txs of the chain, keeping the current tx and search term.
+func (detail *txDetailView) Refresh(chainID string, txs []blockdb.TxResult) {
+	detail.chainIDs = make([]string, len(txs))
+	for i := range detail.chainIDs {
+		detail.chainIDs[i] = chainID
+	}
+	detail.Txs = txs
+	idx, _ := detail.Pages.GetFrontPage()
+	if idx == "" {
+		idx = "0"
+	}
+	detail.replacePages(detail.Search.GetText(), idx)
+}
+
 func (detail *txDetailView) ToggleSearch() {
 	if detail.Search.HasFocus() {
 		detail.deactivateSearch()
@@ -211,18 +331,12 @@ func (detail *txDetailView) ToggleSearch() {
 }
 
 func (detail *txDetailView) deactivateSearch() {
-	detail.Search.SetBorderColor(searchInactiveColor)
-	detail.Search.SetFieldTextColor(searchInactiveColor)
-	detail.Search.SetTitleColor(searchInactiveColor)
-	detail.Search.Blur()
+	setSearchActive(detail.Search, false)
 	detail.Pages.Focus(nil)
 }
 
 func (detail *txDetailView) activateSearch() {
-	detail.Search.SetBorderColor(searchActiveColor)
-	detail.Search.SetFieldTextColor(searchActiveColor)
-	detail.Search.SetTitleColor(searchActiveColor)
-	detail.Search.Focus(nil)
+	setSearchActive(detail.Search, true)
 	detail.Pages.Blur()
 }
 
@@ -259,7 +373,14 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 			SetBorderPadding(0, 0, 1, 1).
 			SetBorderAttributes(tcell.AttrDim)
 
-		textView.SetTitle(fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainID, tx.Height, i+1, len(detail.Txs)))
+		title := fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainIDs[i], tx.Height, i+1, len(detail.Txs))
+		if summary := pres.Summary(); summary != "" {
+			title += " " + summary
+		}
+		textView.SetTitle(title)
+		if pres.Failed() {
+			textView.SetTitleColor(errorTextColor)
+		}
 
 		detail.Pages.AddPage(idx, textView, true, false)
 	}
@@ -267,7 +388,7 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 	detail.Pages.SwitchToPage(pageIdx)
 }
 
-func (*txDetailView) buildSearchInput() *tview.InputField {
+func newSearchInput() *tview.InputField {
 	input := tview.NewInputField().
 		SetFieldTextColor(searchInactiveColor).
 		SetFieldBackgroundColor(backgroundColor)
@@ -280,3 +401,19 @@ func (*txDetailView) buildSearchInput() *tview.InputField {
 		SetBorderColor(searchInactiveColor)
 	return input
 }
+
+// setSearchActive colors and focuses the input if active, otherwise removes its focus.
+func setSearchActive(input *tview.InputField, active bool) {
+	color := searchInactiveColor
+	if active {
+		color = searchActiveColor
+	}
+	input.SetBorderColor(color)
+	input.SetFieldTextColor(color)
+	input.SetTitleColor(color)
+	if active {
+		input.Focus(nil)
+		return
+	}
+	input.Blur()
+}
@@ -2,6 +2,7 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -24,10 +25,10 @@ func TestTxFlattenedView(t *testing.T) {
 	require.NoError(t, err)
 
 	beforeBlocksCreated := time.Now().UTC().Format(time.RFC3339)
-	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{
+	require.NoError(t, chain.SaveBlock(ctx, 1, BlockHeader{}, []Tx{
 		{Data: []byte("tx1.0")},
 	}))
-	require.NoError(t, chain.SaveBlock(ctx, 2, []Tx{
+	require.NoError(t, chain.SaveBlock(ctx, 2, BlockHeader{}, []Tx{
 		{Data: []byte("tx2.0")},
 		{Data: []byte("tx2.1")},
 	}))
@@ -139,3 +140,52 @@ ORDER BY test_case_id, chain_kid, block_id, tx_id
 func TestTxAggView(t *testing.T) {
 	// Nop. Tested as part of QueryService.
 }
+
+func TestBlockView(t *testing.T) {
+	t.Parallel()
+
+	db := migratedDB()
+	defer db.Close()
+
+	ctx := context.Background()
+
+	tc, err := CreateTestCase(ctx, db, "mytest", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain1", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, chain.SaveBlock(ctx, 1, BlockHeader{Time: start, Proposer: "AAA", NumTxs: 2}, []Tx{
+		{Data: []byte("tx1.0"), GasWanted: 200, GasUsed: 100},
+		{Data: []byte("tx1.1"), GasWanted: 300, GasUsed: 250},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 2, BlockHeader{Time: start.Add(1500 * time.Millisecond), Proposer: "BBB"}, nil))
+	require.NoError(t, chain.SaveBlock(ctx, 3, BlockHeader{}, nil))
+
+	rows, err := db.Query(`SELECT block_height, block_interval_ms, proposer, num_txs, gas_wanted, gas_used
+FROM v_block WHERE chain_kid = ? ORDER BY block_height`, chain.id)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type blockRow struct {
+		Height    int
+		Interval  sql.NullInt64
+		Proposer  sql.NullString
+		NumTxs    sql.NullInt64
+		GasWanted sql.NullInt64
+		GasUsed   sql.NullInt64
+	}
+	var got []blockRow
+	for rows.Next() {
+		var r blockRow
+		require.NoError(t, rows.Scan(&r.Height, &r.Interval, &r.Proposer, &r.NumTxs, &r.GasWanted, &r.GasUsed))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, []blockRow{
+		{Height: 1, Proposer: sql.NullString{String: "AAA", Valid: true}, NumTxs: sql.NullInt64{Int64: 2, Valid: true}, GasWanted: sql.NullInt64{Int64: 500, Valid: true}, GasUsed: sql.NullInt64{Int64: 350, Valid: true}},
+		{Height: 2, Interval: sql.NullInt64{Int64: 1500, Valid: true}, Proposer: sql.NullString{String: "BBB", Valid: true}, NumTxs: sql.NullInt64{Int64: 0, Valid: true}},
+		{Height: 3},
+	}, got)
+}
@@ -1,9 +1,11 @@
 package dockerutil
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	dockertypes "github.com/docker/docker/api/types"
@@ -12,6 +14,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	"go.uber.org/zap"
 
@@ -212,6 +215,31 @@ func (c *ContainerLifecycle) ContainerID() string {
 	return c.id
 }
 
+// Logs returns the last tail lines the container wrote to stdout and stderr, or all of them when tail is 0.
+func (c *ContainerLifecycle) Logs(ctx context.Context, tail int) ([]byte, error) {
+	opts := dockertypes.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       "all",
+	}
+	if tail > 0 {
+		opts.Tail = strconv.Itoa(tail)
+	}
+
+	rc, err := c.client.ContainerLogs(ctx, c.id, opts)
+	if err != nil {
+		return nil, fmt.Errorf("logs of container %s: %w", c.containerName, err)
+	}
+	defer rc.Close()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, rc); err != nil {
+		return nil, fmt.Errorf("demux logs of container %s: %w", c.containerName, err)
+	}
+	return buf.Bytes(), nil
+}
+
 func (c *ContainerLifecycle) GetHostPorts(ctx context.Context, portIDs ...string) ([]string, error) {
 	cjson, err := c.client.ContainerInspect(ctx, c.id)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -98,3 +99,84 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 
 	return nil, fmt.Errorf("path %q not found in tar from container", relPath)
 }
+
+// Archive writes every file in the volume specified by volumeName to w as a tar stream.
+// Paths in the archive are relative to the root of the volume.
+func (r *FileRetriever) Archive(ctx context.Context, volumeName string, w io.Writer) error {
+	const mountPath = "/mnt/dockervolume"
+
+	if err := ensureBusybox(ctx, r.cli); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-archive-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := r.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when reading files from the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: r.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + mountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if err := r.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			r.log.Warn("Failed to remove archive container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, mountPath)
+	if err != nil {
+		return fmt.Errorf("copying from container: %w", err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	// Docker prefixes every entry with the base name of the mount path, strip it.
+	prefix := path.Base(mountPath) + "/"
+
+	tr := tar.NewReader(rc)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar from container: %w", err)
+		}
+
+		name := strings.TrimPrefix(hdr.Name, prefix)
+		if name == hdr.Name || name == "" {
+			// The mount path itself.
+			continue
+		}
+		hdr.Name = name
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing tar header: %w", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return fmt.Errorf("writing %s to tar: %w", name, err)
+		}
+	}
+
+	return tw.Close()
+}
@@ -1,6 +1,7 @@
 package dockerutil_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -67,3 +68,56 @@ func TestFileRetriever(t *testing.T) {
 		require.Equal(t, string(b), "test")
 	})
 }
+
+func TestFileRetrieverArchive(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	src, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+	})
+	require.NoError(t, err)
+	dst, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+	})
+	require.NoError(t, err)
+
+	img := dockerutil.NewImage(
+		zaptest.NewLogger(t),
+		cli,
+		network,
+		t.Name(),
+		"busybox", "stable",
+	)
+
+	res := img.Run(
+		ctx,
+		[]string{"sh", "-c", "mkdir -p /mnt/test/foo/bar/ && printf 'hello world' > /mnt/test/hello.txt && printf 'test' > /mnt/test/foo/bar/baz.txt"},
+		dockerutil.ContainerOptions{
+			Binds: []string{src.Name + ":/mnt/test"},
+			User:  dockerutil.GetRootUserString(),
+		},
+	)
+	require.NoError(t, res.Err)
+
+	var buf bytes.Buffer
+	fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+	require.NoError(t, fr.Archive(ctx, src.Name, &buf))
+
+	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), cli, t.Name())
+	require.NoError(t, fw.WriteArchive(ctx, dst.Name, &buf))
+
+	b, err := fr.SingleFileContent(ctx, dst.Name, "hello.txt")
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(b))
+
+	b, err = fr.SingleFileContent(ctx, dst.Name, "foo/bar/baz.txt")
+	require.NoError(t, err)
+	require.Equal(t, "test", string(b))
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -13,8 +14,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// FileWriter allows retrieving a single file from a Docker volume.
-// In the future it may allow retrieving an entire directory.
+// FileWriter allows writing files to a Docker volume.
 type FileWriter struct {
 	log *zap.Logger
 
@@ -30,6 +30,39 @@ func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWr
 
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: relPath,
+
+		Size: int64(len(content)),
+		Mode: 0600,
+		// Not setting uname because the container will chown it anyway.
+
+		ModTime: time.Now(),
+
+		Format: tar.FormatPAX,
+	}); err != nil {
+		return fmt.Errorf("writing tar header: %w", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf("writing content to tar: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+
+	return w.writeTar(ctx, volumeName, &buf)
+}
+
+// WriteArchive extracts the tar stream r into the root of the given volume,
+// such as one produced by (*FileRetriever).Archive.
+func (w *FileWriter) WriteArchive(ctx context.Context, volumeName string, r io.Reader) error {
+	return w.writeTar(ctx, volumeName, r)
+}
+
+// writeTar extracts the tarball into the volume and sets the owner of every file to the owner of the volume.
+func (w *FileWriter) writeTar(ctx context.Context, volumeName string, tarball io.Reader) error {
 	const mountPath = "/mnt/dockervolume"
 
 	if err := ensureBusybox(ctx, w.cli); err != nil {
@@ -84,33 +117,11 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 	}()
 
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	if err := tw.WriteHeader(&tar.Header{
-		Name: relPath,
-
-		Size: int64(len(content)),
-		Mode: 0600,
-		// Not setting uname because the container will chown it anyway.
-
-		ModTime: time.Now(),
-
-		Format: tar.FormatPAX,
-	}); err != nil {
-		return fmt.Errorf("writing tar header: %w", err)
-	}
-	if _, err := tw.Write(content); err != nil {
-		return fmt.Errorf("writing content to tar: %w", err)
-	}
-	if err := tw.Close(); err != nil {
-		return fmt.Errorf("closing tar writer: %w", err)
-	}
-
 	if err := w.cli.CopyToContainer(
 		ctx,
 		cc.ID,
 		mountPath,
-		&buf,
+		tarball,
 		types.CopyToContainerOptions{},
 	); err != nil {
 		return fmt.Errorf("copying tar to container: %w", err)
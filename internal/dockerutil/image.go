@@ -17,6 +17,8 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -103,7 +105,14 @@ type ContainerExecResult struct {
 //
 // Run blocks until the command completes. Thus, Run is not suitable for daemons or servers. Use Start instead.
 // A non-zero status code returns an error.
-func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions) ContainerExecResult {
+func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions) (res ContainerExecResult) {
+	ctx, span := tracing.Start(ctx, "Image.Run",
+		attribute.String("image", image.imageRef()), attribute.StringSlice("command", cmd))
+	defer func() {
+		span.SetAttributes(attribute.Int("exit_code", res.ExitCode))
+		tracing.End(span, res.Err)
+	}()
+
 	c, err := image.Start(ctx, cmd, opts)
 	if err != nil {
 		return ContainerExecResult{
@@ -125,12 +134,15 @@ func (image *Image) ensurePulled(ctx context.Context) error {
 	ref := image.imageRef()
 	_, _, err := image.client.ImageInspectWithRaw(ctx, ref)
 	if err != nil {
-		rc, err := image.client.ImagePull(ctx, ref, types.ImagePullOptions{})
-		if err != nil {
-			return fmt.Errorf("pull image %s: %w", ref, err)
-		}
-		_, _ = io.Copy(io.Discard, rc)
-		_ = rc.Close()
+		return tracing.Run(ctx, "pull image", func(ctx context.Context) error {
+			rc, err := image.client.ImagePull(ctx, ref, types.ImagePullOptions{})
+			if err != nil {
+				return fmt.Errorf("pull image %s: %w", ref, err)
+			}
+			_, _ = io.Copy(io.Discard, rc)
+			_ = rc.Close()
+			return nil
+		}, attribute.String("image", ref))
 	}
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
 )
 
 // DockerSetupTestingT is a subset of testing.T required for DockerSetup.
@@ -57,6 +58,25 @@ const (
 // is interchaintest.KeepDockerVolumesOnFailure(bool).
 var KeepVolumesOnFailure = os.Getenv("IBCTEST_SKIP_FAILURE_CLEANUP") != ""
 
+// ContainerLogs is the tail of the logs of a container, captured when its test failed.
+type ContainerLogs struct {
+	TestName      string
+	ContainerID   string
+	ContainerName string
+	Image         string
+
+	// Stdout and stderr of the container, interleaved.
+	Logs []byte
+}
+
+// FailedContainerLogsHandler, if set, receives the logs of every container labeled with a failed test's CleanupLabel,
+// before DockerSetup's cleanup removes the containers. Errors are logged to the test.
+// The number of lines is set by the environment variable CONTAINER_LOG_TAIL, 50 by default.
+//
+// Because dockerutil is an internal package, the public API for setting this value
+// is interchaintest.CaptureContainerLogsOnFailure.
+var FailedContainerLogsHandler func(ContainerLogs) error
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, DockerSetup panics because the test cannot continue.
@@ -108,22 +128,33 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 			return
 		}
 
+		logTail := "50"
+		if containerLogTail != "" {
+			logTail = containerLogTail
+		}
+		handler := FailedContainerLogsHandler
+
 		for _, c := range cs {
-			if (t.Failed() && showContainerLogs == "") || showContainerLogs == "always" {
-				logTail := "50"
-				if containerLogTail != "" {
-					logTail = containerLogTail
+			showLogs := (t.Failed() && showContainerLogs == "") || showContainerLogs == "always"
+			captureLogs := t.Failed() && handler != nil
+			if showLogs || captureLogs {
+				logs, err := containerLogs(ctx, cli, c.ID, logTail)
+				switch {
+				case err != nil:
+					t.Logf("Failed to get logs of container %s during docker cleanup: %v", c.ID, err)
+				case showLogs:
+					t.Logf("\n\nContainer logs - {%s}\n%s", strings.Join(c.Names, " "), logs)
 				}
-				rc, err := cli.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
-					ShowStdout: true,
-					ShowStderr: true,
-					Tail:       logTail,
-				})
-				if err == nil {
-					b := new(bytes.Buffer)
-					_, err := b.ReadFrom(rc)
-					if err == nil {
-						t.Logf("\n\nContainer logs - {%s}\n%s", strings.Join(c.Names, " "), b.String())
+				if err == nil && captureLogs {
+					err := handler(ContainerLogs{
+						TestName:      t.Name(),
+						ContainerID:   c.ID,
+						ContainerName: containerName(c),
+						Image:         c.Image,
+						Logs:          logs,
+					})
+					if err != nil {
+						t.Logf("Failed to capture logs of container %s during docker cleanup: %v", c.ID, err)
 					}
 				}
 			}
@@ -170,6 +201,33 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 	}
 }
 
+func containerLogs(ctx context.Context, cli *client.Client, id, tail string) ([]byte, error) {
+	rc, err := cli.ContainerLogs(ctx, id, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       tail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, rc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// containerName is the first name of the container, without the leading slash.
+func containerName(c types.Container) string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
 func pruneVolumesWithRetry(ctx context.Context, t DockerSetupTestingT, cli *client.Client) {
 	if KeepVolumesOnFailure && t.Failed() {
 		return
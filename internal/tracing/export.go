@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ExporterStdout is the Setup exporter writing spans to stdout.
+const ExporterStdout = "stdout"
+
+// ExporterFilePrefix prefixes the path of the file Setup writes spans to, e.g. "file:trace.jsonl".
+const ExporterFilePrefix = "file:"
+
+// ServiceName is the service name of the exported spans.
+const ServiceName = "interchaintest"
+
+// Setup sets the global TracerProvider to export spans in the OTLP JSON file format,
+// i.e. one JSON encoded ExportTraceServiceRequest per line, which trace viewers and the OpenTelemetry Collector can load.
+// The exporter is either ExporterStdout or ExporterFilePrefix followed by the path of the file, which is truncated.
+//
+// If exporter is empty, Setup does nothing. Otherwise, shutdown must be called before exiting to flush the spans.
+func Setup(ctx context.Context, exporter string) (shutdown func(context.Context) error, err error) {
+	if exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version.GitSha),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+
+	var w io.WriteCloser
+	switch {
+	case exporter == ExporterStdout:
+		w = nopCloser{os.Stdout}
+	case strings.HasPrefix(exporter, ExporterFilePrefix):
+		f, err := os.Create(strings.TrimPrefix(exporter, ExporterFilePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("create trace file: %w", err)
+		}
+		w = f
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, must be %q or %q followed by a path", exporter, ExporterStdout, ExporterFilePrefix)
+	}
+
+	exp, err := otlptrace.New(ctx, &jsonClient{w: w})
+	if err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("start trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// jsonClient is an otlptrace.Client writing spans to w in the OTLP JSON file format.
+type jsonClient struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+func (c *jsonClient) Start(context.Context) error {
+	return nil
+}
+
+func (c *jsonClient) Stop(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.w.Close()
+}
+
+func (c *jsonClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	line, err := marshalResourceSpans(spans)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.w.Write(line)
+	return err
+}
+
+// marshalResourceSpans encodes spans as an ExportTraceServiceRequest on one line.
+func marshalResourceSpans(spans []*tracepb.ResourceSpans) ([]byte, error) {
+	// OTLP JSON differs from the protobuf JSON mapping: enums are numbers, and ids are hex instead of base64.
+	opts := protojson.MarshalOptions{UseEnumNumbers: true}
+	req := struct {
+		ResourceSpans []any `json:"resourceSpans"`
+	}{ResourceSpans: make([]any, len(spans))}
+	for i, rs := range spans {
+		b, err := opts.Marshal(rs)
+		if err != nil {
+			return nil, fmt.Errorf("marshal resource spans: %w", err)
+		}
+		var v any
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("unmarshal resource spans: %w", err)
+		}
+		if err := hexIDs(v); err != nil {
+			return nil, err
+		}
+		req.ResourceSpans[i] = v
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return nil, fmt.Errorf("encode export request: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var idKeys = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// hexIDs re-encodes the base64 trace and span ids within v as hex.
+func hexIDs(v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, val := range v {
+			if s, ok := val.(string); ok && idKeys[key] {
+				id, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return fmt.Errorf("decode %s: %w", key, err)
+				}
+				v[key] = hex.EncodeToString(id)
+				continue
+			}
+			if err := hexIDs(val); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if err := hexIDs(val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+var _ otlptrace.Client = (*jsonClient)(nil)
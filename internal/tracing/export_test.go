@@ -0,0 +1,112 @@
+package tracing_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type otlpSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+	Status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []otlpSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestSetup_File(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(prev)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	shutdown, err := tracing.Setup(ctx, tracing.ExporterFilePrefix+path)
+	require.NoError(t, err)
+
+	err = tracing.Run(ctx, "Interchain.Build", func(ctx context.Context) error {
+		_, span := tracing.Start(ctx, "pull image", attribute.String("image", "gaia:v7.0.1"))
+		tracing.End(span, errors.New("boom"))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	spans := make(map[string]otlpSpan)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var req otlpRequest
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &req))
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, spans, 2)
+
+	build, pull := spans["Interchain.Build"], spans["pull image"]
+	// Ids are hex encoded.
+	require.Regexp(t, "^[0-9a-f]{32}$", build.TraceID)
+	require.Regexp(t, "^[0-9a-f]{16}$", build.SpanID)
+	require.Equal(t, build.TraceID, pull.TraceID)
+	require.Equal(t, build.SpanID, pull.ParentSpanID)
+	require.Empty(t, build.ParentSpanID)
+
+	require.Len(t, pull.Attributes, 1)
+	require.Equal(t, "image", pull.Attributes[0].Key)
+	require.Equal(t, "gaia:v7.0.1", pull.Attributes[0].Value.StringValue)
+	// STATUS_CODE_ERROR is encoded as a number.
+	require.Equal(t, 2, pull.Status.Code)
+	require.Equal(t, "boom", pull.Status.Message)
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(prev)
+
+	ctx := context.Background()
+
+	shutdown, err := tracing.Setup(ctx, "")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+	require.Equal(t, prev, otel.GetTracerProvider())
+
+	_, err = tracing.Setup(ctx, "jaeger")
+	require.ErrorContains(t, err, `unknown trace exporter "jaeger"`)
+
+	_, err = tracing.Setup(ctx, tracing.ExporterFilePrefix+filepath.Join(t.TempDir(), "missing", "trace.jsonl"))
+	require.ErrorContains(t, err, "create trace file")
+}
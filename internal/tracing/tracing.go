@@ -0,0 +1,39 @@
+// Package tracing records OpenTelemetry spans around the slow steps of building an interchain,
+// such as pulling images, starting chains and linking relayer paths.
+//
+// Spans are recorded by the global TracerProvider, so they are dropped unless a provider is set,
+// either by the importer or with Setup.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/strangelove-ventures/interchaintest/v7"
+
+// Start starts a span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Run calls fn within a span, passing it the context of the span.
+func Run(ctx context.Context, name string, fn func(ctx context.Context) error, attrs ...attribute.KeyValue) error {
+	ctx, span := Start(ctx, name, attrs...)
+	err := fn(ctx)
+	End(span, err)
+	return err
+}
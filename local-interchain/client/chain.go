@@ -0,0 +1,319 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+// Chain calls the endpoints of a single chain. Commands may use the %RPC%, %CHAIN_ID% and %HOME%
+// placeholders, which the API replaces with the values of the chain's first validator.
+type Chain struct {
+	client  *Client
+	ChainID string
+}
+
+// TxResult is the result of a transaction once it is included in a block.
+type TxResult struct {
+	TxHash  string `json:"txhash"`
+	Height  string `json:"height"`
+	Code    uint32 `json:"code"`
+	RawLog  string `json:"raw_log"`
+	GasUsed string `json:"gas_used"`
+
+	// Raw is the full output of `query tx`.
+	Raw json.RawMessage `json:"-"`
+}
+
+// txFlags are added to transactions unless the command already sets them.
+var txFlags = []string{"--node=%RPC%", "--chain-id=%CHAIN_ID%", "--keyring-backend=test", "--home=%HOME%", "--output=json", "--yes"}
+
+// Action runs one of the actions of the root endpoint, such as "bin" or "recover-key", and returns its output.
+func (ch *Chain) Action(ctx context.Context, action, cmd string) ([]byte, error) {
+	var out []byte
+	if err := ch.client.do(ctx, http.MethodPost, "/", nil, handlers.ActionHandler{
+		ChainId: ch.ChainID,
+		Action:  action,
+		Cmd:     cmd,
+	}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Bin runs a command of the chain's binary, e.g. "keys list --keyring-backend=test".
+func (ch *Chain) Bin(ctx context.Context, cmd string) ([]byte, error) {
+	return ch.Action(ctx, "bin", cmd)
+}
+
+// Query runs a query against the chain's RPC and decodes its JSON output into out, e.g. "bank balances juno1...".
+// The leading "query" or "q" is optional.
+func (ch *Chain) Query(ctx context.Context, cmd string, out any) error {
+	cmd = strings.TrimSpace(cmd)
+	for _, prefix := range []string{"query ", "q "} {
+		cmd = strings.TrimPrefix(cmd, prefix)
+	}
+
+	bz, err := ch.Action(ctx, "query", cmd)
+	if err != nil {
+		return err
+	}
+	if raw, ok := out.(*json.RawMessage); ok {
+		*raw = bz
+		return nil
+	}
+	if err := json.Unmarshal(bz, out); err != nil {
+		return fmt.Errorf("query %q returned %s: %w", cmd, strings.TrimSpace(string(bz)), err)
+	}
+	return nil
+}
+
+// Exec runs a command in the container of the chain's first validator, e.g. "ls %HOME%".
+func (ch *Chain) Exec(ctx context.Context, cmd string) ([]byte, error) {
+	return ch.Action(ctx, "exec", cmd)
+}
+
+// Tx broadcasts a transaction, e.g. "tx bank send acc0 juno1... 1ujuno --from=acc0", and waits for it to be
+// included in a block. It returns an error along with the result when the transaction failed.
+func (ch *Chain) Tx(ctx context.Context, cmd string) (*TxResult, error) {
+	bz, err := ch.Bin(ctx, withFlags(cmd, txFlags))
+	if err != nil {
+		return nil, err
+	}
+
+	var broadcast TxResult
+	if err := json.Unmarshal(bz, &broadcast); err != nil || broadcast.TxHash == "" {
+		return nil, fmt.Errorf("tx %q did not return a tx hash: %s", cmd, strings.TrimSpace(string(bz)))
+	}
+	if broadcast.Code != 0 {
+		return &broadcast, fmt.Errorf("tx %s failed with code %d: %s", broadcast.TxHash, broadcast.Code, broadcast.RawLog)
+	}
+
+	return ch.WaitForTx(ctx, broadcast.TxHash)
+}
+
+// WaitForTx polls for a transaction until it is included in a block, or ctx is done.
+func (ch *Chain) WaitForTx(ctx context.Context, txHash string) (*TxResult, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		res, err := ch.QueryTx(ctx, txHash)
+		if err == nil {
+			if res.Code != 0 {
+				return res, fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+			}
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %s was not found: %w", txHash, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// QueryTx returns an included transaction.
+func (ch *Chain) QueryTx(ctx context.Context, txHash string) (*TxResult, error) {
+	var raw json.RawMessage
+	if err := ch.Query(ctx, "tx "+txHash, &raw); err != nil {
+		return nil, err
+	}
+
+	var res TxResult
+	if err := json.Unmarshal(raw, &res); err != nil || res.TxHash == "" {
+		return nil, fmt.Errorf("tx %s not found: %s", txHash, strings.TrimSpace(string(raw)))
+	}
+	res.Raw = raw
+	return &res, nil
+}
+
+// withFlags appends every flag of required whose name is not already in cmd.
+func withFlags(cmd string, required []string) string {
+	for _, flag := range required {
+		name, _, _ := strings.Cut(flag, "=")
+		if !strings.Contains(cmd, name) {
+			cmd += " " + flag
+		}
+	}
+	return cmd
+}
+
+// UploadFile copies a file from this machine into the home directory of the chain's first validator
+// and returns its path in the container.
+func (ch *Chain) UploadFile(ctx context.Context, path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Location string `json:"location"`
+	}
+	if err := ch.client.do(ctx, http.MethodPost, "/upload", nil, handlers.Uploader{
+		ChainId:  ch.ChainID,
+		FilePath: abs,
+	}, &res); err != nil {
+		return "", err
+	}
+	return res.Location, nil
+}
+
+// UploadContract stores a wasm file from this machine on chain, signed by keyName, and returns its code id.
+func (ch *Chain) UploadContract(ctx context.Context, keyName, path string) (uint64, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return 0, err
+	}
+
+	var res struct {
+		CodeID uint64 `json:"code_id"`
+	}
+	if err := ch.client.do(ctx, http.MethodPost, "/upload", http.Header{"Upload-Type": {"cosmwasm"}}, handlers.Uploader{
+		ChainId:  ch.ChainID,
+		FilePath: abs,
+		KeyName:  keyName,
+	}, &res); err != nil {
+		return 0, err
+	}
+	return res.CodeID, nil
+}
+
+// RecoverKey adds a key to the keyring of the chain's first validator.
+func (ch *Chain) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
+	_, err := ch.Action(ctx, "recover-key", fmt.Sprintf("keyname=%s;mnemonic=%s", keyName, mnemonic))
+	return err
+}
+
+// AddFullNodes starts amount new full nodes on the chain.
+func (ch *Chain) AddFullNodes(ctx context.Context, amount int) error {
+	_, err := ch.Action(ctx, "add-full-nodes", fmt.Sprintf("amount=%d", amount))
+	return err
+}
+
+// KeyAddress returns the address of a key in the keyring of the chain's first validator.
+func (ch *Chain) KeyAddress(ctx context.Context, keyName string) (string, error) {
+	out, err := ch.Bin(ctx, fmt.Sprintf("keys show --address %s --home=%%HOME%% --keyring-backend=test", keyName))
+	if err != nil {
+		return "", err
+	}
+	addr := strings.TrimSpace(string(out))
+	if strings.Contains(addr, "Error:") {
+		return "", fmt.Errorf("failed to show key %s: %s", keyName, addr)
+	}
+	return addr, nil
+}
+
+// info returns the answer to an /info request of the chain.
+func (ch *Chain) info(ctx context.Context, request string, params url.Values) (string, error) {
+	q := url.Values{"chain_id": {ch.ChainID}, "request": {request}}
+	for k, v := range params {
+		q[k] = v
+	}
+
+	var out []byte
+	if err := ch.client.do(ctx, http.MethodGet, "/info?"+q.Encode(), nil, nil, &out); err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Config returns the config the chain was started with.
+func (ch *Chain) Config(ctx context.Context) (*handlers.IbcChainConfigAlias, error) {
+	out, err := ch.info(ctx, "config", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg handlers.IbcChainConfigAlias
+	if err := json.Unmarshal([]byte(out), &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// HomeDir is the home directory of the chain's first validator in its container.
+func (ch *Chain) HomeDir(ctx context.Context) (string, error) {
+	return ch.info(ctx, "home_dir", nil)
+}
+
+// ContainerID is the docker container of the chain's first validator.
+func (ch *Chain) ContainerID(ctx context.Context) (string, error) {
+	return ch.info(ctx, "container_id", nil)
+}
+
+// HostName is the hostname of the chain's first validator on the docker network.
+func (ch *Chain) HostName(ctx context.Context) (string, error) {
+	return ch.info(ctx, "hostname", nil)
+}
+
+// ReadFile reads a file relative to the home directory of the chain's first validator.
+func (ch *Chain) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
+	out, err := ch.info(ctx, "read_file", url.Values{"relative_path": {relPath}})
+	return []byte(out), err
+}
+
+// HasCommand reports whether the chain's binary has a command, e.g. "tx tokenfactory".
+func (ch *Chain) HasCommand(ctx context.Context, command string) (bool, error) {
+	out, err := ch.info(ctx, "has_command", url.Values{"command": {command}})
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(out)
+}
+
+// Height returns the chain's latest block height.
+func (ch *Chain) Height(ctx context.Context) (uint64, error) {
+	var res handlers.HeightResponse
+	if err := ch.client.do(ctx, http.MethodGet, ch.v1Path("/height"), nil, nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Height, nil
+}
+
+// WaitForHeight polls the chain until it reaches height, or ctx is done.
+func (ch *Chain) WaitForHeight(ctx context.Context, height uint64) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		current, err := ch.Height(ctx)
+		if err != nil {
+			return err
+		}
+		if current >= height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s is at height %d, waiting for %d: %w", ch.ChainID, current, height, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForBlocks waits until blocks more blocks are produced.
+func (ch *Chain) WaitForBlocks(ctx context.Context, blocks uint64) error {
+	start, err := ch.Height(ctx)
+	if err != nil {
+		return err
+	}
+	return ch.WaitForHeight(ctx, start+blocks)
+}
+
+// Relayer returns the client of the relayer, acting on this chain's side of its paths.
+func (ch *Chain) Relayer() *Relayer {
+	return &Relayer{chain: ch}
+}
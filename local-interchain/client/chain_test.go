@@ -0,0 +1,12 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFlags(t *testing.T) {
+	cmd := withFlags("tx bank send acc0 juno1dest 1ujuno --chain-id=other --yes", txFlags)
+	require.Equal(t, "tx bank send acc0 juno1dest 1ujuno --chain-id=other --yes --node=%RPC% --keyring-backend=test --home=%HOME% --output=json", cmd)
+}
@@ -0,0 +1,184 @@
+// Package client calls the REST API of a running local-interchain, such as the one started by `local-ic start`.
+//
+//	c := client.New("http://127.0.0.1:8080")
+//	juno := c.Chain("localjuno-1")
+//	codeID, err := juno.UploadContract(ctx, "acc0", "./contracts/cw_ibc_example.wasm")
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+const pollInterval = 500 * time.Millisecond
+
+// Client calls the API at a base URL such as http://127.0.0.1:8080.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set a timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithToken authenticates requests to a server configured with an auth_token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Chain returns the client of a running chain.
+func (c *Client) Chain(chainID string) *Chain {
+	return &Chain{client: c, ChainID: chainID}
+}
+
+// Error is an error reported by the API. Code is one of the handlers.ErrCode values for typed
+// endpoints, and empty for the actions which only return a message.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return e.Code + ": " + e.Message
+}
+
+// WaitForStart polls the API until it responds, or ctx is done.
+func (c *Client) WaitForStart(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/", nil)
+		if err != nil {
+			return err
+		}
+		if res, err := c.http.Do(req); err == nil {
+			res.Body.Close()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("local-interchain at %s did not start: %w", c.baseURL, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Info returns the chains, relayer and endpoints of the running environment.
+func (c *Client) Info(ctx context.Context) (*handlers.GetInfo, error) {
+	var info handlers.GetInfo
+	if err := c.do(ctx, http.MethodGet, "/info", nil, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Faucet funds an address from a chain's faucet key.
+func (c *Client) Faucet(ctx context.Context, req handlers.FaucetRequest) (*handlers.FaucetResponse, error) {
+	var res handlers.FaucetResponse
+	if err := c.do(ctx, http.MethodPost, "/faucet", nil, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Snapshot saves the running environment to ./snapshots/<name> of the server.
+func (c *Client) Snapshot(ctx context.Context, name string) (*handlers.SnapshotResponse, error) {
+	var res handlers.SnapshotResponse
+	if err := c.do(ctx, http.MethodPost, "/snapshot", nil, handlers.SnapshotRequest{Name: name}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// do sends body as JSON and decodes the response into out, or stores it when out is a *[]byte.
+func (c *Client) do(ctx context.Context, method, path string, header http.Header, body, out any) error {
+	var rd io.Reader
+	if body != nil {
+		bz, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(bz)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	bz, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var apiErr handlers.ErrorResponse
+		if err := json.Unmarshal(bz, &apiErr); err == nil && apiErr.Error.Message != "" {
+			return &Error{StatusCode: res.StatusCode, Code: apiErr.Error.Code, Message: apiErr.Error.Message}
+		}
+		return &Error{StatusCode: res.StatusCode, Message: fmt.Sprintf("%s %s: %s: %s", method, path, res.Status, bz)}
+	}
+
+	// The untyped endpoints report failures in the body with a 200 status.
+	var legacyErr struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(bz, &legacyErr) == nil && legacyErr.Error != "" {
+		return &Error{StatusCode: res.StatusCode, Message: legacyErr.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = bz
+		return nil
+	}
+	return json.Unmarshal(bz, out)
+}
@@ -0,0 +1,374 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/localinterchain/client"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	"github.com/strangelove-ventures/localinterchain/interchain/router"
+	"github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+const chainID = "localjuno-1"
+
+// fakeChain is an in-memory handlers.V1Chain. Its height goes up by one every time it is read.
+type fakeChain struct {
+	mu       sync.Mutex
+	height   uint64
+	balances map[string]sdk.Coins
+	keys     map[string]sdk.AccAddress
+	codes    []string
+}
+
+var _ handlers.V1Chain = (*fakeChain)(nil)
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		height:   1,
+		balances: make(map[string]sdk.Coins),
+		keys:     map[string]sdk.AccAddress{"acc0": sdk.AccAddress("acc0________________")},
+	}
+}
+
+func (f *fakeChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: chainID, Denom: "ujuno", Bech32Prefix: "juno"}
+}
+
+func (f *fakeChain) Height(ctx context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.height++
+	return f.height, nil
+}
+
+func (f *fakeChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.keys[keyName]; !ok {
+		return fmt.Errorf("key %s not found", keyName)
+	}
+	f.balances[amount.Address] = f.balances[amount.Address].Add(sdk.NewCoin(amount.Denom, amount.Amount))
+	return nil
+}
+
+func (f *fakeChain) AllBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances[address], nil
+}
+
+func (f *fakeChain) StoreContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.codes = append(f.codes, fileName)
+	return fmt.Sprint(len(f.codes)), nil
+}
+
+func (f *fakeChain) InstantiateContract(ctx context.Context, keyName string, codeID string, initMessage string, needsNoAdminFlag bool, extraExecTxArgs ...string) (string, error) {
+	return "juno14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9skjuwg8", nil
+}
+
+func (f *fakeChain) ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string, extraExecTxArgs ...string) (*sdk.TxResponse, error) {
+	return &sdk.TxResponse{TxHash: "ABCD", Height: 5}, nil
+}
+
+func (f *fakeChain) QueryContract(ctx context.Context, contractAddress string, query any, response any) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeChain) SubmitProposal(ctx context.Context, keyName string, prop cosmos.TxProposalv1) (cosmos.TxProposal, error) {
+	return cosmos.TxProposal{}, errors.New("not implemented")
+}
+
+func (f *fakeChain) QueryProposal(ctx context.Context, proposalID string) (*cosmos.ProposalResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) VoteOnProposal(ctx context.Context, keyName string, proposalID string, vote string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeChain) VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	addr, ok := f.keys[keyName]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyName)
+	}
+	return addr, nil
+}
+
+func (f *fakeChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error) {
+	return ibc.Tx{}, errors.New("not implemented")
+}
+
+// newV1Server serves the v1 API of a fake chain.
+func newV1Server(t *testing.T) (*client.Client, *fakeChain) {
+	t.Helper()
+
+	fake := newFakeChain()
+	r := mux.NewRouter()
+	router.RegisterV1(context.Background(), r, map[string]handlers.V1Chain{chainID: fake})
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL + "/"), fake
+}
+
+// staticConfig is the config of a server whose chains do not change.
+type staticConfig types.Config
+
+func (c *staticConfig) Config() *types.Config {
+	return (*types.Config)(c)
+}
+
+// newServer serves the full router without any chains, from an install directory holding logs.json.
+func newServer(t *testing.T, server types.RestServer, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	installDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(installDir, "configs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(installDir, "configs", "logs.json"), []byte(`{"start_time":1,"chains":[{"chain_id":"localjuno-1"}]}`), 0644))
+
+	config := &staticConfig{Chains: []types.Chain{{ChainID: chainID}}, Server: server}
+	r := router.NewRouter(context.Background(), nil, config,
+		map[string]*cosmos.CosmosChain{}, nil, map[string]*cosmos.ChainNode{},
+		nil, nil, installDir, nil, nil)
+
+	srv := httptest.NewServer(router.Protect(r, server))
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL, opts...)
+}
+
+func TestHeight(t *testing.T) {
+	c, _ := newV1Server(t)
+	ctx := context.Background()
+	juno := c.Chain(chainID)
+
+	start, err := juno.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), start)
+
+	require.NoError(t, juno.WaitForBlocks(ctx, 3))
+	height, err := juno.Height(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, height, start+3)
+}
+
+func TestWaitForHeightTimeout(t *testing.T) {
+	c, _ := newV1Server(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := c.Chain(chainID).WaitForHeight(ctx, 1_000_000)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBank(t *testing.T) {
+	c, fake := newV1Server(t)
+	ctx := context.Background()
+	juno := c.Chain(chainID)
+
+	res, err := juno.BankSend(ctx, handlers.BankSendRequest{KeyName: "acc0", ToAddress: "juno1dest", Amount: "500"})
+	require.NoError(t, err)
+	require.Equal(t, "ujuno", res.Denom)
+	require.Equal(t, math.NewInt(500), fake.balances["juno1dest"].AmountOf("ujuno"))
+
+	balance, err := juno.Balance(ctx, "juno1dest", "ujuno")
+	require.NoError(t, err)
+	require.Equal(t, "500", balance)
+
+	balance, err = juno.Balance(ctx, "juno1dest", "uatom")
+	require.NoError(t, err)
+	require.Equal(t, "0", balance)
+
+	_, err = juno.BankSend(ctx, handlers.BankSendRequest{KeyName: "acc0", ToAddress: "juno1dest", Amount: "-1"})
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, handlers.ErrCodeInvalidRequest, apiErr.Code)
+}
+
+func TestStoreContract(t *testing.T) {
+	c, fake := newV1Server(t)
+	ctx := context.Background()
+
+	wasm := filepath.Join(t.TempDir(), "contract.wasm")
+	require.NoError(t, os.WriteFile(wasm, []byte("\x00asm"), 0644))
+
+	codeID, err := c.Chain(chainID).StoreContract(ctx, "acc0", wasm)
+	require.NoError(t, err)
+	require.Equal(t, "1", codeID)
+	require.Equal(t, []string{wasm}, fake.codes)
+}
+
+func TestKeys(t *testing.T) {
+	c, _ := newV1Server(t)
+	ctx := context.Background()
+	juno := c.Chain(chainID)
+
+	addr, err := juno.Key(ctx, "acc0")
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("juno", []byte("acc0________________")), addr)
+
+	_, err = juno.Key(ctx, "missing")
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, handlers.ErrCodeKeyNotFound, apiErr.Code)
+}
+
+func TestChainNotFound(t *testing.T) {
+	c, _ := newV1Server(t)
+
+	_, err := c.Chain("localjuno-2").Height(context.Background())
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(t, handlers.ErrCodeChainNotFound, apiErr.Code)
+}
+
+func TestActionsReportErrors(t *testing.T) {
+	c := newServer(t, types.RestServer{})
+	ctx := context.Background()
+	juno := c.Chain(chainID)
+
+	// The actions report a missing chain in the body of a 200 response.
+	_, err := juno.Bin(ctx, "version")
+	require.ErrorContains(t, err, "chain_id 'localjuno-1' not found")
+
+	err = juno.Query(ctx, "bank balances juno1dest", &struct{}{})
+	require.ErrorContains(t, err, "not found")
+
+	_, err = juno.Relayer().Channels(ctx)
+	require.ErrorContains(t, err, "not found")
+
+	wasm := filepath.Join(t.TempDir(), "contract.wasm")
+	require.NoError(t, os.WriteFile(wasm, []byte("\x00asm"), 0644))
+	_, err = juno.UploadContract(ctx, "acc0", wasm)
+	require.ErrorContains(t, err, "chain_id localjuno-1 not found")
+
+	_, err = juno.UploadContract(ctx, "acc0", filepath.Join(t.TempDir(), "missing.wasm"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInfo(t *testing.T) {
+	c := newServer(t, types.RestServer{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, c.WaitForStart(ctx))
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), info.Logs.StartTime)
+	require.Len(t, info.Chains, 1)
+	require.Equal(t, chainID, info.Chains[0].ChainID)
+}
+
+func TestWaitForStartTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, client.New(url).WaitForStart(ctx), context.DeadlineExceeded)
+}
+
+func TestAuthToken(t *testing.T) {
+	server := types.RestServer{AuthToken: "secret"}
+	ctx := context.Background()
+
+	_, err := newServer(t, server).Info(ctx)
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	require.Equal(t, router.ErrCodeUnauthorized, apiErr.Code)
+
+	_, err = newServer(t, server, client.WithToken("wrong")).Info(ctx)
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, router.ErrCodeUnauthorized, apiErr.Code)
+
+	_, err = newServer(t, server, client.WithToken("secret")).Info(ctx)
+	require.NoError(t, err)
+}
+
+func TestReadOnly(t *testing.T) {
+	juno := newServer(t, types.RestServer{ReadOnly: true}).Chain(chainID)
+	ctx := context.Background()
+
+	for _, run := range []func() error{
+		func() error { _, err := juno.Exec(ctx, "ls"); return err },
+		func() error { _, err := juno.Relayer().Exec(ctx, "rly paths list"); return err },
+		func() error { _, err := juno.Action(ctx, "overwrite-genesis-file", "new_genesis={}"); return err },
+		func() error { _, err := juno.Bin(ctx, "tx bank send acc0 juno1a 1ujuno"); return err },
+		func() error { return juno.RecoverKey(ctx, "key1", "mnemonic") },
+	} {
+		var apiErr *client.Error
+		require.ErrorAs(t, run(), &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		require.Equal(t, router.ErrCodeReadOnly, apiErr.Code)
+	}
+
+	// Queries still reach the handler, which does not know the chain.
+	err := juno.Query(ctx, "bank total", nil)
+	require.ErrorContains(t, err, "chain_id 'localjuno-1' not found")
+}
+
+func TestCORS(t *testing.T) {
+	installDir := t.TempDir()
+	r := router.NewRouter(context.Background(), nil, &staticConfig{},
+		map[string]*cosmos.CosmosChain{}, nil, map[string]*cosmos.ChainNode{},
+		nil, nil, installDir, nil, nil)
+	srv := httptest.NewServer(router.Protect(r, types.RestServer{
+		AuthToken: "secret",
+		CORS:      types.CORS{AllowedOrigins: []string{"http://localhost:3000"}},
+	}))
+	defer srv.Close()
+
+	preflight := func(origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, srv.URL+"/faucet", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	// Preflights carry no token, so they are answered before authentication.
+	res := preflight("http://localhost:3000")
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	require.Equal(t, "http://localhost:3000", res.Header.Get("Access-Control-Allow-Origin"))
+	require.Contains(t, res.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+
+	res = preflight("http://evil.example")
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	require.Empty(t, res.Header.Get("Access-Control-Allow-Origin"))
+}
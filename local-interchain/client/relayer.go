@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// Relayer runs relayer commands through the actions of a chain.
+type Relayer struct {
+	chain *Chain
+}
+
+// Exec runs a relayer command, e.g. "rly paths list". The relayer's --home is added when it is not set.
+func (r *Relayer) Exec(ctx context.Context, cmd string) ([]byte, error) {
+	return r.chain.Action(ctx, "relayer-exec", cmd)
+}
+
+// Flush relays the pending packets and acknowledgements of a channel on path.
+func (r *Relayer) Flush(ctx context.Context, path, channelID string) error {
+	_, err := r.Exec(ctx, fmt.Sprintf("rly transact flush %s %s", path, channelID))
+	return err
+}
+
+// CreateChannel creates a channel on an existing path, ordering is "ordered" or "unordered".
+func (r *Relayer) CreateChannel(ctx context.Context, path, srcPort, dstPort, ordering, version string) error {
+	_, err := r.Exec(ctx, fmt.Sprintf("rly tx channel %s --src-port %s --dst-port %s --order %s --version %s",
+		path, srcPort, dstPort, ordering, version))
+	return err
+}
+
+// Channels returns the channels of the chain.
+func (r *Relayer) Channels(ctx context.Context) ([]ibc.ChannelOutput, error) {
+	out, err := r.chain.Action(ctx, "get_channels", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []ibc.ChannelOutput
+	if err := json.Unmarshal(out, &channels); err != nil {
+		return nil, fmt.Errorf("failed to parse channels of %s: %s", r.chain.ChainID, strings.TrimSpace(string(out)))
+	}
+	return channels, nil
+}
+
+func (r *Relayer) Start(ctx context.Context, paths ...string) error {
+	_, err := r.chain.Action(ctx, "start-relayer", strings.Join(paths, ","))
+	return err
+}
+
+func (r *Relayer) Stop(ctx context.Context) error {
+	_, err := r.chain.Action(ctx, "stop-relayer", "")
+	return err
+}
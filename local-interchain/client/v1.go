@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+// The methods in this file call the typed v1 API of cosmos chains, described by handlers/openapi.yaml.
+
+func (ch *Chain) v1Path(path string) string {
+	return "/v1/chains/" + url.PathEscape(ch.ChainID) + path
+}
+
+func (ch *Chain) v1(ctx context.Context, method, path string, req, res any) error {
+	return ch.client.do(ctx, method, ch.v1Path(path), nil, req, res)
+}
+
+func (ch *Chain) BankSend(ctx context.Context, req handlers.BankSendRequest) (*handlers.BankSendResponse, error) {
+	var res handlers.BankSendResponse
+	if err := ch.v1(ctx, http.MethodPost, "/bank/send", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (ch *Chain) Balances(ctx context.Context, address string) ([]handlers.Coin, error) {
+	var res handlers.BalancesResponse
+	if err := ch.v1(ctx, http.MethodGet, "/bank/balances/"+url.PathEscape(address), nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Balances, nil
+}
+
+// Balance returns the amount of denom held by address, "0" when it has none.
+func (ch *Chain) Balance(ctx context.Context, address, denom string) (string, error) {
+	balances, err := ch.Balances(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range balances {
+		if c.Denom == denom {
+			return c.Amount, nil
+		}
+	}
+	return "0", nil
+}
+
+// StoreContract stores a wasm file from the machine running local-ic and returns its code id.
+func (ch *Chain) StoreContract(ctx context.Context, keyName, filePath string) (string, error) {
+	var res handlers.WasmStoreResponse
+	if err := ch.v1(ctx, http.MethodPost, "/wasm/store", handlers.WasmStoreRequest{KeyName: keyName, FilePath: filePath}, &res); err != nil {
+		return "", err
+	}
+	return res.CodeID, nil
+}
+
+// InstantiateContract returns the address of the new contract.
+func (ch *Chain) InstantiateContract(ctx context.Context, req handlers.WasmInstantiateRequest) (string, error) {
+	var res handlers.WasmInstantiateResponse
+	if err := ch.v1(ctx, http.MethodPost, "/wasm/instantiate", req, &res); err != nil {
+		return "", err
+	}
+	return res.ContractAddress, nil
+}
+
+func (ch *Chain) ExecuteContract(ctx context.Context, req handlers.WasmExecuteRequest) (*handlers.TxResponse, error) {
+	var res handlers.TxResponse
+	if err := ch.v1(ctx, http.MethodPost, "/wasm/execute", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// QueryContract smart queries a contract and returns the raw JSON result.
+func (ch *Chain) QueryContract(ctx context.Context, req handlers.WasmQueryRequest) ([]byte, error) {
+	var res handlers.WasmQueryResponse
+	if err := ch.v1(ctx, http.MethodPost, "/wasm/query", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+func (ch *Chain) SubmitProposal(ctx context.Context, req handlers.GovSubmitRequest) (*handlers.GovSubmitResponse, error) {
+	var res handlers.GovSubmitResponse
+	if err := ch.v1(ctx, http.MethodPost, "/gov/proposals", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (ch *Chain) Proposal(ctx context.Context, proposalID string) (*cosmos.ProposalResponse, error) {
+	var res cosmos.ProposalResponse
+	if err := ch.v1(ctx, http.MethodGet, "/gov/proposals/"+url.PathEscape(proposalID), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (ch *Chain) Vote(ctx context.Context, proposalID string, req handlers.GovVoteRequest) (*handlers.GovVoteResponse, error) {
+	var res handlers.GovVoteResponse
+	if err := ch.v1(ctx, http.MethodPost, "/gov/proposals/"+url.PathEscape(proposalID)+"/votes", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AddKey creates a key, or recovers it when mnemonic is set, and returns its address.
+func (ch *Chain) AddKey(ctx context.Context, keyName, mnemonic string) (string, error) {
+	var res handlers.KeyResponse
+	if err := ch.v1(ctx, http.MethodPost, "/keys", handlers.KeyRequest{KeyName: keyName, Mnemonic: mnemonic}, &res); err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+// Key returns the address of a key.
+func (ch *Chain) Key(ctx context.Context, keyName string) (string, error) {
+	var res handlers.KeyResponse
+	if err := ch.v1(ctx, http.MethodGet, "/keys/"+url.PathEscape(keyName), nil, &res); err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+func (ch *Chain) IBCTransfer(ctx context.Context, req handlers.IBCTransferRequest) (*handlers.IBCTransferResponse, error) {
+	var res handlers.IBCTransferResponse
+	if err := ch.v1(ctx, http.MethodPost, "/ibc/transfer", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/localinterchain/client"
+)
+
+const (
+	FlagAuthToken = "token"
+	FlagTLS       = "tls"
+)
+
+// addAuthFlags adds the flags apiClient needs besides the API address and port.
+func addAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagAuthToken, "", "auth_token of the API, when the server sets one")
+	cmd.Flags().Bool(FlagTLS, false, "call the API over https, when the server sets tls")
+}
+
+// apiClient returns a client of the API at the address of the command's flags.
+func apiClient(cmd *cobra.Command) *client.Client {
+	apiAddr, _ := cmd.Flags().GetString(FlagAPIAddressOverride)
+	apiPort, _ := cmd.Flags().GetUint16(FlagAPIPortOverride)
+	token, _ := cmd.Flags().GetString(FlagAuthToken)
+	useTLS, _ := cmd.Flags().GetBool(FlagTLS)
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	baseURL := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(apiAddr, strconv.Itoa(int(apiPort))))
+	return client.New(baseURL, client.WithToken(token))
+}
@@ -9,6 +9,7 @@ func main() {
 	rootCmd.AddCommand(chainsCmd)
 	rootCmd.AddCommand(newChainCmd)
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(snapshotCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "error while executing your CLI. Err: %v\n", err)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/localinterchain/interchain/scenario"
+)
+
+const FlagReportOutput = "output"
+
+// runScenario runs the scenario at path against the running chains, for `local-ic run <scenario.yaml>`.
+func runScenario(cmd *cobra.Command, path string) error {
+	output, _ := cmd.Flags().GetString(FlagReportOutput)
+
+	s, err := scenario.Load(path)
+	if err != nil {
+		return err
+	}
+
+	runner := scenario.NewRunner(apiClient(cmd))
+	report := runner.Run(cmd.Context(), s)
+
+	if err := report.WriteText(cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if output != "" {
+		bz, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(output, bz, 0644); err != nil {
+			return err
+		}
+	}
+
+	if !report.Passed {
+		return fmt.Errorf("scenario %s failed", report.Scenario)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <name>",
+	Short: "Saves the state of the running chains and relayer so they can be started again with `start --from-snapshot`",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := apiClient(cmd).Snapshot(cmd.Context(), args[0])
+		if err != nil {
+			return fmt.Errorf("snapshot failed, is local-ic running? %w", err)
+		}
+
+		fmt.Printf("Saved %s to %s\n", res.Name, res.Path)
+		return nil
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "address of the running local-ic API")
+	snapshotCmd.Flags().Uint16(FlagAPIPortOverride, 8080, "port of the running local-ic API")
+	addAuthFlags(snapshotCmd)
+}
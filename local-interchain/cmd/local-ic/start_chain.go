@@ -6,20 +6,39 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/strangelove-ventures/localinterchain/interchain"
+	"github.com/strangelove-ventures/localinterchain/interchain/scenario"
 )
 
 const (
 	FlagAPIAddressOverride = "api-address"
 	FlagAPIPortOverride    = "api-port"
+	FlagFromSnapshot       = "from-snapshot"
 )
 
 var startCmd = &cobra.Command{
 	Use:     "start <config.json>",
 	Aliases: []string{"s", "run"},
 	Short:   "Starts up the chain of choice with the config name",
-	Args:    cobra.ExactArgs(1),
+	Long: `Starts up the chain of choice with the config name.
+
+When called as run with a YAML or JSON scenario, e.g. local-ic run scenarios/ibc_transfer.yaml,
+the scenario is run against the running chains instead.`,
+	Args: cobra.RangeArgs(0, 1),
 	Run: func(cmd *cobra.Command, args []string) {
-		configPath := args[0]
+		if cmd.CalledAs() == "run" && len(args) == 1 && scenario.IsScenario(args[0]) {
+			cobra.CheckErr(runScenario(cmd, args[0]))
+			return
+		}
+
+		fromSnapshot, _ := cmd.Flags().GetString(FlagFromSnapshot)
+		if len(args) == 0 && fromSnapshot == "" {
+			cobra.CheckErr("a config is required unless --" + FlagFromSnapshot + " is set")
+		}
+
+		var configPath string
+		if len(args) > 0 {
+			configPath = args[0]
+		}
 		parentDir := GetDirectory()
 
 		if path.IsAbs(configPath) {
@@ -38,6 +57,8 @@ var startCmd = &cobra.Command{
 		interchain.StartChain(parentDir, configPath, &interchain.AppConfig{
 			Address: apiAddr,
 			Port:    apiPort,
+
+			FromSnapshot: fromSnapshot,
 		})
 	},
 }
@@ -45,4 +66,9 @@ var startCmd = &cobra.Command{
 func init() {
 	startCmd.Flags().String(FlagAPIAddressOverride, "127.0.0.1", "override the default API address")
 	startCmd.Flags().Uint16(FlagAPIPortOverride, 8080, "override the default API port")
+	startCmd.Flags().String(FlagFromSnapshot, "", "restore the chains from a snapshot taken with `local-ic snapshot` instead of starting from genesis")
+
+	// Only used by `run <scenario.yaml>`, the API address and port flags are shared with start.
+	startCmd.Flags().StringP(FlagReportOutput, "o", "", "also write the report of a scenario as JSON to this file")
+	addAuthFlags(startCmd)
 }
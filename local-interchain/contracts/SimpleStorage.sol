@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// SimpleStorage stores a single number, as a minimal contract to deploy to local EVM chains.
+contract SimpleStorage {
+    uint256 private value;
+
+    event ValueChanged(uint256 value);
+
+    function set(uint256 newValue) public {
+        value = newValue;
+        emit ValueChanged(newValue);
+    }
+
+    function get() public view returns (uint256) {
+        return value;
+    }
+}
@@ -2,6 +2,7 @@ package interchain
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -60,13 +61,7 @@ func LoadConfig(installDir, chainCfgFile string) (*types.Config, error) {
 	relayer := config.Relayer
 
 	for i := range chains {
-		chain := chains[i]
-		chain.SetChainDefaults()
-		util.ReplaceStringValues(&chain, "%DENOM%", chain.Denom)
-		util.ReplaceStringValues(&chain, "%BIN%", chain.Binary)
-		util.ReplaceStringValues(&chain, "%CHAIN_ID%", chain.ChainID)
-
-		chains[i] = chain
+		chains[i] = normalizeChain(chains[i])
 
 		if config.Chains[i].Debugging {
 			fmt.Printf("Loaded %v\n", config)
@@ -78,6 +73,19 @@ func LoadConfig(installDir, chainCfgFile string) (*types.Config, error) {
 	return config, nil
 }
 
+// LoadServerConfig applies the optional configs/server.json of installDir on top of the server settings of config.
+func LoadServerConfig(installDir string, config *types.Config) error {
+	serverFilePath := filepath.Join(installDir, "configs", "server.json")
+	if _, err := os.Stat(serverFilePath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if _, err := loadConfig(config, serverFilePath); err != nil {
+		return fmt.Errorf("failed to load %s: %w", serverFilePath, err)
+	}
+	return nil
+}
+
 func FasterBlockTimesBuilder(blockTime string) testutil.Toml {
 	if _, err := time.ParseDuration(blockTime); err != nil {
 		panic(err)
@@ -93,6 +101,15 @@ func FasterBlockTimesBuilder(blockTime string) testutil.Toml {
 	return testutil.Toml{"config/config.toml": tomlCfg}
 }
 
+// normalizeChain sets the chain's defaults and replaces the %DENOM%, %BIN% and %CHAIN_ID% placeholders.
+func normalizeChain(chain types.Chain) types.Chain {
+	chain.SetChainDefaults()
+	util.ReplaceStringValues(&chain, "%DENOM%", chain.Denom)
+	util.ReplaceStringValues(&chain, "%BIN%", chain.Binary)
+	util.ReplaceStringValues(&chain, "%CHAIN_ID%", chain.ChainID)
+	return chain
+}
+
 func CreateChainConfigs(cfg types.Chain) (ibc.ChainConfig, *interchaintest.ChainSpec) {
 	chainCfg := ibc.ChainConfig{
 		Type:                cfg.ChainType,
@@ -111,6 +128,12 @@ func CreateChainConfigs(cfg types.Chain) (ibc.ChainConfig, *interchaintest.Chain
 		EncodingConfig:      nil,
 	}
 
+	// Genesis modifications and config.toml overrides only apply to cosmos nodes.
+	if cfg.ChainType == types.ChainTypeEthereum {
+		chainCfg.ModifyGenesis = nil
+		chainCfg.ConfigFileOverrides = nil
+	}
+
 	if cfg.DockerImage.Version == "" {
 		panic("DockerImage.Version is required in your config")
 	}
@@ -0,0 +1,524 @@
+package interchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"cosmossdk.io/math"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"go.uber.org/zap"
+)
+
+// Environment is a running set of chains and their relayer. Chains and IBC paths can be added and removed
+// while it runs, and it can be saved as a snapshot.
+type Environment struct {
+	mu sync.Mutex
+
+	installDir string
+	testName   string
+
+	// configMu guards config, which the API reads through Config while chains and IBC paths are changed.
+	// The changes also hold mu, so methods of the Environment read config under mu alone.
+	configMu sync.RWMutex
+	config   *types.Config
+
+	logger  *zap.Logger
+	client  *client.Client
+	network string
+
+	// chains are in the same order as config.Chains.
+	chains   []ibc.Chain
+	ibcpaths map[string][]int
+	relayer  ibc.Relayer
+	eRep     ibc.RelayerExecReporter
+
+	// relayerChains holds the chain ids the relayer is configured for, and whether its wallet is funded on the running chain.
+	relayerChains map[string]bool
+
+	channels  []types.IBCChannel
+	contracts map[string]map[string]string
+
+	// newChain and stopChain create and stop the containers of chains added and removed while running.
+	newChain  func(cfg types.Chain) (ibc.Chain, error)
+	stopChain func(ctx context.Context, chain ibc.Chain) error
+}
+
+// NewEnvironment tracks chains started from config. The chains in an IBC path of the config must already be configured in relayer.
+func NewEnvironment(
+	installDir, testName string,
+	config *types.Config,
+	logger *zap.Logger,
+	cli *client.Client,
+	network string,
+	chains []ibc.Chain,
+	relayer ibc.Relayer,
+	eRep ibc.RelayerExecReporter,
+) *Environment {
+	e := &Environment{
+		installDir: installDir,
+		testName:   testName,
+		config:     config,
+		logger:     logger,
+		client:     cli,
+		network:    network,
+		chains:     chains,
+		ibcpaths:   ibcPathsFromConfig(config),
+		relayer:    relayer,
+		eRep:       eRep,
+
+		relayerChains: make(map[string]bool),
+
+		stopChain: stopChain,
+	}
+	e.newChain = e.buildChain
+
+	for _, idxs := range e.ibcpaths {
+		for _, idx := range idxs {
+			e.relayerChains[chains[idx].Config().ChainID] = true
+		}
+	}
+
+	return e
+}
+
+// ibcPathsFromConfig maps each ibc path name to the indexes of its chains in config.Chains.
+func ibcPathsFromConfig(config *types.Config) map[string][]int {
+	ibcpaths := make(map[string][]int)
+	for idx, cfg := range config.Chains {
+		for _, path := range cfg.IBCPaths {
+			ibcpaths[path] = append(ibcpaths[path], idx)
+		}
+	}
+	return ibcpaths
+}
+
+// Config returns a copy of the config of the running chains, which is not changed when chains and IBC paths are.
+func (e *Environment) Config() *types.Config {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
+
+	config := *e.config
+	config.Chains = make([]types.Chain, len(e.config.Chains))
+	for i, c := range e.config.Chains {
+		c.IBCPaths = append([]string{}, c.IBCPaths...)
+		config.Chains[i] = c
+	}
+	return &config
+}
+
+// updateConfig changes the config while holding configMu. The caller must hold mu.
+func (e *Environment) updateConfig(update func(config *types.Config)) {
+	e.configMu.Lock()
+	defer e.configMu.Unlock()
+	update(e.config)
+}
+
+// SetState records the IBC channels and deployed contracts once they are known, and writes them to configs/logs.json.
+func (e *Environment) SetState(channels []types.IBCChannel, contracts map[string]map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.channels = channels
+	e.contracts = contracts
+	e.writeLogs()
+}
+
+func (e *Environment) relayerPaths() []string {
+	return pathNames(e.ibcpaths)
+}
+
+func pathNames(ibcpaths map[string][]int) []string {
+	paths := make([]string, 0, len(ibcpaths))
+	for k := range ibcpaths {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (e *Environment) chainIndex(chainID string) int {
+	for idx, c := range e.chains {
+		if c.Config().ChainID == chainID {
+			return idx
+		}
+	}
+	return -1
+}
+
+// writeLogs keeps configs/logs.json in sync with the running chains.
+func (e *Environment) writeLogs() {
+	DumpChainsInfoToLogs(e.installDir, e.config, e.chains, e.channels, e.contracts)
+}
+
+// AddChain starts a new chain from cfg, the same way chains of the startup config are started.
+// IBC paths are added separately with AddIBCPath once the chain is running.
+func (e *Environment) AddChain(ctx context.Context, cfg types.Chain) (_ ibc.Chain, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cfg = normalizeChain(cfg)
+	if cfg.ChainID == "" {
+		return nil, fmt.Errorf("chain_id is required")
+	}
+	if cfg.DockerImage.Version == "" {
+		return nil, fmt.Errorf("docker_image.version is required")
+	}
+	if e.chainIndex(cfg.ChainID) >= 0 {
+		return nil, fmt.Errorf("chain %s is already running", cfg.ChainID)
+	}
+	if len(cfg.IBCPaths) > 0 {
+		return nil, fmt.Errorf("ibc_paths can not be set on a new chain, add them once it is running")
+	}
+	if len(cfg.Sidecars) > 0 && cfg.ChainType != types.ChainTypeCosmos {
+		return nil, fmt.Errorf("sidecars are only supported on cosmos chains")
+	}
+
+	chain, err := e.newChain(cfg)
+	if err != nil {
+		return nil, err
+	}
+	chains := []ibc.Chain{chain}
+
+	if err := chain.Initialize(ctx, e.testName, e.client, e.network); err != nil {
+		return nil, fmt.Errorf("failed to initialize %s: %w", cfg.ChainID, err)
+	}
+
+	chainCfg := &types.Config{Chains: []types.Chain{cfg}}
+	wallets := SetupGenesisWallets(chainCfg, chains)[chain]
+
+	// Chains started by ic.Build have a faucet, which funds relayer wallets when IBC paths are added.
+	if _, ok := chain.(*cosmos.CosmosChain); ok {
+		faucet, err := chain.BuildWallet(ctx, interchaintest.FaucetAccountKeyName, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create faucet on %s: %w", cfg.ChainID, err)
+		}
+		wallets = append(wallets, ibc.WalletAmount{
+			Address: faucet.FormattedAddress(),
+			Denom:   cfg.Denom,
+			Amount:  math.NewInt(100_000_000_000_000),
+		})
+	}
+
+	if err := chain.Start(e.testName, ctx, wallets...); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", cfg.ChainID, err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if stopErr := e.stopChain(ctx, chain); stopErr != nil {
+			log.Println("Failed to stop", cfg.ChainID, "after it could not be added:", stopErr)
+		}
+	}()
+
+	AddGenesisKeysToKeyring(ctx, chainCfg, chains)
+	PostStartupCommands(ctx, chainCfg, chains)
+
+	deployed, err := DeployContracts(ctx, e.installDir, chainCfg, chains)
+	if err != nil {
+		return nil, err
+	}
+	contracts := make(map[string]map[string]string, len(e.contracts)+len(deployed))
+	for _, m := range []map[string]map[string]string{e.contracts, deployed} {
+		for chainID, c := range m {
+			contracts[chainID] = c
+		}
+	}
+
+	running := append(append([]ibc.Chain{}, e.chains...), chain)
+	if err := StartSidecars(ctx, e.installDir, e.testName, e.client, e.network, chainCfg, chains, running, contracts); err != nil {
+		return nil, err
+	}
+
+	e.updateConfig(func(config *types.Config) {
+		config.Chains = append(config.Chains, cfg)
+	})
+	e.chains = append(e.chains, chain)
+	e.contracts = contracts
+	e.writeLogs()
+
+	log.Println("Added chain", cfg.ChainID)
+	return chain, nil
+}
+
+// RemoveChain stops and removes the containers of a chain, along with the IBC paths it is part of.
+// Its volumes are kept until local-ic exits. If the chain can not be stopped, it stays in the environment
+// without its IBC paths, so that removing it can be retried.
+func (e *Environment) RemoveChain(ctx context.Context, chainID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	idx := e.chainIndex(chainID)
+	if idx < 0 {
+		return fmt.Errorf("chain %s is not running", chainID)
+	}
+	chain := e.chains[idx]
+
+	// Stop relaying the chain's paths before the chain goes away.
+	removed := make(map[string]bool)
+	for _, path := range e.config.Chains[idx].IBCPaths {
+		removed[path] = true
+	}
+	if len(removed) > 0 {
+		pruned := types.Config{Chains: make([]types.Chain, len(e.config.Chains))}
+		for i, c := range e.config.Chains {
+			c.IBCPaths = withoutPaths(c.IBCPaths, removed)
+			pruned.Chains[i] = c
+		}
+		if err := e.restartRelayer(ctx, ibcPathsFromConfig(&pruned)); err != nil {
+			return err
+		}
+
+		e.updateConfig(func(config *types.Config) {
+			for i := range config.Chains {
+				config.Chains[i].IBCPaths = pruned.Chains[i].IBCPaths
+			}
+		})
+		channels := []types.IBCChannel{}
+		for _, c := range e.channels {
+			if !removed[c.Path] {
+				channels = append(channels, c)
+			}
+		}
+		e.channels = channels
+	}
+
+	if err := e.stopChain(ctx, chain); err != nil {
+		e.writeLogs()
+		return fmt.Errorf("failed to stop %s: %w", chainID, err)
+	}
+
+	e.updateConfig(func(config *types.Config) {
+		config.Chains = append(config.Chains[:idx:idx], config.Chains[idx+1:]...)
+	})
+	e.chains = append(e.chains[:idx:idx], e.chains[idx+1:]...)
+	// The chain has no paths left, but the indexes of the chains after it changed.
+	e.ibcpaths = ibcPathsFromConfig(e.config)
+	delete(e.contracts, chainID)
+
+	channels := []types.IBCChannel{}
+	for _, c := range e.channels {
+		if c.ChainID != chainID {
+			channels = append(channels, c)
+		}
+	}
+	e.channels = channels
+
+	// The relayer keeps its config and key for the chain, but the wallet must be funded again if the chain returns.
+	if _, ok := e.relayerChains[chainID]; ok {
+		e.relayerChains[chainID] = false
+	}
+
+	e.writeLogs()
+	log.Println("Removed chain", chainID)
+	return nil
+}
+
+func withoutPaths(paths []string, removed map[string]bool) []string {
+	kept := []string{}
+	for _, path := range paths {
+		if !removed[path] {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// buildChain creates a chain from cfg the same way the chains of the startup config are created.
+func (e *Environment) buildChain(cfg types.Chain) (ibc.Chain, error) {
+	_, chainSpec := CreateChainConfigs(cfg)
+	cf := interchaintest.NewBuiltinChainFactory(e.logger, []*interchaintest.ChainSpec{chainSpec})
+	chains, err := cf.Chains(e.testName)
+	if err != nil {
+		return nil, err
+	}
+	return chains[0], nil
+}
+
+// stopChain stops and removes the containers of a chain and its sidecars.
+func stopChain(ctx context.Context, chain ibc.Chain) error {
+	switch c := chain.(type) {
+	case *cosmos.CosmosChain:
+		if err := c.StopAllNodes(ctx); err != nil {
+			return err
+		}
+		return c.StopAllSidecars(ctx)
+	case *ethereum.EthereumChain:
+		return c.Stop(ctx)
+	default:
+		return fmt.Errorf("removing chains of type %T is not supported", chain)
+	}
+}
+
+// AddIBCPath creates clients, connections and a transfer channel between two running cosmos chains, and starts relaying them.
+func (e *Environment) AddIBCPath(ctx context.Context, path, chainA, chainB string) ([]types.IBCChannel, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.relayer == nil {
+		return nil, fmt.Errorf("relayer not configured for this setup")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	if _, ok := e.ibcpaths[path]; ok {
+		return nil, fmt.Errorf("ibc path '%s' already exists", path)
+	}
+	if chainA == chainB {
+		return nil, fmt.Errorf("ibc path '%s' must be between two different chains", path)
+	}
+
+	idxs := make([]int, 2)
+	for i, chainID := range []string{chainA, chainB} {
+		idx := e.chainIndex(chainID)
+		if idx < 0 {
+			return nil, fmt.Errorf("chain %s is not running", chainID)
+		}
+		if _, ok := e.chains[idx].(*cosmos.CosmosChain); !ok {
+			return nil, fmt.Errorf("chain %s does not support IBC", chainID)
+		}
+		if err := e.configureRelayerChain(ctx, e.chains[idx]); err != nil {
+			return nil, err
+		}
+		idxs[i] = idx
+	}
+
+	before, err := e.relayer.GetChannels(ctx, e.eRep, chainA)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.relayer.GeneratePath(ctx, e.eRep, chainA, chainB, path); err != nil {
+		return nil, fmt.Errorf("failed to generate path %s between %s and %s: %w", path, chainA, chainB, err)
+	}
+	if err := e.relayer.LinkPath(ctx, e.eRep, path, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts()); err != nil {
+		return nil, fmt.Errorf("failed to link path %s between %s and %s: %w", path, chainA, chainB, err)
+	}
+
+	channels, err := e.newTransferChannels(ctx, path, chainA, chainB, before)
+	if err != nil {
+		return nil, err
+	}
+
+	ibcpaths := ibcPathsFromConfig(e.config)
+	ibcpaths[path] = idxs
+	if err := e.restartRelayer(ctx, ibcpaths); err != nil {
+		return nil, err
+	}
+
+	e.updateConfig(func(config *types.Config) {
+		for _, idx := range idxs {
+			config.Chains[idx].IBCPaths = append(config.Chains[idx].IBCPaths, path)
+		}
+	})
+	e.channels = append(e.channels, channels...)
+	e.writeLogs()
+
+	log.Println("Added IBC path", path, "between", chainA, "and", chainB)
+	return channels, nil
+}
+
+// configureRelayerChain adds the chain and a funded key to the relayer, unless that was already done.
+func (e *Environment) configureRelayerChain(ctx context.Context, chain ibc.Chain) error {
+	cfg := chain.Config()
+
+	funded, configured := e.relayerChains[cfg.ChainID]
+	if !configured {
+		rpcAddr, grpcAddr := chain.GetRPCAddress(), chain.GetGRPCAddress()
+		if !e.relayer.UseDockerNetwork() {
+			rpcAddr, grpcAddr = chain.GetHostRPCAddress(), chain.GetHostGRPCAddress()
+		}
+
+		if err := e.relayer.AddChainConfiguration(ctx, e.eRep, cfg, cfg.Name, rpcAddr, grpcAddr); err != nil {
+			return fmt.Errorf("failed to configure relayer for chain %s: %w", cfg.ChainID, err)
+		}
+		if _, err := e.relayer.AddKey(ctx, e.eRep, cfg.ChainID, cfg.Name, cfg.CoinType); err != nil {
+			return fmt.Errorf("failed to add relayer key for chain %s: %w", cfg.ChainID, err)
+		}
+		e.relayerChains[cfg.ChainID] = false
+	}
+
+	if !funded {
+		wallet, ok := e.relayer.GetWallet(cfg.ChainID)
+		if !ok {
+			return fmt.Errorf("relayer has no wallet for chain %s", cfg.ChainID)
+		}
+		if err := chain.SendFunds(ctx, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: wallet.FormattedAddress(),
+			Denom:   cfg.Denom,
+			Amount:  math.NewInt(1_000_000_000_000),
+		}); err != nil {
+			return fmt.Errorf("failed to fund relayer wallet on %s: %w", cfg.ChainID, err)
+		}
+		e.relayerChains[cfg.ChainID] = true
+	}
+
+	return nil
+}
+
+// newTransferChannels finds the transfer channel created on chainA since before, and its counterparty on chainB.
+func (e *Environment) newTransferChannels(ctx context.Context, path, chainA, chainB string, before []ibc.ChannelOutput) ([]types.IBCChannel, error) {
+	existing := make(map[string]bool, len(before))
+	for _, c := range before {
+		existing[c.PortID+"/"+c.ChannelID] = true
+	}
+
+	after, err := e.relayer.GetChannels(ctx, e.eRep, chainA)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range after {
+		a := a
+		if a.PortID != "transfer" || existing[a.PortID+"/"+a.ChannelID] {
+			continue
+		}
+
+		counterparties, err := e.relayer.GetChannels(ctx, e.eRep, chainB)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range counterparties {
+			b := b
+			if b.PortID == a.Counterparty.PortID && b.ChannelID == a.Counterparty.ChannelID {
+				return []types.IBCChannel{
+					{ChainID: chainA, Path: path, Channel: &a},
+					{ChainID: chainB, Path: path, Channel: &b},
+				}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no transfer channel was created for path %s", path)
+}
+
+// restartRelayer relays the paths of ibcpaths instead of the current ones. If it can not, it relays
+// the current ones again.
+func (e *Environment) restartRelayer(ctx context.Context, ibcpaths map[string][]int) error {
+	if len(e.ibcpaths) > 0 {
+		if err := e.relayer.StopRelayer(ctx, e.eRep); err != nil {
+			return fmt.Errorf("failed to stop relayer: %w", err)
+		}
+	}
+	if len(ibcpaths) > 0 {
+		if err := e.relayer.StartRelayer(ctx, e.eRep, pathNames(ibcpaths)...); err != nil {
+			if len(e.ibcpaths) > 0 {
+				if restartErr := e.relayer.StartRelayer(ctx, e.eRep, e.relayerPaths()...); restartErr != nil {
+					e.ibcpaths = nil
+					return fmt.Errorf("failed to start relayer: %w, nor with its previous paths: %v", err, restartErr)
+				}
+			}
+			return fmt.Errorf("failed to start relayer: %w", err)
+		}
+	}
+	e.ibcpaths = ibcpaths
+	return nil
+}
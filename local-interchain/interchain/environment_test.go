@@ -0,0 +1,241 @@
+package interchain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// envChain is a chain without containers, calling any method not used by the Environment panics.
+type envChain struct {
+	ibc.Chain
+
+	chainID string
+	stopErr error
+	stopped bool
+}
+
+func (c *envChain) Config() ibc.ChainConfig { return ibc.ChainConfig{ChainID: c.chainID} }
+func (c *envChain) GetRPCAddress() string   { return "http://" + c.chainID + ":26657" }
+func (c *envChain) GetGRPCAddress() string  { return c.chainID + ":9090" }
+
+func (c *envChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	return nil
+}
+
+func (c *envChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	return nil
+}
+
+// envRelayer records the paths it relays. startErr is returned by the next StartRelayer call only.
+type envRelayer struct {
+	ibc.Relayer
+
+	startErr error
+	paths    []string
+}
+
+func (r *envRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	r.paths = nil
+	return nil
+}
+
+func (r *envRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if err := r.startErr; err != nil {
+		r.startErr = nil
+		return err
+	}
+	r.paths = pathNames
+	return nil
+}
+
+// newTestEnvironment runs localjuno-1, localosmo-1 and localgaia-1, with juno relayed to the others.
+func newTestEnvironment(t *testing.T) (*Environment, *envRelayer, []*envChain) {
+	t.Helper()
+
+	installDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(installDir, "configs"), 0755))
+
+	chains := []*envChain{{chainID: "localjuno-1"}, {chainID: "localosmo-1"}, {chainID: "localgaia-1"}}
+	config := &types.Config{Chains: []types.Chain{
+		{ChainID: "localjuno-1", IBCPaths: []string{"juno-osmo", "juno-gaia"}},
+		{ChainID: "localosmo-1", IBCPaths: []string{"juno-osmo"}},
+		{ChainID: "localgaia-1", IBCPaths: []string{"juno-gaia"}},
+	}}
+	relayer := &envRelayer{paths: []string{"juno-gaia", "juno-osmo"}}
+
+	e := NewEnvironment(installDir, "test", config, zap.NewNop(), nil, "", []ibc.Chain{chains[0], chains[1], chains[2]}, relayer, nil)
+	e.SetState([]types.IBCChannel{
+		{ChainID: "localjuno-1", Path: "juno-osmo"},
+		{ChainID: "localosmo-1", Path: "juno-osmo"},
+		{ChainID: "localjuno-1", Path: "juno-gaia"},
+		{ChainID: "localgaia-1", Path: "juno-gaia"},
+	}, nil)
+	e.stopChain = func(ctx context.Context, chain ibc.Chain) error {
+		c := chain.(*envChain)
+		if c.stopErr != nil {
+			return c.stopErr
+		}
+		c.stopped = true
+		return nil
+	}
+	return e, relayer, chains
+}
+
+// chainConfig is the config of a cosmos chain added while running.
+func chainConfig(chainID string) types.Chain {
+	return types.Chain{
+		ChainID:      chainID,
+		ChainType:    "cosmos",
+		Binary:       "simd",
+		Denom:        "stake",
+		Bech32Prefix: "cosmos",
+		DockerImage:  types.DockerImage{Version: "v1.0.0"},
+	}
+}
+
+func chainIDs(config *types.Config) []string {
+	ids := []string{}
+	for _, c := range config.Chains {
+		ids = append(ids, c.ChainID)
+	}
+	return ids
+}
+
+func TestEnvironment_AddChain(t *testing.T) {
+	e, _, _ := newTestEnvironment(t)
+	stars := &envChain{chainID: "localstars-1"}
+	e.newChain = func(cfg types.Chain) (ibc.Chain, error) { return stars, nil }
+
+	cfg := chainConfig("localstars-1")
+	chain, err := e.AddChain(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, stars, chain)
+	require.Equal(t, []string{"localjuno-1", "localosmo-1", "localgaia-1", "localstars-1"}, chainIDs(e.Config()))
+	require.Equal(t, 3, e.chainIndex("localstars-1"))
+
+	_, err = e.AddChain(context.Background(), cfg)
+	require.EqualError(t, err, "chain localstars-1 is already running")
+}
+
+func TestEnvironment_AddChain_StopsOnError(t *testing.T) {
+	e, _, _ := newTestEnvironment(t)
+	stars := &envChain{chainID: "localstars-1"}
+	e.newChain = func(cfg types.Chain) (ibc.Chain, error) { return stars, nil }
+
+	// Sidecars fail to start once the chain is running, since it is not a *cosmos.CosmosChain.
+	cfg := chainConfig("localstars-1")
+	cfg.Sidecars = []types.Sidecar{{Name: "sidecar"}}
+	_, err := e.AddChain(context.Background(), cfg)
+	require.ErrorContains(t, err, "sidecars are only supported on cosmos chains")
+	require.True(t, stars.stopped)
+	require.Equal(t, []string{"localjuno-1", "localosmo-1", "localgaia-1"}, chainIDs(e.Config()))
+	require.Equal(t, -1, e.chainIndex("localstars-1"))
+}
+
+func TestEnvironment_RemoveChain(t *testing.T) {
+	e, relayer, chains := newTestEnvironment(t)
+
+	require.NoError(t, e.RemoveChain(context.Background(), "localosmo-1"))
+	require.True(t, chains[1].stopped)
+	require.Equal(t, []string{"juno-gaia"}, relayer.paths)
+
+	config := e.Config()
+	require.Equal(t, []string{"localjuno-1", "localgaia-1"}, chainIDs(config))
+	require.Equal(t, []string{"juno-gaia"}, config.Chains[0].IBCPaths)
+	require.Equal(t, []string{"juno-gaia"}, config.Chains[1].IBCPaths)
+	require.Len(t, e.channels, 2)
+	// The indexes of the chains after the removed one changed.
+	require.Equal(t, map[string][]int{"juno-gaia": {0, 1}}, e.ibcpaths)
+
+	require.EqualError(t, e.RemoveChain(context.Background(), "localosmo-1"), "chain localosmo-1 is not running")
+
+	// Without juno there are no paths left to relay.
+	require.NoError(t, e.RemoveChain(context.Background(), "localjuno-1"))
+	require.Empty(t, relayer.paths)
+	require.Empty(t, e.Config().Chains[0].IBCPaths)
+	require.Empty(t, e.channels)
+	require.Empty(t, e.ibcpaths)
+}
+
+func TestEnvironment_RemoveChain_RelayerError(t *testing.T) {
+	e, relayer, chains := newTestEnvironment(t)
+	relayer.startErr = errors.New("relayer failed")
+
+	err := e.RemoveChain(context.Background(), "localosmo-1")
+	require.EqualError(t, err, "failed to start relayer: relayer failed")
+	require.False(t, chains[1].stopped)
+
+	// The relayer relays the previous paths again, and nothing is removed.
+	require.Equal(t, []string{"juno-gaia", "juno-osmo"}, relayer.paths)
+	config := e.Config()
+	require.Equal(t, []string{"localjuno-1", "localosmo-1", "localgaia-1"}, chainIDs(config))
+	require.Equal(t, []string{"juno-osmo", "juno-gaia"}, config.Chains[0].IBCPaths)
+	require.Len(t, e.channels, 4)
+}
+
+func TestEnvironment_RemoveChain_StopError(t *testing.T) {
+	e, relayer, chains := newTestEnvironment(t)
+	chains[1].stopErr = errors.New("docker failed")
+
+	err := e.RemoveChain(context.Background(), "localosmo-1")
+	require.EqualError(t, err, "failed to stop localosmo-1: docker failed")
+
+	// The relayer no longer relays the chain's path, and the chain can be removed again.
+	require.Equal(t, []string{"juno-gaia"}, relayer.paths)
+	config := e.Config()
+	require.Equal(t, []string{"localjuno-1", "localosmo-1", "localgaia-1"}, chainIDs(config))
+	require.Equal(t, []string{"juno-gaia"}, config.Chains[0].IBCPaths)
+	require.Empty(t, config.Chains[1].IBCPaths)
+	require.Len(t, e.channels, 2)
+
+	chains[1].stopErr = nil
+	require.NoError(t, e.RemoveChain(context.Background(), "localosmo-1"))
+	require.Equal(t, []string{"localjuno-1", "localgaia-1"}, chainIDs(e.Config()))
+}
+
+// The API reads the config while chains are added and removed, run with -race.
+func TestEnvironment_ConcurrentConfig(t *testing.T) {
+	e, _, _ := newTestEnvironment(t)
+	e.newChain = func(cfg types.Chain) (ibc.Chain, error) { return &envChain{chainID: cfg.ChainID}, nil }
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, c := range e.Config().Chains {
+				_ = c.ChainID + c.ChainType
+				_ = len(c.IBCPaths)
+			}
+		}
+	}()
+
+	ctx := context.Background()
+	for _, chainID := range []string{"localstars-1", "localhub-1"} {
+		_, err := e.AddChain(ctx, chainConfig(chainID))
+		require.NoError(t, err)
+	}
+	require.NoError(t, e.RemoveChain(ctx, "localjuno-1"))
+	require.NoError(t, e.RemoveChain(ctx, "localstars-1"))
+
+	close(done)
+	wg.Wait()
+	require.Equal(t, []string{"localosmo-1", "localgaia-1", "localhub-1"}, chainIDs(e.Config()))
+}
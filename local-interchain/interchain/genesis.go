@@ -2,18 +2,27 @@ package interchain
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	types "github.com/strangelove-ventures/localinterchain/interchain/types"
 )
 
 func AddGenesisKeysToKeyring(ctx context.Context, config *types.Config, chains []ibc.Chain) {
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		// ethereum genesis accounts are funded by address and have no keyring.
+		chainObj, ok := chains[idx].(*cosmos.CosmosChain)
+		if !ok {
+			continue
+		}
 
 		for _, acc := range chain.Genesis.Accounts {
 			if err := chainObj.RecoverKey(ctx, acc.Name, acc.Mnemonic); err != nil {
@@ -25,12 +34,18 @@ func AddGenesisKeysToKeyring(ctx context.Context, config *types.Config, chains [
 
 func PostStartupCommands(ctx context.Context, config *types.Config, chains []ibc.Chain) {
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		chainObj := chains[idx]
 
 		for _, cmd := range chain.Genesis.StartupCommands {
 			log.Println("Running startup command", chainObj.Config().ChainID, cmd)
 
-			cmd = strings.ReplaceAll(cmd, "%HOME%", chainObj.Validators[0].HomeDir())
+			switch c := chainObj.(type) {
+			case *cosmos.CosmosChain:
+				cmd = strings.ReplaceAll(cmd, "%HOME%", c.Validators[0].HomeDir())
+			case *ethereum.EthereumChain:
+				cmd = strings.ReplaceAll(cmd, "%HOME%", c.HomeDir())
+				cmd = strings.ReplaceAll(cmd, "%RPC%", c.GetRPCAddress())
+			}
 			cmd = strings.ReplaceAll(cmd, "%CHAIN_ID%", chainObj.Config().ChainID)
 
 			stdout, stderr, err := chainObj.Exec(ctx, strings.Split(cmd, " "), []string{})
@@ -51,7 +66,7 @@ func SetupGenesisWallets(config *types.Config, chains []ibc.Chain) map[ibc.Chain
 	// iterate all chains chain's configs & setup accounts
 	additionalWallets := make(map[ibc.Chain][]ibc.WalletAmount)
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
+		chainObj := chains[idx]
 
 		for _, acc := range chain.Genesis.Accounts {
 			amount, err := sdk.ParseCoinsNormalized(acc.Amount)
@@ -70,3 +85,39 @@ func SetupGenesisWallets(config *types.Config, chains []ibc.Chain) map[ibc.Chain
 	}
 	return additionalWallets
 }
+
+// DeployContracts deploys the Solidity contracts listed in each ethereum chain's genesis, signed by the faucet.
+// It returns the deployed addresses by chain id and contract name.
+func DeployContracts(ctx context.Context, installDir string, config *types.Config, chains []ibc.Chain) (map[string]map[string]string, error) {
+	deployed := make(map[string]map[string]string)
+	for idx, chain := range config.Chains {
+		ethChain, ok := chains[idx].(*ethereum.EthereumChain)
+		if !ok || len(chain.Genesis.Contracts) == 0 {
+			continue
+		}
+
+		chainID := ethChain.Config().ChainID
+		deployed[chainID] = make(map[string]string)
+
+		for _, contract := range chain.Genesis.Contracts {
+			src, err := os.ReadFile(filepath.Join(installDir, contract.Path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read contract %s: %w", contract.Name, err)
+			}
+
+			relPath := path.Join("contracts", path.Base(contract.Path))
+			if err := ethChain.WriteFile(ctx, src, relPath); err != nil {
+				return nil, fmt.Errorf("failed to copy contract %s: %w", contract.Name, err)
+			}
+
+			addr, err := ethChain.DeployContract(ctx, "faucet", relPath, contract.Name, contract.ConstructorArgs...)
+			if err != nil {
+				return nil, err
+			}
+
+			log.Println("Deployed contract", chainID, contract.Name, addr)
+			deployed[chainID][contract.Name] = addr
+		}
+	}
+	return deployed, nil
+}
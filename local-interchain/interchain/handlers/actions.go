@@ -12,14 +12,19 @@ import (
 
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 )
 
+// ErrCodeReadOnly is returned in ErrorResponse for requests a read-only server refuses.
+const ErrCodeReadOnly = "read_only"
+
 type actions struct {
 	ctx  context.Context
 	ic   *interchaintest.Interchain
 	vals map[string]*cosmos.ChainNode
 	cc   map[string]*cosmos.CosmosChain
+	eth  map[string]*ethereum.EthereumChain
 
 	relayer ibc.Relayer
 	eRep    ibc.RelayerExecReporter
@@ -31,12 +36,38 @@ type ActionHandler struct {
 	Cmd     string `json:"cmd"`
 }
 
-func NewActions(ctx context.Context, ic *interchaintest.Interchain, cosmosChains map[string]*cosmos.CosmosChain, vals map[string]*cosmos.ChainNode, relayer ibc.Relayer, eRep ibc.RelayerExecReporter) *actions {
+// IsReadOnlyAction reports whether an action only reads the state of a chain or the relayer.
+// Read-only servers refuse every other action.
+func IsReadOnlyAction(action string) bool {
+	switch action {
+	case "q", "query",
+		"get_channels", "get-channels", "getChannels",
+		"dump-contract-state":
+		return true
+	}
+	return false
+}
+
+type readOnlyKey struct{}
+
+// WithReadOnly marks the requests of a read-only server. Their ethereum queries are limited to castQueries,
+// since cast can also send transactions.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+func NewActions(ctx context.Context, ic *interchaintest.Interchain, cosmosChains map[string]*cosmos.CosmosChain, ethChains map[string]*ethereum.EthereumChain, vals map[string]*cosmos.ChainNode, relayer ibc.Relayer, eRep ibc.RelayerExecReporter) *actions {
 	return &actions{
 		ctx:     ctx,
 		ic:      ic,
 		vals:    vals,
 		cc:      cosmosChains,
+		eth:     ethChains,
 		relayer: relayer,
 		eRep:    eRep,
 	}
@@ -57,6 +88,11 @@ func (a *actions) PostActions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	chainId := ah.ChainId
+	if eth, ok := a.eth[chainId]; ok {
+		a.postEthereumActions(w, r, ah, eth)
+		return
+	}
+
 	if _, ok := a.vals[chainId]; !ok {
 		util.Write(w, []byte(fmt.Sprintf(`{"error":"chain_id '%s' not found. Chains %v"}`, chainId, a.vals[chainId])))
 		return
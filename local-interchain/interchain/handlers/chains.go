@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// ChainManager starts and stops chains and IBC paths while the API is running.
+type ChainManager interface {
+	AddChain(ctx context.Context, cfg types.Chain) (ibc.Chain, error)
+	RemoveChain(ctx context.Context, chainID string) error
+	AddIBCPath(ctx context.Context, path, chainA, chainB string) ([]types.IBCChannel, error)
+}
+
+type chains struct {
+	ctx     context.Context
+	manager ChainManager
+
+	// mu guards the chain maps shared with the other handlers.
+	mu   *sync.RWMutex
+	cc   map[string]*cosmos.CosmosChain
+	eth  map[string]*ethereum.EthereumChain
+	vals map[string]*cosmos.ChainNode
+	v1   map[string]V1Chain
+
+	events *events
+}
+
+type ChainResponse struct {
+	ChainID string `json:"chain_id"`
+}
+
+type IBCPathRequest struct {
+	Path   string `json:"path"`
+	ChainA string `json:"chain_a"`
+	ChainB string `json:"chain_b"`
+}
+
+type IBCPathResponse struct {
+	Path     string             `json:"path"`
+	Channels []types.IBCChannel `json:"channels"`
+}
+
+func NewChains(
+	ctx context.Context,
+	manager ChainManager,
+	mu *sync.RWMutex,
+	cosmosChains map[string]*cosmos.CosmosChain,
+	ethChains map[string]*ethereum.EthereumChain,
+	vals map[string]*cosmos.ChainNode,
+	v1Chains map[string]V1Chain,
+	events *events,
+) *chains {
+	return &chains{
+		ctx:     ctx,
+		manager: manager,
+		mu:      mu,
+		cc:      cosmosChains,
+		eth:     ethChains,
+		vals:    vals,
+		v1:      v1Chains,
+		events:  events,
+	}
+}
+
+func writeChainsError(w http.ResponseWriter, status int, code, msg string) {
+	util.WriteJSON(w, status, ErrorResponse{Error: APIError{Code: code, Message: msg}})
+}
+
+func (c *chains) PostChain(w http.ResponseWriter, r *http.Request) {
+	var cfg types.Chain
+	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		writeChainsError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	chain, err := c.manager.AddChain(c.ctx, cfg)
+	if err != nil {
+		writeChainsError(w, http.StatusInternalServerError, ErrCodeChainError, err.Error())
+		return
+	}
+
+	chainID := chain.Config().ChainID
+	c.mu.Lock()
+	switch ch := chain.(type) {
+	case *cosmos.CosmosChain:
+		c.cc[chainID] = ch
+		c.vals[chainID] = ch.Validators[0]
+		c.v1[chainID] = ch
+		c.events.AddChain(chainID, ch.Validators[0])
+	case *ethereum.EthereumChain:
+		c.eth[chainID] = ch
+	}
+	c.mu.Unlock()
+
+	util.WriteJSON(w, http.StatusOK, ChainResponse{ChainID: chainID})
+}
+
+func (c *chains) DeleteChain(w http.ResponseWriter, r *http.Request) {
+	chainID := mux.Vars(r)["chain_id"]
+
+	c.mu.RLock()
+	_, isCosmos := c.cc[chainID]
+	_, isEth := c.eth[chainID]
+	c.mu.RUnlock()
+	if !isCosmos && !isEth {
+		writeChainsError(w, http.StatusNotFound, ErrCodeChainNotFound, fmt.Sprintf("chain_id '%s' not found", chainID))
+		return
+	}
+
+	// The handlers keep serving the chain if it could not be removed.
+	if err := c.manager.RemoveChain(c.ctx, chainID); err != nil {
+		writeChainsError(w, http.StatusInternalServerError, ErrCodeChainError, err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.cc, chainID)
+	delete(c.eth, chainID)
+	delete(c.vals, chainID)
+	delete(c.v1, chainID)
+	c.mu.Unlock()
+	c.events.RemoveChain(chainID)
+
+	util.WriteJSON(w, http.StatusOK, ChainResponse{ChainID: chainID})
+}
+
+func (c *chains) PostIBCPath(w http.ResponseWriter, r *http.Request) {
+	var req IBCPathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeChainsError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	if req.Path == "" || req.ChainA == "" || req.ChainB == "" {
+		writeChainsError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "path, chain_a and chain_b are required")
+		return
+	}
+
+	channels, err := c.manager.AddIBCPath(c.ctx, req.Path, req.ChainA, req.ChainB)
+	if err != nil {
+		writeChainsError(w, http.StatusInternalServerError, ErrCodeChainError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, IBCPathResponse{Path: req.Path, Channels: channels})
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+//go:embed dashboard.html
+var dashboardPage []byte
+
+const (
+	// relayerContainer is the name the relayer's logs are requested by.
+	relayerContainer = "relayer"
+
+	defaultLogTail = 200
+	heightTimeout  = 3 * time.Second
+)
+
+// logReader is implemented by the chain nodes and relayers whose container output can be read.
+type logReader interface {
+	Logs(ctx context.Context, tail int) ([]byte, error)
+}
+
+type dashboard struct {
+	ctx        context.Context
+	config     ConfigSource
+	installDir string
+
+	cc      map[string]*cosmos.CosmosChain
+	eth     map[string]*ethereum.EthereumChain
+	relayer ibc.Relayer
+}
+
+type DashboardStatus struct {
+	Chains   []DashboardChain   `json:"chains"`
+	Channels []types.IBCChannel `json:"ibc_channels"`
+	Relayer  DashboardRelayer   `json:"relayer"`
+}
+
+type DashboardChain struct {
+	ChainID string `json:"chain_id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Denom   string `json:"denom"`
+
+	Height      uint64 `json:"height"`
+	HeightError string `json:"height_error,omitempty"`
+
+	RPCAddress  string `json:"rpc_address"`
+	APIAddress  string `json:"api_address,omitempty"`
+	GRPCAddress string `json:"grpc_address,omitempty"`
+
+	Nodes []DashboardNode `json:"nodes"`
+}
+
+// DashboardNode is a container of a chain. Its logs are served at /dashboard/logs/{name}.
+type DashboardNode struct {
+	Name      string `json:"name"`
+	Validator bool   `json:"validator"`
+}
+
+type DashboardRelayer struct {
+	Name    string   `json:"name,omitempty"`
+	Image   string   `json:"image,omitempty"`
+	Running bool     `json:"running"`
+	Paths   []string `json:"paths"`
+}
+
+func NewDashboard(
+	ctx context.Context,
+	config ConfigSource,
+	installDir string,
+	cosmosChains map[string]*cosmos.CosmosChain,
+	ethChains map[string]*ethereum.EthereumChain,
+	relayer ibc.Relayer,
+) *dashboard {
+	return &dashboard{
+		ctx:        ctx,
+		config:     config,
+		installDir: installDir,
+		cc:         cosmosChains,
+		eth:        ethChains,
+		relayer:    relayer,
+	}
+}
+
+// GetDashboard serves the web UI.
+func (d *dashboard) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	util.Write(w, dashboardPage)
+}
+
+// GetStatus returns the chains, channels and relayer shown by the web UI.
+func (d *dashboard) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status := DashboardStatus{
+		Chains:   []DashboardChain{},
+		Channels: d.channels(),
+		Relayer:  d.relayerStatus(),
+	}
+
+	for chainID, c := range d.cc {
+		dc := DashboardChain{
+			ChainID:     chainID,
+			Name:        c.Config().Name,
+			Type:        types.ChainTypeCosmos,
+			Denom:       c.Config().Denom,
+			RPCAddress:  c.GetHostRPCAddress(),
+			APIAddress:  c.GetHostAPIAddress(),
+			GRPCAddress: c.GetHostGRPCAddress(),
+			Nodes:       []DashboardNode{},
+		}
+		for _, n := range c.Nodes() {
+			dc.Nodes = append(dc.Nodes, DashboardNode{Name: n.Name(), Validator: n.Validator})
+		}
+		dc.Height, dc.HeightError = d.height(c)
+		status.Chains = append(status.Chains, dc)
+	}
+
+	for chainID, c := range d.eth {
+		dc := DashboardChain{
+			ChainID:    chainID,
+			Name:       c.Config().Name,
+			Type:       types.ChainTypeEthereum,
+			Denom:      c.Config().Denom,
+			RPCAddress: c.GetHostRPCAddress(),
+			Nodes:      []DashboardNode{{Name: c.Name(), Validator: true}},
+		}
+		dc.Height, dc.HeightError = d.height(c)
+		status.Chains = append(status.Chains, dc)
+	}
+
+	sort.Slice(status.Chains, func(i, j int) bool { return status.Chains[i].ChainID < status.Chains[j].ChainID })
+	util.WriteJSON(w, http.StatusOK, status)
+}
+
+func (d *dashboard) height(c ibc.Chain) (uint64, string) {
+	ctx, cancel := context.WithTimeout(d.ctx, heightTimeout)
+	defer cancel()
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return 0, err.Error()
+	}
+	return height, ""
+}
+
+// channels reads the IBC channels from logs.json, which is rewritten whenever paths change.
+func (d *dashboard) channels() []types.IBCChannel {
+	bz, err := os.ReadFile(filepath.Join(d.installDir, "configs", "logs.json"))
+	if err != nil {
+		return []types.IBCChannel{}
+	}
+
+	var logs types.MainLogs
+	if err := json.Unmarshal(bz, &logs); err != nil || logs.Channels == nil {
+		return []types.IBCChannel{}
+	}
+	return logs.Channels
+}
+
+func (d *dashboard) relayerStatus() DashboardRelayer {
+	status := DashboardRelayer{Paths: []string{}}
+	if d.relayer == nil {
+		return status
+	}
+
+	config := d.config.Config()
+	img := config.Relayer.DockerImage
+	status.Name = relayerContainer
+	status.Image = img.Repository + ":" + img.Version
+	if r, ok := d.relayer.(interface{ IsRunning() bool }); ok {
+		status.Running = r.IsRunning()
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range config.Chains {
+		for _, p := range c.IBCPaths {
+			if !seen[p] {
+				seen[p] = true
+				status.Paths = append(status.Paths, p)
+			}
+		}
+	}
+	sort.Strings(status.Paths)
+	return status
+}
+
+// GetLogs returns the end of a container's output as text. The tail query parameter sets the number of lines.
+func (d *dashboard) GetLogs(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["container"]
+
+	tail := defaultLogTail
+	if t := r.URL.Query().Get("tail"); t != "" {
+		n, err := strconv.Atoi(t)
+		if err != nil || n < 0 {
+			util.WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: APIError{
+				Code:    ErrCodeInvalidRequest,
+				Message: fmt.Sprintf("invalid tail %q", t),
+			}})
+			return
+		}
+		tail = n
+	}
+
+	container, ok := d.container(name)
+	if !ok {
+		util.WriteJSON(w, http.StatusNotFound, ErrorResponse{Error: APIError{
+			Code:    ErrCodeChainNotFound,
+			Message: fmt.Sprintf("container '%s' not found", name),
+		}})
+		return
+	}
+
+	logs, err := container.Logs(d.ctx, tail)
+	if err != nil {
+		util.WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: APIError{Code: ErrCodeChainError, Message: err.Error()}})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	util.Write(w, logs)
+}
+
+func (d *dashboard) container(name string) (logReader, bool) {
+	if name == relayerContainer {
+		lr, ok := d.relayer.(logReader)
+		return lr, ok
+	}
+
+	for _, c := range d.cc {
+		for _, n := range c.Nodes() {
+			if n.Name() == name {
+				return n, true
+			}
+		}
+	}
+	for _, c := range d.eth {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
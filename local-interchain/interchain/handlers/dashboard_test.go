@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// runningRelayer reports whether it runs, calling any other method panics.
+type runningRelayer struct {
+	ibc.Relayer
+	running bool
+}
+
+func (r runningRelayer) IsRunning() bool { return r.running }
+
+func getStatus(t *testing.T, d *dashboard) DashboardStatus {
+	t.Helper()
+	w := httptest.NewRecorder()
+	d.GetStatus(w, httptest.NewRequest(http.MethodGet, "/dashboard/status", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var status DashboardStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	return status
+}
+
+func TestDashboard_GetStatus(t *testing.T) {
+	installDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(installDir, "configs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(installDir, "configs", "logs.json"), []byte(`{
+		"start_time": 1,
+		"chains": [],
+		"ibc_channels": [{"chain_id": "localjuno-1", "path": "juno-osmo", "channel": {"channel_id": "channel-0", "port_id": "transfer", "state": "STATE_OPEN"}}]
+	}`), 0644))
+
+	config := &testConfig{
+		Chains: []types.Chain{
+			{ChainID: "localjuno-1", IBCPaths: []string{"juno-osmo", "juno-gaia"}},
+			{ChainID: "localosmo-1", IBCPaths: []string{"juno-osmo"}},
+			{ChainID: "localgaia-1", IBCPaths: []string{"juno-gaia"}},
+		},
+		Relayer: types.Relayer{DockerImage: types.DockerImage{Repository: "ghcr.io/cosmos/relayer", Version: "v2.5.0"}},
+	}
+
+	d := NewDashboard(context.Background(), config, installDir, nil, nil, runningRelayer{running: true})
+	status := getStatus(t, d)
+
+	require.Empty(t, status.Chains)
+	require.Len(t, status.Channels, 1)
+	require.Equal(t, "juno-osmo", status.Channels[0].Path)
+	require.Equal(t, "channel-0", status.Channels[0].Channel.ChannelID)
+	require.Equal(t, DashboardRelayer{
+		Name:    relayerContainer,
+		Image:   "ghcr.io/cosmos/relayer:v2.5.0",
+		Running: true,
+		Paths:   []string{"juno-gaia", "juno-osmo"},
+	}, status.Relayer)
+
+	// Paths removed from the config are no longer shown.
+	config.Chains = config.Chains[:2]
+	config.Chains[0].IBCPaths = []string{"juno-osmo"}
+	require.Equal(t, []string{"juno-osmo"}, getStatus(t, d).Relayer.Paths)
+}
+
+func TestDashboard_GetStatus_Empty(t *testing.T) {
+	// Without logs.json or a relayer, lists are empty rather than null for the web UI.
+	d := NewDashboard(context.Background(), &testConfig{}, t.TempDir(), nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	d.GetStatus(w, httptest.NewRequest(http.MethodGet, "/dashboard/status", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"chains":[],"ibc_channels":[],"relayer":{"running":false,"paths":[]}}`, w.Body.String())
+}
+
+func TestDashboard_GetDashboard(t *testing.T) {
+	d := NewDashboard(context.Background(), &testConfig{}, t.TempDir(), nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	d.GetDashboard(w, httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "<title>Local Interchain</title>")
+	// API data is only added to the page as text.
+	require.NotContains(t, w.Body.String(), "innerHTML")
+}
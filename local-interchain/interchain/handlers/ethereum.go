@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// getEthereumInfo answers the /info requests which make sense for an anvil chain.
+func (i *info) getEthereumInfo(w http.ResponseWriter, request string, eth *ethereum.EthereumChain) {
+	switch request {
+	case "logs":
+		get_logs(w, nil, i)
+	case "config":
+		jsonRes, err := MarshalIBCChainConfig(eth.Config())
+		if err != nil {
+			util.WriteError(w, fmt.Errorf("failed to marshal config: %w", err))
+			return
+		}
+		util.Write(w, jsonRes)
+	case "name":
+		util.Write(w, []byte(eth.Name()))
+	case "hostname":
+		util.Write(w, []byte(eth.HostName()))
+	case "home_dir":
+		util.Write(w, []byte(eth.HomeDir()))
+	case "rpc_address":
+		util.Write(w, []byte(eth.GetHostRPCAddress()))
+	case "height":
+		height, err := eth.Height(i.ctx)
+		if err != nil {
+			util.WriteError(w, err)
+			return
+		}
+		util.Write(w, []byte(strconv.FormatUint(height, 10)))
+	default:
+		util.WriteError(w, fmt.Errorf("invalid get param: %s. does not exist for ethereum chains", request))
+	}
+}
+
+// castQueries are the cast subcommands which only read the chain, or convert values.
+var castQueries = map[string]bool{
+	"abi-decode": true, "abi-encode": true, "age": true, "balance": true, "basefee": true, "block": true,
+	"block-number": true, "calldata": true, "call": true, "chain-id": true, "code": true, "codesize": true,
+	"estimate": true, "find-block": true, "from-wei": true, "gas-price": true, "keccak": true, "logs": true,
+	"nonce": true, "proof": true, "receipt": true, "sig": true, "storage": true, "to-dec": true, "to-hex": true,
+	"to-wei": true, "tx": true,
+}
+
+func isCastQuery(cmd string) bool {
+	fields := strings.Fields(cmd)
+	return len(fields) > 0 && castQueries[fields[0]]
+}
+
+// postEthereumActions runs foundry commands against an anvil chain.
+// Queries are cast subcommands, e.g. "balance 0x...", sent to the chain's RPC.
+func (a *actions) postEthereumActions(w http.ResponseWriter, r *http.Request, ah ActionHandler, eth *ethereum.EthereumChain) {
+	if isReadOnly(r.Context()) && !isCastQuery(ah.Cmd) {
+		util.WriteJSON(w, http.StatusForbidden, ErrorResponse{Error: APIError{
+			Code:    ErrCodeReadOnly,
+			Message: fmt.Sprintf("query %q is disabled, the server is read-only", ah.Cmd),
+		}})
+		return
+	}
+
+	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%RPC%", eth.GetRPCAddress())
+	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%CHAIN_ID%", ah.ChainId)
+	ah.Cmd = strings.ReplaceAll(ah.Cmd, "%HOME%", eth.HomeDir())
+
+	cmd := strings.Fields(ah.Cmd)
+
+	var stdout, stderr []byte
+	var err error
+
+	switch ah.Action {
+	case "q", "query":
+		cmd = append(append([]string{"cast"}, cmd...), "--rpc-url", eth.GetRPCAddress())
+		stdout, stderr, err = eth.Exec(a.ctx, cmd, nil)
+	case "e", "exec", "execute":
+		stdout, stderr, err = eth.Exec(a.ctx, cmd, nil)
+	default:
+		util.WriteError(w, fmt.Errorf("action '%s' is not supported for ethereum chains", ah.Action))
+		return
+	}
+
+	var output []byte
+	if len(stdout) > 0 {
+		output = stdout
+	} else if len(stderr) > 0 {
+		output = stderr
+	} else if err == nil {
+		output = []byte("{}")
+	} else {
+		output = []byte(err.Error())
+	}
+
+	util.Write(w, output)
+}
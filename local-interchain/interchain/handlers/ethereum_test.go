@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEthereumActions_ReadOnly(t *testing.T) {
+	a := &actions{ctx: context.Background()}
+
+	// The chain is never used, since the queries are refused before they run.
+	for _, cmd := range []string{"send 0x0 --value 1", "rpc anvil_setBalance 0x0 0x1", "--rpc-url x send", ""} {
+		t.Run(cmd, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r = r.WithContext(WithReadOnly(r.Context()))
+			w := httptest.NewRecorder()
+			a.postEthereumActions(w, r, ActionHandler{ChainId: "localeth-1", Action: "query", Cmd: cmd}, nil)
+
+			require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+			var res ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			require.Equal(t, ErrCodeReadOnly, res.Error.Code)
+		})
+	}
+
+	require.True(t, isCastQuery("balance 0x0"))
+	require.True(t, isCastQuery("  block-number"))
+	require.False(t, isCastQuery("send 0x0"))
+}
@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/gorilla/websocket"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// Types of EventMessage.
+const (
+	EventTypeBlock  = "block"
+	EventTypeTx     = "tx"
+	EventTypeEvent  = "event"
+	EventTypePacket = "packet"
+)
+
+// packetEventTypes are the ICS-04 events streamed as EventTypePacket messages.
+// Everything except send_packet is the result of a relayer submitting a packet or acknowledgement.
+var packetEventTypes = map[string]bool{
+	"send_packet":           true,
+	"recv_packet":           true,
+	"write_acknowledgement": true,
+	"acknowledge_packet":    true,
+	"timeout_packet":        true,
+}
+
+const (
+	eventPollInterval = 500 * time.Millisecond
+
+	// subscriberBuffer is the number of messages queued for a client before it is disconnected as too slow.
+	subscriberBuffer = 512
+)
+
+// Attribute is a key value pair of an ABCI event.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// EventMessage is a single message sent to /ws/events subscribers. Fields are set depending on Type.
+type EventMessage struct {
+	Type    string `json:"type"`
+	ChainID string `json:"chain_id"`
+	Height  int64  `json:"height"`
+
+	// block
+	Time   *time.Time `json:"time,omitempty"`
+	NumTxs int        `json:"num_txs,omitempty"`
+
+	// tx, event and packet
+	TxHash string `json:"tx_hash,omitempty"`
+
+	// tx
+	Code    uint32         `json:"code,omitempty"`
+	GasUsed int64          `json:"gas_used,omitempty"`
+	Events  []EventMessage `json:"events,omitempty"`
+
+	// event and packet
+	EventType       string      `json:"event_type,omitempty"`
+	Attributes      []Attribute `json:"attributes,omitempty"`
+	ContractAddress string      `json:"contract_address,omitempty"`
+
+	// packet
+	Sequence   string `json:"sequence,omitempty"`
+	SrcPort    string `json:"src_port,omitempty"`
+	SrcChannel string `json:"src_channel,omitempty"`
+	DstPort    string `json:"dst_port,omitempty"`
+	DstChannel string `json:"dst_channel,omitempty"`
+}
+
+// Attribute returns the value of the first attribute with key.
+func (m EventMessage) Attribute(key string) (string, bool) {
+	for _, a := range m.Attributes {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// EventFilter selects the messages sent to a subscriber. Empty fields match everything.
+type EventFilter struct {
+	ChainIDs []string `json:"chain_ids,omitempty"`
+	// Types are EventMessage types: block, tx, event or packet.
+	Types []string `json:"types,omitempty"`
+	// EventTypes are ABCI event types such as wasm or recv_packet. They do not apply to block messages
+	// and a tx matches when any of its events do.
+	EventTypes []string `json:"event_types,omitempty"`
+	// ContractAddress only applies to event messages, and to txs which emitted an event for the contract.
+	ContractAddress string `json:"contract_address,omitempty"`
+}
+
+// eventFilterFromQuery reads a filter from repeated chain_id, type and event_type parameters and contract_address.
+func eventFilterFromQuery(r *http.Request) EventFilter {
+	q := r.URL.Query()
+	return EventFilter{
+		ChainIDs:        q["chain_id"],
+		Types:           q["type"],
+		EventTypes:      q["event_type"],
+		ContractAddress: q.Get("contract_address"),
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether m should be sent to a subscriber with the filter.
+func (f EventFilter) Match(m EventMessage) bool {
+	if len(f.ChainIDs) > 0 && !contains(f.ChainIDs, m.ChainID) {
+		return false
+	}
+	if len(f.Types) > 0 && !contains(f.Types, m.Type) {
+		return false
+	}
+
+	switch m.Type {
+	case EventTypeTx:
+		if len(f.EventTypes) == 0 && f.ContractAddress == "" {
+			return true
+		}
+		for _, e := range m.Events {
+			if f.matchEvent(e) {
+				return true
+			}
+		}
+		return false
+	case EventTypeEvent, EventTypePacket:
+		return f.matchEvent(m)
+	default:
+		return true
+	}
+}
+
+func (f EventFilter) matchEvent(e EventMessage) bool {
+	if len(f.EventTypes) > 0 && !contains(f.EventTypes, e.EventType) {
+		return false
+	}
+	if f.ContractAddress != "" && e.ContractAddress != f.ContractAddress {
+		return false
+	}
+	return true
+}
+
+type subscriber struct {
+	mu     sync.Mutex
+	filter EventFilter
+	send   chan EventMessage
+}
+
+func (s *subscriber) setFilter(f EventFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filter = f
+}
+
+func (s *subscriber) match(m EventMessage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filter.Match(m)
+}
+
+type events struct {
+	ctx context.Context
+
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	pollers     map[string]context.CancelFunc
+}
+
+// NewEvents polls every chain for new blocks and streams them to websocket subscribers until ctx is done.
+func NewEvents(ctx context.Context, vals map[string]*cosmos.ChainNode) *events {
+	e := &events{
+		ctx: ctx,
+		upgrader: websocket.Upgrader{
+			// The API is meant for local tooling, browsers on any origin may subscribe.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		subscribers: make(map[*subscriber]struct{}),
+		pollers:     make(map[string]context.CancelFunc),
+	}
+
+	for chainID, val := range vals {
+		e.AddChain(chainID, val)
+	}
+
+	return e
+}
+
+// AddChain starts streaming the blocks of a chain added after the server started.
+func (e *events) AddChain(chainID string, val *cosmos.ChainNode) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.pollers[chainID]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.pollers[chainID] = cancel
+	go e.poll(ctx, chainID, val)
+}
+
+// RemoveChain stops streaming the blocks of a chain.
+func (e *events) RemoveChain(chainID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if cancel, ok := e.pollers[chainID]; ok {
+		cancel()
+		delete(e.pollers, chainID)
+	}
+}
+
+func (e *events) hasChain(chainID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.pollers[chainID]
+	return ok
+}
+
+// GetEvents upgrades the request to a websocket which receives EventMessages as JSON.
+// The initial filter is read from the query string, clients may replace it by sending an EventFilter.
+func (e *events) GetEvents(w http.ResponseWriter, r *http.Request) {
+	filter := eventFilterFromQuery(r)
+	for _, chainID := range filter.ChainIDs {
+		if !e.hasChain(chainID) {
+			util.WriteJSON(w, http.StatusNotFound, ErrorResponse{Error: APIError{
+				Code:    ErrCodeChainNotFound,
+				Message: fmt.Sprintf("chain_id '%s' not found", chainID),
+			}})
+			return
+		}
+	}
+
+	conn, err := e.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with an error.
+		return
+	}
+	defer conn.Close()
+
+	sub := &subscriber{filter: filter, send: make(chan EventMessage, subscriberBuffer)}
+	e.subscribe(sub)
+	defer e.unsubscribe(sub)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var f EventFilter
+			if err := conn.ReadJSON(&f); err != nil {
+				var syntaxErr *json.SyntaxError
+				var typeErr *json.UnmarshalTypeError
+				if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+					continue
+				}
+				return
+			}
+			sub.setFilter(f)
+		}
+	}()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-done:
+			return
+		case msg, ok := <-sub.send:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "client too slow"), time.Now().Add(time.Second))
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *events) subscribe(s *subscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.subscribers[s] = struct{}{}
+}
+
+func (e *events) unsubscribe(s *subscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.subscribers[s]; ok {
+		delete(e.subscribers, s)
+		close(s.send)
+	}
+}
+
+func (e *events) hasSubscribers() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.subscribers) > 0
+}
+
+func (e *events) publish(msgs []EventMessage) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for s := range e.subscribers {
+		for _, m := range msgs {
+			if !s.match(m) {
+				continue
+			}
+			select {
+			case s.send <- m:
+			default:
+				// Closing send tells the writer to disconnect the client.
+				delete(e.subscribers, s)
+				close(s.send)
+			}
+			if _, ok := e.subscribers[s]; !ok {
+				break
+			}
+		}
+	}
+}
+
+// poll publishes the blocks produced by the chain after the server started, until ctx is done.
+func (e *events) poll(ctx context.Context, chainID string, val *cosmos.ChainNode) {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	var last int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		status, err := val.Client.Status(ctx)
+		if err != nil {
+			// The chain may be restarting, try again on the next tick.
+			continue
+		}
+		latest := status.SyncInfo.LatestBlockHeight
+		if last == 0 || !e.hasSubscribers() {
+			last = latest
+			continue
+		}
+
+		for h := last + 1; h <= latest; h++ {
+			msgs, err := blockMessages(ctx, chainID, val, h)
+			if err != nil {
+				log.Default().Printf("events: %s block %d: %v\n", chainID, h, err)
+				break
+			}
+			e.publish(msgs)
+			last = h
+		}
+	}
+}
+
+// blockMessages fetches the block at height and converts it to a block message followed by a tx message
+// and the event or packet messages of each transaction.
+func blockMessages(ctx context.Context, chainID string, val *cosmos.ChainNode, height int64) ([]EventMessage, error) {
+	block, err := val.Client.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("get block: %w", err)
+	}
+	results, err := val.Client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("get block results: %w", err)
+	}
+	return BlockToEventMessages(chainID, block, results), nil
+}
+
+// BlockToEventMessages converts a block and its results to the messages streamed to subscribers.
+func BlockToEventMessages(chainID string, block *coretypes.ResultBlock, results *coretypes.ResultBlockResults) []EventMessage {
+	t := block.Block.Time
+	msgs := []EventMessage{{
+		Type:    EventTypeBlock,
+		ChainID: chainID,
+		Height:  block.Block.Height,
+		Time:    &t,
+		NumTxs:  len(block.Block.Txs),
+	}}
+
+	for i, tx := range block.Block.Txs {
+		if i >= len(results.TxsResults) {
+			break
+		}
+		res := results.TxsResults[i]
+		txHash := fmt.Sprintf("%X", tx.Hash())
+
+		events := make([]EventMessage, len(res.Events))
+		for j, ev := range res.Events {
+			events[j] = abciEventMessage(chainID, block.Block.Height, txHash, ev)
+		}
+
+		msgs = append(msgs, EventMessage{
+			Type:    EventTypeTx,
+			ChainID: chainID,
+			Height:  block.Block.Height,
+			TxHash:  txHash,
+			Code:    res.Code,
+			GasUsed: res.GasUsed,
+			Events:  events,
+		})
+		msgs = append(msgs, events...)
+	}
+
+	return msgs
+}
+
+func abciEventMessage(chainID string, height int64, txHash string, ev abcitypes.Event) EventMessage {
+	m := EventMessage{
+		Type:       EventTypeEvent,
+		ChainID:    chainID,
+		Height:     height,
+		TxHash:     txHash,
+		EventType:  ev.Type,
+		Attributes: make([]Attribute, len(ev.Attributes)),
+	}
+	for i, a := range ev.Attributes {
+		m.Attributes[i] = Attribute{Key: a.Key, Value: a.Value}
+	}
+
+	if addr, ok := m.Attribute("_contract_address"); ok {
+		m.ContractAddress = addr
+	} else if addr, ok := m.Attribute("contract_address"); ok {
+		m.ContractAddress = addr
+	}
+
+	if packetEventTypes[ev.Type] {
+		m.Type = EventTypePacket
+		m.Sequence, _ = m.Attribute("packet_sequence")
+		m.SrcPort, _ = m.Attribute("packet_src_port")
+		m.SrcChannel, _ = m.Attribute("packet_src_channel")
+		m.DstPort, _ = m.Attribute("packet_dst_port")
+		m.DstChannel, _ = m.Attribute("packet_dst_channel")
+	}
+
+	return m
+}
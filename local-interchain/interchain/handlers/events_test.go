@@ -0,0 +1,125 @@
+package handlers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+func TestEventFilter_Match(t *testing.T) {
+	block := handlers.EventMessage{Type: handlers.EventTypeBlock, ChainID: "localjuno-1", Height: 5}
+	wasm := handlers.EventMessage{Type: handlers.EventTypeEvent, ChainID: "localjuno-1", EventType: "wasm", ContractAddress: "juno1contract"}
+	transfer := handlers.EventMessage{Type: handlers.EventTypeEvent, ChainID: "localjuno-1", EventType: "transfer"}
+	recv := handlers.EventMessage{Type: handlers.EventTypePacket, ChainID: "localjuno-1", EventType: "recv_packet"}
+	wasmTx := handlers.EventMessage{Type: handlers.EventTypeTx, ChainID: "localjuno-1", Events: []handlers.EventMessage{transfer, wasm}}
+	bankTx := handlers.EventMessage{Type: handlers.EventTypeTx, ChainID: "localjuno-1", Events: []handlers.EventMessage{transfer}}
+
+	for _, tt := range []struct {
+		name   string
+		filter handlers.EventFilter
+		msg    handlers.EventMessage
+		want   bool
+	}{
+		{"empty filter", handlers.EventFilter{}, block, true},
+		{"chain id", handlers.EventFilter{ChainIDs: []string{"localosmo-1", "localjuno-1"}}, block, true},
+		{"other chain id", handlers.EventFilter{ChainIDs: []string{"localosmo-1"}}, block, false},
+		{"type", handlers.EventFilter{Types: []string{handlers.EventTypeBlock}}, block, true},
+		{"other type", handlers.EventFilter{Types: []string{handlers.EventTypeTx}}, block, false},
+		{"event types do not apply to blocks", handlers.EventFilter{EventTypes: []string{"wasm"}}, block, true},
+		{"contract does not apply to blocks", handlers.EventFilter{ContractAddress: "juno1other"}, block, true},
+		{"event type", handlers.EventFilter{EventTypes: []string{"wasm"}}, wasm, true},
+		{"other event type", handlers.EventFilter{EventTypes: []string{"wasm"}}, transfer, false},
+		{"packet event type", handlers.EventFilter{EventTypes: []string{"recv_packet"}}, recv, true},
+		{"packet type", handlers.EventFilter{Types: []string{handlers.EventTypeEvent}}, recv, false},
+		{"contract", handlers.EventFilter{ContractAddress: "juno1contract"}, wasm, true},
+		{"other contract", handlers.EventFilter{ContractAddress: "juno1other"}, wasm, false},
+		{"event without contract", handlers.EventFilter{ContractAddress: "juno1contract"}, transfer, false},
+		{"tx", handlers.EventFilter{Types: []string{handlers.EventTypeTx}}, bankTx, true},
+		{"tx with any event type", handlers.EventFilter{EventTypes: []string{"wasm"}}, wasmTx, true},
+		{"tx without event type", handlers.EventFilter{EventTypes: []string{"wasm"}}, bankTx, false},
+		{"tx with contract", handlers.EventFilter{ContractAddress: "juno1contract"}, wasmTx, true},
+		{"tx without contract", handlers.EventFilter{ContractAddress: "juno1contract"}, bankTx, false},
+		{"tx event type and contract on different events", handlers.EventFilter{EventTypes: []string{"transfer"}, ContractAddress: "juno1contract"}, wasmTx, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Match(tt.msg))
+		})
+	}
+}
+
+func TestBlockToEventMessages(t *testing.T) {
+	blockTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	txs := cmttypes.Txs{cmttypes.Tx("wasm tx"), cmttypes.Tx("ibc tx"), cmttypes.Tx("no results")}
+	block := &coretypes.ResultBlock{Block: &cmttypes.Block{
+		Header: cmttypes.Header{Height: 42, Time: blockTime},
+		Data:   cmttypes.Data{Txs: txs},
+	}}
+	results := &coretypes.ResultBlockResults{
+		Height: 42,
+		TxsResults: []*abcitypes.ResponseDeliverTx{
+			{Code: 0, GasUsed: 100, Events: []abcitypes.Event{{
+				Type:       "wasm",
+				Attributes: []abcitypes.EventAttribute{{Key: "_contract_address", Value: "juno1contract"}, {Key: "action", Value: "increment"}},
+			}}},
+			{Code: 5, GasUsed: 200, Events: []abcitypes.Event{{
+				Type: "recv_packet",
+				Attributes: []abcitypes.EventAttribute{
+					{Key: "packet_sequence", Value: "7"},
+					{Key: "packet_src_port", Value: "transfer"},
+					{Key: "packet_src_channel", Value: "channel-0"},
+					{Key: "packet_dst_port", Value: "transfer"},
+					{Key: "packet_dst_channel", Value: "channel-1"},
+				},
+			}}},
+		},
+	}
+
+	msgs := handlers.BlockToEventMessages("localjuno-1", block, results)
+
+	// The block, then each tx with results followed by its events.
+	require.Len(t, msgs, 5)
+	for _, m := range msgs {
+		require.Equal(t, "localjuno-1", m.ChainID)
+		require.EqualValues(t, 42, m.Height)
+	}
+
+	require.Equal(t, handlers.EventTypeBlock, msgs[0].Type)
+	require.Equal(t, blockTime, *msgs[0].Time)
+	require.Equal(t, 3, msgs[0].NumTxs)
+
+	wasmHash := fmt.Sprintf("%X", txs[0].Hash())
+	require.Equal(t, handlers.EventTypeTx, msgs[1].Type)
+	require.Equal(t, wasmHash, msgs[1].TxHash)
+	require.EqualValues(t, 100, msgs[1].GasUsed)
+	require.Equal(t, []handlers.EventMessage{msgs[2]}, msgs[1].Events)
+
+	require.Equal(t, handlers.EventTypeEvent, msgs[2].Type)
+	require.Equal(t, wasmHash, msgs[2].TxHash)
+	require.Equal(t, "wasm", msgs[2].EventType)
+	require.Equal(t, "juno1contract", msgs[2].ContractAddress)
+	action, ok := msgs[2].Attribute("action")
+	require.True(t, ok)
+	require.Equal(t, "increment", action)
+
+	ibcHash := fmt.Sprintf("%X", txs[1].Hash())
+	require.Equal(t, handlers.EventTypeTx, msgs[3].Type)
+	require.Equal(t, ibcHash, msgs[3].TxHash)
+	require.EqualValues(t, 5, msgs[3].Code)
+
+	require.Equal(t, handlers.EventTypePacket, msgs[4].Type)
+	require.Equal(t, ibcHash, msgs[4].TxHash)
+	require.Equal(t, "recv_packet", msgs[4].EventType)
+	require.Empty(t, msgs[4].ContractAddress)
+	require.Equal(t, "7", msgs[4].Sequence)
+	require.Equal(t, "transfer", msgs[4].SrcPort)
+	require.Equal(t, "channel-0", msgs[4].SrcChannel)
+	require.Equal(t, "transfer", msgs[4].DstPort)
+	require.Equal(t, "channel-1", msgs[4].DstChannel)
+}
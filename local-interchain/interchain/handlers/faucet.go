@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// Error codes returned by the faucet in ErrorResponse, next to the v1 ones.
+const (
+	ErrCodeFaucetDisabled = "faucet_disabled"
+	ErrCodeRateLimited    = "rate_limited"
+)
+
+const tokenFactoryPrefix = "factory/"
+
+var ethAddress = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+type faucet struct {
+	ctx    context.Context
+	config ConfigSource
+	cc     map[string]*cosmos.CosmosChain
+	eth    map[string]*ethereum.EthereumChain
+
+	mu sync.Mutex
+	// nextSend is when each chain_id/address funded recently, or being funded, can request again.
+	nextSend map[string]time.Time
+}
+
+type FaucetRequest struct {
+	ChainID string `json:"chain_id"`
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	// Denom defaults to the chain's native denom. Denoms starting with factory/ are minted.
+	Denom string `json:"denom,omitempty"`
+}
+
+type FaucetResponse struct {
+	ChainID string `json:"chain_id"`
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	Denom   string `json:"denom"`
+	TxHash  string `json:"tx_hash,omitempty"`
+}
+
+func NewFaucet(
+	ctx context.Context,
+	config ConfigSource,
+	cosmosChains map[string]*cosmos.CosmosChain,
+	ethChains map[string]*ethereum.EthereumChain,
+) *faucet {
+	return &faucet{
+		ctx:      ctx,
+		config:   config,
+		cc:       cosmosChains,
+		eth:      ethChains,
+		nextSend: make(map[string]time.Time),
+	}
+}
+
+func writeFaucetError(w http.ResponseWriter, status int, code, msg string) {
+	util.WriteJSON(w, status, ErrorResponse{Error: APIError{Code: code, Message: msg}})
+}
+
+func (f *faucet) PostFaucet(w http.ResponseWriter, r *http.Request) {
+	var req FaucetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFaucetError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	cfg, ok := f.chainConfig(req.ChainID)
+	if !ok {
+		writeFaucetError(w, http.StatusNotFound, ErrCodeChainNotFound, fmt.Sprintf("chain_id '%s' not found", req.ChainID))
+		return
+	}
+	if cfg.Faucet.Disabled {
+		writeFaucetError(w, http.StatusForbidden, ErrCodeFaucetDisabled, fmt.Sprintf("the faucet is disabled for %s", req.ChainID))
+		return
+	}
+	if req.Denom == "" {
+		req.Denom = cfg.Denom
+	}
+
+	amount, err := faucetAmount(req.Amount, cfg.Faucet.MaxAmount)
+	if err != nil {
+		writeFaucetError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	cooldown, err := cfg.Faucet.CooldownDuration()
+	if err != nil {
+		writeFaucetError(w, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("invalid faucet cooldown for %s: %s", req.ChainID, err))
+		return
+	}
+
+	var send func() (string, error)
+	switch {
+	case f.cc[req.ChainID] != nil:
+		send, err = f.cosmosSend(f.cc[req.ChainID], cfg, req, amount)
+	case f.eth[req.ChainID] != nil:
+		send, err = f.ethSend(f.eth[req.ChainID], cfg, req, amount)
+	default:
+		err = fmt.Errorf("chain_id '%s' is not running", req.ChainID)
+	}
+	if err != nil {
+		writeFaucetError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	key := req.ChainID + "/" + req.Address
+	if wait := f.reserve(key, cooldown); wait > 0 {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", wait.Seconds()))
+		writeFaucetError(w, http.StatusTooManyRequests, ErrCodeRateLimited,
+			fmt.Sprintf("%s was funded recently, try again in %s", req.Address, wait.Round(time.Second)))
+		return
+	}
+
+	txHash, err := send()
+	if err != nil {
+		f.release(key)
+		writeFaucetError(w, http.StatusInternalServerError, ErrCodeChainError, err.Error())
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, FaucetResponse{
+		ChainID: req.ChainID,
+		Address: req.Address,
+		Amount:  amount.String(),
+		Denom:   req.Denom,
+		TxHash:  txHash,
+	})
+}
+
+func (f *faucet) chainConfig(chainID string) (types.Chain, bool) {
+	for _, c := range f.config.Config().Chains {
+		if c.ChainID == chainID {
+			return c, true
+		}
+	}
+	return types.Chain{}, false
+}
+
+// faucetAmount parses a requested amount and checks it is positive and at most max.
+func faucetAmount(amount, max string) (math.Int, error) {
+	amt, ok := math.NewIntFromString(amount)
+	if !ok || !amt.IsPositive() {
+		return math.Int{}, fmt.Errorf("amount must be a positive integer, got %q", amount)
+	}
+
+	maxAmt, ok := math.NewIntFromString(max)
+	if !ok {
+		return math.Int{}, fmt.Errorf("invalid faucet max_amount %q", max)
+	}
+	if amt.GT(maxAmt) {
+		return math.Int{}, fmt.Errorf("amount %s is more than the faucet's max of %s", amt, maxAmt)
+	}
+	return amt, nil
+}
+
+// cosmosSend validates a request for a cosmos chain and returns the function which sends the funds.
+// Tokenfactory denoms are minted to the address by the faucet key, which must be the denom's admin.
+func (f *faucet) cosmosSend(c *cosmos.CosmosChain, cfg types.Chain, req FaucetRequest, amount math.Int) (func() (string, error), error) {
+	if !strings.HasPrefix(req.Address, cfg.Bech32Prefix+"1") {
+		return nil, fmt.Errorf("address %q does not have the %s prefix", req.Address, cfg.Bech32Prefix)
+	}
+
+	keyName := cfg.Faucet.KeyName
+	if strings.HasPrefix(req.Denom, tokenFactoryPrefix) {
+		if !amount.IsUint64() {
+			return nil, fmt.Errorf("amount %s is too large to mint", amount)
+		}
+		return func() (string, error) {
+			return cosmos.TokenFactoryMintDenomTo(c, f.ctx, keyName, req.Denom, amount.Uint64(), req.Address)
+		}, nil
+	}
+
+	return func() (string, error) {
+		return "", c.SendFunds(f.ctx, keyName, ibc.WalletAmount{
+			Address: req.Address,
+			Denom:   req.Denom,
+			Amount:  amount,
+		})
+	}, nil
+}
+
+// ethSend validates a request for an ethereum chain and returns the function which sends the wei.
+func (f *faucet) ethSend(c *ethereum.EthereumChain, cfg types.Chain, req FaucetRequest, amount math.Int) (func() (string, error), error) {
+	if !ethAddress.MatchString(req.Address) {
+		return nil, fmt.Errorf("address %q is not an ethereum address", req.Address)
+	}
+	if req.Denom != cfg.Denom {
+		return nil, fmt.Errorf("ethereum chains only fund %s, got %q", cfg.Denom, req.Denom)
+	}
+
+	return func() (string, error) {
+		return "", c.SendFunds(f.ctx, cfg.Faucet.KeyName, ibc.WalletAmount{
+			Address: req.Address,
+			Denom:   req.Denom,
+			Amount:  amount,
+		})
+	}, nil
+}
+
+// reserve marks key as funded now unless it was funded within its cooldown, in which case it returns how long is left.
+// Keys whose cooldown is over are dropped.
+func (f *faucet) reserve(key string, cooldown time.Duration) time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for k, next := range f.nextSend {
+		if !now.Before(next) {
+			delete(f.nextSend, k)
+		}
+	}
+
+	if next, ok := f.nextSend[key]; ok {
+		return next.Sub(now)
+	}
+	f.nextSend[key] = now.Add(cooldown)
+	return 0
+}
+
+// release lets key request again after a failed send.
+func (f *faucet) release(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.nextSend, key)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// testConfig is the config of chains which do not change.
+type testConfig types.Config
+
+func (c *testConfig) Config() *types.Config {
+	return (*types.Config)(c)
+}
+
+func TestFaucetAmount(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		amount  string
+		max     string
+		want    string
+		wantErr string
+	}{
+		{"below max", "10", "100", "10", ""},
+		{"max", "100", "100", "100", ""},
+		{"above max", "101", "100", "", "amount 101 is more than the faucet's max of 100"},
+		{"above uint64", "100000000000000000000", "100000000000000000000", "100000000000000000000", ""},
+		{"zero", "0", "100", "", `amount must be a positive integer, got "0"`},
+		{"negative", "-1", "100", "", `amount must be a positive integer, got "-1"`},
+		{"not a number", "10stake", "100", "", `amount must be a positive integer, got "10stake"`},
+		{"empty", "", "100", "", `amount must be a positive integer, got ""`},
+		{"invalid max", "10", "lots", "", `invalid faucet max_amount "lots"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			amt, err := faucetAmount(tt.amount, tt.max)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, amt.String())
+		})
+	}
+}
+
+func TestFaucet_Reserve(t *testing.T) {
+	f := NewFaucet(context.Background(), &testConfig{}, nil, nil)
+
+	require.Zero(t, f.reserve("localjuno-1/juno1a", time.Hour))
+
+	wait := f.reserve("localjuno-1/juno1a", time.Hour)
+	require.Greater(t, wait, 59*time.Minute)
+	require.LessOrEqual(t, wait, time.Hour)
+
+	// Cooldowns are per chain and address.
+	require.Zero(t, f.reserve("localjuno-1/juno1b", time.Hour))
+	require.Zero(t, f.reserve("localosmo-1/juno1a", time.Hour))
+
+	// A failed send can be retried right away.
+	f.release("localjuno-1/juno1a")
+	require.Zero(t, f.reserve("localjuno-1/juno1a", time.Hour))
+
+	// Keys whose cooldown is over are dropped, while the others keep theirs.
+	require.Zero(t, f.reserve("localjuno-1/juno1c", time.Millisecond))
+	time.Sleep(2 * time.Millisecond)
+	require.Zero(t, f.reserve("localjuno-1/juno1d", time.Hour))
+	require.NotContains(t, f.nextSend, "localjuno-1/juno1c")
+	require.Len(t, f.nextSend, 4)
+	require.Positive(t, f.reserve("localjuno-1/juno1b", time.Millisecond))
+}
+
+func TestPostFaucet_Errors(t *testing.T) {
+	config := &testConfig{Chains: []types.Chain{
+		{ChainID: "localjuno-1", Denom: "ujuno", Faucet: types.Faucet{MaxAmount: "100", Cooldown: "1m"}},
+		{ChainID: "localosmo-1", Denom: "uosmo", Faucet: types.Faucet{Disabled: true}},
+		{ChainID: "localgaia-1", Denom: "uatom", Faucet: types.Faucet{MaxAmount: "100", Cooldown: "soon"}},
+	}}
+	f := NewFaucet(context.Background(), config, nil, nil)
+
+	for _, tt := range []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"invalid body", `{`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"unknown chain", `{"chain_id":"localstars-1","address":"stars1a","amount":"1"}`, http.StatusNotFound, ErrCodeChainNotFound},
+		{"disabled", `{"chain_id":"localosmo-1","address":"osmo1a","amount":"1"}`, http.StatusForbidden, ErrCodeFaucetDisabled},
+		{"above max", `{"chain_id":"localjuno-1","address":"juno1a","amount":"101"}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"invalid cooldown", `{"chain_id":"localgaia-1","address":"cosmos1a","amount":"1"}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"not running", `{"chain_id":"localjuno-1","address":"juno1a","amount":"1"}`, http.StatusBadRequest, ErrCodeInvalidRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			f.PostFaucet(w, httptest.NewRequest(http.MethodPost, "/faucet", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			var res ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			require.Equal(t, tt.code, res.Error.Code)
+		})
+	}
+
+	// Requests which are refused do not start a cooldown.
+	require.Empty(t, f.nextSend)
+}
@@ -12,13 +12,20 @@ import (
 
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	types "github.com/strangelove-ventures/localinterchain/interchain/types"
 	util "github.com/strangelove-ventures/localinterchain/interchain/util"
 )
 
+// ConfigSource returns the config of the running chains. Chains and IBC paths can be added and removed
+// while the API runs, so handlers get the config for each request.
+type ConfigSource interface {
+	Config() *types.Config
+}
+
 type info struct {
-	Config     *types.Config
+	Config     ConfigSource
 	InstallDir string
 
 	// used to get information about state of the container
@@ -28,17 +35,19 @@ type info struct {
 	relayer ibc.Relayer
 	eRep    ibc.RelayerExecReporter
 
-	cc map[string]*cosmos.CosmosChain
+	cc  map[string]*cosmos.CosmosChain
+	eth map[string]*ethereum.EthereumChain
 
 	chainId string
 }
 
 func NewInfo(
-	cfg *types.Config,
+	cfg ConfigSource,
 	installDir string,
 	ctx context.Context,
 	ic *interchaintest.Interchain,
 	cosmosChains map[string]*cosmos.CosmosChain,
+	ethChains map[string]*ethereum.EthereumChain,
 	vals map[string]*cosmos.ChainNode,
 	relayer ibc.Relayer,
 	eRep ibc.RelayerExecReporter,
@@ -51,6 +60,7 @@ func NewInfo(
 		ic:      ic,
 		vals:    vals,
 		cc:      cosmosChains,
+		eth:     ethChains,
 		relayer: relayer,
 		eRep:    eRep,
 	}
@@ -78,7 +88,16 @@ func (i *info) GetInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	i.chainId = chainId[0]
 
-	val := i.vals[i.chainId]
+	if eth, ok := i.eth[i.chainId]; ok {
+		i.getEthereumInfo(w, res[0], eth)
+		return
+	}
+
+	val, ok := i.vals[i.chainId]
+	if !ok {
+		util.WriteError(w, fmt.Errorf("chain_id '%s' not found", i.chainId))
+		return
+	}
 
 	switch res[0] {
 	case "logs":
@@ -174,10 +193,11 @@ func get_logs(w http.ResponseWriter, r *http.Request, i *info) {
 		return
 	}
 
+	config := i.Config.Config()
 	info := GetInfo{
 		Logs:   logs,
-		Chains: i.Config.Chains,
-		Relay:  i.Config.Relayer,
+		Chains: config.Chains,
+		Relay:  config.Relayer,
 	}
 
 	jsonRes, err := json.MarshalIndent(info, "", "  ")
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// Snapshotter saves the running environment under a name and returns where it was written.
+type Snapshotter interface {
+	Snapshot(ctx context.Context, name string) (string, error)
+}
+
+type snapshot struct {
+	ctx         context.Context
+	snapshotter Snapshotter
+}
+
+type SnapshotRequest struct {
+	Name string `json:"name"`
+}
+
+type SnapshotResponse struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+func NewSnapshot(ctx context.Context, snapshotter Snapshotter) *snapshot {
+	return &snapshot{
+		ctx:         ctx,
+		snapshotter: snapshotter,
+	}
+}
+
+func (s *snapshot) PostSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req SnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: APIError{Code: ErrCodeInvalidRequest, Message: err.Error()}})
+		return
+	}
+	if req.Name == "" {
+		util.WriteJSON(w, http.StatusBadRequest, ErrorResponse{Error: APIError{Code: ErrCodeInvalidRequest, Message: "name is required"}})
+		return
+	}
+
+	path, err := s.snapshotter.Snapshot(s.ctx, req.Name)
+	if err != nil {
+		util.WriteJSON(w, http.StatusInternalServerError, ErrorResponse{Error: APIError{
+			Code:    ErrCodeChainError,
+			Message: fmt.Sprintf("failed to snapshot %s: %s", req.Name, err),
+		}})
+		return
+	}
+
+	util.WriteJSON(w, http.StatusOK, SnapshotResponse{Name: req.Name, Path: path})
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// V1Chain is the subset of *cosmos.CosmosChain used by the v1 API.
+type V1Chain interface {
+	Config() ibc.ChainConfig
+	Height(ctx context.Context) (uint64, error)
+
+	SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error
+	AllBalances(ctx context.Context, address string) (sdk.Coins, error)
+
+	StoreContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error)
+	InstantiateContract(ctx context.Context, keyName string, codeID string, initMessage string, needsNoAdminFlag bool, extraExecTxArgs ...string) (string, error)
+	ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string, extraExecTxArgs ...string) (*sdk.TxResponse, error)
+	QueryContract(ctx context.Context, contractAddress string, query any, response any) error
+
+	SubmitProposal(ctx context.Context, keyName string, prop cosmos.TxProposalv1) (cosmos.TxProposal, error)
+	QueryProposal(ctx context.Context, proposalID string) (*cosmos.ProposalResponse, error)
+	VoteOnProposal(ctx context.Context, keyName string, proposalID string, vote string) error
+	VoteOnProposalAllValidators(ctx context.Context, proposalID string, vote string) error
+
+	BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error)
+	GetAddress(ctx context.Context, keyName string) ([]byte, error)
+
+	SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error)
+}
+
+var _ V1Chain = (*cosmos.CosmosChain)(nil)
+
+type v1 struct {
+	ctx    context.Context
+	chains map[string]V1Chain
+}
+
+// NewV1 serves the v1 API for chains keyed by chain id.
+func NewV1(ctx context.Context, chains map[string]V1Chain) *v1 {
+	return &v1{
+		ctx:    ctx,
+		chains: chains,
+	}
+}
+
+// apiError is an error with the status code and APIError code it is reported with.
+type apiError struct {
+	status int
+	code   string
+	err    error
+}
+
+func (e *apiError) Error() string { return e.err.Error() }
+
+func invalidRequest(format string, a ...any) error {
+	return &apiError{status: http.StatusBadRequest, code: ErrCodeInvalidRequest, err: fmt.Errorf(format, a...)}
+}
+
+func writeV1Error(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, code: ErrCodeChainError, err: err}
+	}
+	util.WriteJSON(w, apiErr.status, ErrorResponse{Error: APIError{Code: apiErr.code, Message: apiErr.Error()}})
+}
+
+// handle decodes the request body into req, when it is not nil, and writes the result of fn.
+func (v *v1) handle(w http.ResponseWriter, r *http.Request, req any, fn func(c V1Chain, vars map[string]string) (any, error)) {
+	vars := mux.Vars(r)
+
+	c, ok := v.chains[vars["chain_id"]]
+	if !ok {
+		util.WriteJSON(w, http.StatusNotFound, ErrorResponse{Error: APIError{
+			Code:    ErrCodeChainNotFound,
+			Message: fmt.Sprintf("chain_id '%s' not found", vars["chain_id"]),
+		}})
+		return
+	}
+
+	if req != nil {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(req); err != nil {
+			writeV1Error(w, invalidRequest("failed to decode json: %s", err))
+			return
+		}
+	}
+
+	res, err := fn(c, vars)
+	if err != nil {
+		writeV1Error(w, err)
+		return
+	}
+	util.WriteJSON(w, http.StatusOK, res)
+}
+
+func required(fields map[string]string) error {
+	var missing []string
+	for name, value := range fields {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return invalidRequest("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func parseAmount(amount string) (math.Int, error) {
+	a, ok := math.NewIntFromString(amount)
+	if !ok || !a.IsPositive() {
+		return math.Int{}, invalidRequest("amount '%s' must be a positive integer", amount)
+	}
+	return a, nil
+}
+
+// GetOpenAPI serves the OpenAPI specification of the v1 API.
+func (v *v1) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	util.Write(w, openAPISpec)
+}
+
+func (v *v1) GetHeight(w http.ResponseWriter, r *http.Request) {
+	v.handle(w, r, nil, func(c V1Chain, _ map[string]string) (any, error) {
+		height, err := c.Height(v.ctx)
+		if err != nil {
+			return nil, err
+		}
+		return HeightResponse{Height: height}, nil
+	})
+}
+
+func (v *v1) PostBankSend(w http.ResponseWriter, r *http.Request) {
+	var req BankSendRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "to_address": req.ToAddress, "amount": req.Amount}); err != nil {
+			return nil, err
+		}
+		amount, err := parseAmount(req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if req.Denom == "" {
+			req.Denom = c.Config().Denom
+		}
+
+		if err := c.SendFunds(v.ctx, req.KeyName, ibc.WalletAmount{Address: req.ToAddress, Denom: req.Denom, Amount: amount}); err != nil {
+			return nil, err
+		}
+		return BankSendResponse{ToAddress: req.ToAddress, Amount: amount.String(), Denom: req.Denom}, nil
+	})
+}
+
+func (v *v1) GetBankBalances(w http.ResponseWriter, r *http.Request) {
+	v.handle(w, r, nil, func(c V1Chain, vars map[string]string) (any, error) {
+		coins, err := c.AllBalances(v.ctx, vars["address"])
+		if err != nil {
+			return nil, err
+		}
+
+		res := BalancesResponse{Address: vars["address"], Balances: make([]Coin, len(coins))}
+		for i, coin := range coins {
+			res.Balances[i] = Coin{Denom: coin.Denom, Amount: coin.Amount.String()}
+		}
+		return res, nil
+	})
+}
+
+func (v *v1) PostWasmStore(w http.ResponseWriter, r *http.Request) {
+	var req WasmStoreRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "file_path": req.FilePath}); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(req.FilePath); err != nil {
+			return nil, invalidRequest("file %s does not exist on the source machine", req.FilePath)
+		}
+
+		codeID, err := c.StoreContract(v.ctx, req.KeyName, req.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		return WasmStoreResponse{CodeID: codeID}, nil
+	})
+}
+
+func (v *v1) PostWasmInstantiate(w http.ResponseWriter, r *http.Request) {
+	var req WasmInstantiateRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "code_id": req.CodeID, "msg": string(req.Msg)}); err != nil {
+			return nil, err
+		}
+
+		var extra []string
+		if !req.NoAdmin {
+			admin := req.Admin
+			if admin == "" {
+				addr, err := c.GetAddress(v.ctx, req.KeyName)
+				if err != nil {
+					return nil, err
+				}
+				admin = sdk.MustBech32ifyAddressBytes(c.Config().Bech32Prefix, addr)
+			}
+			extra = append(extra, "--admin", admin)
+		}
+		if req.Funds != "" {
+			extra = append(extra, "--amount", req.Funds)
+		}
+
+		addr, err := c.InstantiateContract(v.ctx, req.KeyName, req.CodeID, string(req.Msg), req.NoAdmin, extra...)
+		if err != nil {
+			return nil, err
+		}
+		return WasmInstantiateResponse{ContractAddress: addr}, nil
+	})
+}
+
+func (v *v1) PostWasmExecute(w http.ResponseWriter, r *http.Request) {
+	var req WasmExecuteRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "contract_address": req.ContractAddress, "msg": string(req.Msg)}); err != nil {
+			return nil, err
+		}
+
+		var extra []string
+		if req.Funds != "" {
+			extra = append(extra, "--amount", req.Funds)
+		}
+
+		res, err := c.ExecuteContract(v.ctx, req.KeyName, req.ContractAddress, string(req.Msg), extra...)
+		if err != nil {
+			return nil, err
+		}
+		return TxResponse{TxHash: res.TxHash, Height: res.Height, Code: res.Code, GasUsed: res.GasUsed, RawLog: res.RawLog}, nil
+	})
+}
+
+func (v *v1) PostWasmQuery(w http.ResponseWriter, r *http.Request) {
+	var req WasmQueryRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"contract_address": req.ContractAddress, "msg": string(req.Msg)}); err != nil {
+			return nil, err
+		}
+
+		var res WasmQueryResponse
+		if err := c.QueryContract(v.ctx, req.ContractAddress, string(req.Msg), &res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+func (v *v1) PostGovProposal(w http.ResponseWriter, r *http.Request) {
+	var req GovSubmitRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "title": req.Title, "deposit": req.Deposit}); err != nil {
+			return nil, err
+		}
+		if req.Summary == "" {
+			req.Summary = req.Title
+		}
+		if req.Messages == nil {
+			req.Messages = []json.RawMessage{}
+		}
+
+		tx, err := c.SubmitProposal(v.ctx, req.KeyName, cosmos.TxProposalv1{
+			Messages: req.Messages,
+			Metadata: req.Metadata,
+			Deposit:  req.Deposit,
+			Title:    req.Title,
+			Summary:  req.Summary,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return GovSubmitResponse{ProposalID: tx.ProposalID, TxHash: tx.TxHash, Height: tx.Height}, nil
+	})
+}
+
+func (v *v1) GetGovProposal(w http.ResponseWriter, r *http.Request) {
+	v.handle(w, r, nil, func(c V1Chain, vars map[string]string) (any, error) {
+		return c.QueryProposal(v.ctx, vars["proposal_id"])
+	})
+}
+
+// voteOptions maps the accepted vote options to the ones understood by the gov CLI.
+var voteOptions = map[string]string{
+	cosmos.ProposalVoteYes:        cosmos.ProposalVoteYes,
+	cosmos.ProposalVoteNo:         cosmos.ProposalVoteNo,
+	cosmos.ProposalVoteAbstain:    cosmos.ProposalVoteAbstain,
+	cosmos.ProposalVoteNoWithVeto: cosmos.ProposalVoteNoWithVeto,
+	"no_with_veto":                cosmos.ProposalVoteNoWithVeto,
+}
+
+func (v *v1) PostGovVote(w http.ResponseWriter, r *http.Request) {
+	var req GovVoteRequest
+	v.handle(w, r, &req, func(c V1Chain, vars map[string]string) (any, error) {
+		option, ok := voteOptions[req.Option]
+		if !ok {
+			return nil, invalidRequest("invalid vote option '%s', must be one of yes, no, abstain or no_with_veto", req.Option)
+		}
+
+		proposalID := vars["proposal_id"]
+		if req.AllValidators {
+			if err := c.VoteOnProposalAllValidators(v.ctx, proposalID, option); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := required(map[string]string{"key_name": req.KeyName}); err != nil {
+				return nil, err
+			}
+			if err := c.VoteOnProposal(v.ctx, req.KeyName, proposalID, option); err != nil {
+				return nil, err
+			}
+		}
+		return GovVoteResponse{ProposalID: proposalID, Option: option}, nil
+	})
+}
+
+func (v *v1) PostKey(w http.ResponseWriter, r *http.Request) {
+	var req KeyRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName}); err != nil {
+			return nil, err
+		}
+
+		wallet, err := c.BuildWallet(v.ctx, req.KeyName, req.Mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		return KeyResponse{KeyName: wallet.KeyName(), Address: wallet.FormattedAddress()}, nil
+	})
+}
+
+func (v *v1) GetKey(w http.ResponseWriter, r *http.Request) {
+	v.handle(w, r, nil, func(c V1Chain, vars map[string]string) (any, error) {
+		addr, err := c.GetAddress(v.ctx, vars["key_name"])
+		if err != nil {
+			return nil, &apiError{status: http.StatusNotFound, code: ErrCodeKeyNotFound, err: err}
+		}
+		return KeyResponse{KeyName: vars["key_name"], Address: sdk.MustBech32ifyAddressBytes(c.Config().Bech32Prefix, addr)}, nil
+	})
+}
+
+func (v *v1) PostIBCTransfer(w http.ResponseWriter, r *http.Request) {
+	var req IBCTransferRequest
+	v.handle(w, r, &req, func(c V1Chain, _ map[string]string) (any, error) {
+		if err := required(map[string]string{"key_name": req.KeyName, "channel_id": req.ChannelID, "to_address": req.ToAddress, "amount": req.Amount}); err != nil {
+			return nil, err
+		}
+		amount, err := parseAmount(req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if req.Denom == "" {
+			req.Denom = c.Config().Denom
+		}
+
+		opts := ibc.TransferOptions{Memo: req.Memo}
+		if req.TimeoutHeight > 0 || req.TimeoutNanoseconds > 0 {
+			opts.Timeout = &ibc.IBCTimeout{Height: req.TimeoutHeight, NanoSeconds: req.TimeoutNanoseconds}
+		}
+
+		tx, err := c.SendIBCTransfer(v.ctx, req.ChannelID, req.KeyName, ibc.WalletAmount{Address: req.ToAddress, Denom: req.Denom, Amount: amount}, opts)
+		if err != nil {
+			return nil, err
+		}
+		return IBCTransferResponse{
+			TxHash: tx.TxHash,
+			Height: tx.Height,
+			Packet: Packet{
+				Sequence:         tx.Packet.Sequence,
+				SourcePort:       tx.Packet.SourcePort,
+				SourceChannel:    tx.Packet.SourceChannel,
+				DestPort:         tx.Packet.DestPort,
+				DestChannel:      tx.Packet.DestChannel,
+				Data:             string(tx.Packet.Data),
+				TimeoutHeight:    tx.Packet.TimeoutHeight,
+				TimeoutTimestamp: uint64(tx.Packet.TimeoutTimestamp),
+			},
+		}, nil
+	})
+}
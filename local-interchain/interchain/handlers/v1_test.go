@@ -0,0 +1,286 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+// fakeChain implements the parts of handlers.V1Chain exercised by these tests,
+// calling any other method panics.
+type fakeChain struct {
+	handlers.V1Chain
+
+	sendErr error
+	sent    []ibc.WalletAmount
+	sentKey string
+
+	votes []string
+
+	instantiateArgs []string
+}
+
+var fakeAddr = sdk.AccAddress("acc0________________")
+
+func (f *fakeChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: "localjuno-1", Denom: "ujuno", Bech32Prefix: "juno"}
+}
+
+func (f *fakeChain) SendFunds(_ context.Context, keyName string, amount ibc.WalletAmount) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sentKey = keyName
+	f.sent = append(f.sent, amount)
+	return nil
+}
+
+func (f *fakeChain) AllBalances(_ context.Context, address string) (sdk.Coins, error) {
+	return sdk.NewCoins(sdk.NewInt64Coin("ujuno", 100), sdk.NewInt64Coin("uatom", 5)), nil
+}
+
+func (f *fakeChain) GetAddress(_ context.Context, keyName string) ([]byte, error) {
+	if keyName != "acc0" {
+		return nil, errors.New("key not found")
+	}
+	return fakeAddr, nil
+}
+
+func (f *fakeChain) InstantiateContract(_ context.Context, _ string, _ string, _ string, _ bool, extraExecTxArgs ...string) (string, error) {
+	f.instantiateArgs = extraExecTxArgs
+	return "juno1contract", nil
+}
+
+func (f *fakeChain) VoteOnProposal(_ context.Context, keyName string, proposalID string, vote string) error {
+	f.votes = append(f.votes, keyName+"/"+proposalID+"/"+vote)
+	return nil
+}
+
+func (f *fakeChain) VoteOnProposalAllValidators(_ context.Context, proposalID string, vote string) error {
+	f.votes = append(f.votes, "all/"+proposalID+"/"+vote)
+	return nil
+}
+
+func newV1Server(t *testing.T, c *fakeChain) *httptest.Server {
+	t.Helper()
+
+	v1H := handlers.NewV1(context.Background(), map[string]handlers.V1Chain{"localjuno-1": c})
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/openapi.yaml", v1H.GetOpenAPI).Methods(http.MethodGet)
+	const chain = "/v1/chains/{chain_id}"
+	r.HandleFunc(chain+"/bank/send", v1H.PostBankSend).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/bank/balances/{address}", v1H.GetBankBalances).Methods(http.MethodGet)
+	r.HandleFunc(chain+"/wasm/instantiate", v1H.PostWasmInstantiate).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/gov/proposals/{proposal_id}/votes", v1H.PostGovVote).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/keys/{key_name}", v1H.GetKey).Methods(http.MethodGet)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// do sends body, when not empty, to path and decodes the response into res.
+func do(t *testing.T, srv *httptest.Server, method, path, body string, res any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if res != nil {
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(res))
+	}
+	return resp.StatusCode
+}
+
+func TestV1_BankSend(t *testing.T) {
+	c := &fakeChain{}
+	srv := newV1Server(t, c)
+
+	var res handlers.BankSendResponse
+	status := do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/bank/send", `{"key_name":"acc0","to_address":"juno1abc","amount":"10"}`, &res)
+	require.Equal(t, http.StatusOK, status)
+	// The denom defaults to the chain's.
+	require.Equal(t, handlers.BankSendResponse{ToAddress: "juno1abc", Amount: "10", Denom: "ujuno"}, res)
+	require.Equal(t, "acc0", c.sentKey)
+	require.Len(t, c.sent, 1)
+	require.Equal(t, "juno1abc", c.sent[0].Address)
+	require.Equal(t, int64(10), c.sent[0].Amount.Int64())
+}
+
+func TestV1_Errors(t *testing.T) {
+	c := &fakeChain{sendErr: errors.New("insufficient funds")}
+	srv := newV1Server(t, c)
+
+	for _, tt := range []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   string
+		wantMsg    string
+	}{
+		{
+			name:       "unknown chain",
+			method:     http.MethodGet,
+			path:       "/v1/chains/localosmo-1/bank/balances/juno1abc",
+			wantStatus: http.StatusNotFound,
+			wantCode:   handlers.ErrCodeChainNotFound,
+			wantMsg:    "chain_id 'localosmo-1' not found",
+		},
+		{
+			name:       "malformed json",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/bank/send",
+			body:       `{"key_name":`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   handlers.ErrCodeInvalidRequest,
+			wantMsg:    "failed to decode json",
+		},
+		{
+			name:       "unknown field",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/bank/send",
+			body:       `{"key_name":"acc0","to":"juno1abc","amount":"10"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   handlers.ErrCodeInvalidRequest,
+			wantMsg:    `unknown field "to"`,
+		},
+		{
+			name:       "missing fields",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/bank/send",
+			body:       `{"to_address":"juno1abc"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   handlers.ErrCodeInvalidRequest,
+			wantMsg:    "missing required fields: amount, key_name",
+		},
+		{
+			name:       "non positive amount",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/bank/send",
+			body:       `{"key_name":"acc0","to_address":"juno1abc","amount":"-1"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   handlers.ErrCodeInvalidRequest,
+			wantMsg:    "amount '-1' must be a positive integer",
+		},
+		{
+			name:       "chain error",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/bank/send",
+			body:       `{"key_name":"acc0","to_address":"juno1abc","amount":"10"}`,
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   handlers.ErrCodeChainError,
+			wantMsg:    "insufficient funds",
+		},
+		{
+			name:       "unknown key",
+			method:     http.MethodGet,
+			path:       "/v1/chains/localjuno-1/keys/acc1",
+			wantStatus: http.StatusNotFound,
+			wantCode:   handlers.ErrCodeKeyNotFound,
+			wantMsg:    "key not found",
+		},
+		{
+			name:       "invalid vote option",
+			method:     http.MethodPost,
+			path:       "/v1/chains/localjuno-1/gov/proposals/1/votes",
+			body:       `{"key_name":"acc0","option":"maybe"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   handlers.ErrCodeInvalidRequest,
+			wantMsg:    "invalid vote option 'maybe'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var res handlers.ErrorResponse
+			status := do(t, srv, tt.method, tt.path, tt.body, &res)
+			require.Equal(t, tt.wantStatus, status)
+			require.Equal(t, tt.wantCode, res.Error.Code)
+			require.Contains(t, res.Error.Message, tt.wantMsg)
+		})
+	}
+}
+
+func TestV1_Balances(t *testing.T) {
+	srv := newV1Server(t, &fakeChain{})
+
+	var res handlers.BalancesResponse
+	status := do(t, srv, http.MethodGet, "/v1/chains/localjuno-1/bank/balances/juno1abc", "", &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, handlers.BalancesResponse{
+		Address:  "juno1abc",
+		Balances: []handlers.Coin{{Denom: "uatom", Amount: "5"}, {Denom: "ujuno", Amount: "100"}},
+	}, res)
+}
+
+func TestV1_GetKey(t *testing.T) {
+	srv := newV1Server(t, &fakeChain{})
+
+	var res handlers.KeyResponse
+	status := do(t, srv, http.MethodGet, "/v1/chains/localjuno-1/keys/acc0", "", &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "acc0", res.KeyName)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("juno", fakeAddr), res.Address)
+}
+
+func TestV1_WasmInstantiate(t *testing.T) {
+	c := &fakeChain{}
+	srv := newV1Server(t, c)
+
+	// The admin defaults to the sender.
+	var res handlers.WasmInstantiateResponse
+	status := do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/wasm/instantiate", `{"key_name":"acc0","code_id":"1","msg":{},"funds":"5ujuno"}`, &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "juno1contract", res.ContractAddress)
+	require.Equal(t, []string{"--admin", sdk.MustBech32ifyAddressBytes("juno", fakeAddr), "--amount", "5ujuno"}, c.instantiateArgs)
+
+	status = do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/wasm/instantiate", `{"key_name":"acc0","code_id":"1","msg":{},"no_admin":true}`, &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, c.instantiateArgs)
+}
+
+func TestV1_GovVote(t *testing.T) {
+	c := &fakeChain{}
+	srv := newV1Server(t, c)
+
+	var res handlers.GovVoteResponse
+	status := do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/gov/proposals/3/votes", `{"key_name":"acc0","option":"no_with_veto"}`, &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, handlers.GovVoteResponse{ProposalID: "3", Option: cosmos.ProposalVoteNoWithVeto}, res)
+
+	status = do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/gov/proposals/3/votes", `{"option":"yes","all_validators":true}`, &res)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, []string{"acc0/3/noWithVeto", "all/3/yes"}, c.votes)
+
+	// A key is required unless every validator votes.
+	var errRes handlers.ErrorResponse
+	status = do(t, srv, http.MethodPost, "/v1/chains/localjuno-1/gov/proposals/3/votes", `{"option":"yes"}`, &errRes)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "missing required fields: key_name", errRes.Error.Message)
+}
+
+func TestV1_OpenAPI(t *testing.T) {
+	srv := newV1Server(t, &fakeChain{})
+
+	resp, err := srv.Client().Get(srv.URL + "/v1/openapi.yaml")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/yaml", resp.Header.Get("Content-Type"))
+}
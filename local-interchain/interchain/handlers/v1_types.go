@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+)
+
+// Error codes returned by the v1 API in ErrorResponse.
+const (
+	ErrCodeInvalidRequest = "invalid_request"
+	ErrCodeChainNotFound  = "chain_not_found"
+	ErrCodeKeyNotFound    = "key_not_found"
+	ErrCodeChainError     = "chain_error"
+)
+
+// APIError is a machine readable error returned by the v1 API.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the body of every non 2xx response from the v1 API.
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// HeightResponse is the latest block height of a chain.
+type HeightResponse struct {
+	Height uint64 `json:"height"`
+}
+
+// BankSendRequest sends tokens from a key in the chain's keyring.
+type BankSendRequest struct {
+	KeyName   string `json:"key_name"`
+	ToAddress string `json:"to_address"`
+	Amount    string `json:"amount"`
+	Denom     string `json:"denom"`
+}
+
+// BankSendResponse echoes the transfer once it is included in a block.
+type BankSendResponse struct {
+	ToAddress string `json:"to_address"`
+	Amount    string `json:"amount"`
+	Denom     string `json:"denom"`
+}
+
+// Coin is an amount of a single denom.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// BalancesResponse lists every balance held by an address.
+type BalancesResponse struct {
+	Address  string `json:"address"`
+	Balances []Coin `json:"balances"`
+}
+
+// WasmStoreRequest uploads a wasm file from the host machine and stores it on chain.
+type WasmStoreRequest struct {
+	KeyName  string `json:"key_name"`
+	FilePath string `json:"file_path"`
+}
+
+type WasmStoreResponse struct {
+	CodeID string `json:"code_id"`
+}
+
+// WasmInstantiateRequest instantiates a stored contract. An admin is set unless NoAdmin is true.
+type WasmInstantiateRequest struct {
+	KeyName string          `json:"key_name"`
+	CodeID  string          `json:"code_id"`
+	Msg     json.RawMessage `json:"msg"`
+	Admin   string          `json:"admin,omitempty"`
+	NoAdmin bool            `json:"no_admin,omitempty"`
+	Funds   string          `json:"funds,omitempty"`
+}
+
+type WasmInstantiateResponse struct {
+	ContractAddress string `json:"contract_address"`
+}
+
+// WasmExecuteRequest executes a message on a contract, optionally sending funds such as "100ujuno".
+type WasmExecuteRequest struct {
+	KeyName         string          `json:"key_name"`
+	ContractAddress string          `json:"contract_address"`
+	Msg             json.RawMessage `json:"msg"`
+	Funds           string          `json:"funds,omitempty"`
+}
+
+// TxResponse summarizes an included transaction.
+type TxResponse struct {
+	TxHash  string `json:"tx_hash"`
+	Height  int64  `json:"height"`
+	Code    uint32 `json:"code"`
+	GasUsed int64  `json:"gas_used"`
+	RawLog  string `json:"raw_log,omitempty"`
+}
+
+// WasmQueryRequest runs a smart query against a contract.
+type WasmQueryRequest struct {
+	ContractAddress string          `json:"contract_address"`
+	Msg             json.RawMessage `json:"msg"`
+}
+
+type WasmQueryResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// GovSubmitRequest submits a gov v1 proposal. Messages are JSON encoded sdk.Msgs including their "@type".
+type GovSubmitRequest struct {
+	KeyName  string            `json:"key_name"`
+	Title    string            `json:"title"`
+	Summary  string            `json:"summary"`
+	Metadata string            `json:"metadata,omitempty"`
+	Deposit  string            `json:"deposit"`
+	Messages []json.RawMessage `json:"messages"`
+}
+
+type GovSubmitResponse struct {
+	ProposalID string `json:"proposal_id"`
+	TxHash     string `json:"tx_hash"`
+	Height     uint64 `json:"height"`
+}
+
+// GovVoteRequest votes on a proposal. When AllValidators is true every validator votes and KeyName is ignored.
+type GovVoteRequest struct {
+	KeyName       string `json:"key_name,omitempty"`
+	Option        string `json:"option"`
+	AllValidators bool   `json:"all_validators,omitempty"`
+}
+
+type GovVoteResponse struct {
+	ProposalID string `json:"proposal_id"`
+	Option     string `json:"option"`
+}
+
+// KeyRequest creates a key, or recovers it when a mnemonic is given.
+type KeyRequest struct {
+	KeyName  string `json:"key_name"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+}
+
+// KeyResponse describes a key in the chain's keyring.
+type KeyResponse struct {
+	KeyName string `json:"key_name"`
+	Address string `json:"address"`
+}
+
+// IBCTransferRequest sends an ICS-20 transfer over ChannelID.
+type IBCTransferRequest struct {
+	KeyName   string `json:"key_name"`
+	ChannelID string `json:"channel_id"`
+	ToAddress string `json:"to_address"`
+	Amount    string `json:"amount"`
+	Denom     string `json:"denom"`
+	Memo      string `json:"memo,omitempty"`
+
+	// Optional timeouts, the chain's defaults are used when both are unset.
+	TimeoutHeight      uint64 `json:"timeout_height,omitempty"`
+	TimeoutNanoseconds uint64 `json:"timeout_nanoseconds,omitempty"`
+}
+
+// Packet is the ICS-04 packet emitted by a transfer.
+type Packet struct {
+	Sequence         uint64 `json:"sequence"`
+	SourcePort       string `json:"source_port"`
+	SourceChannel    string `json:"source_channel"`
+	DestPort         string `json:"dest_port"`
+	DestChannel      string `json:"dest_channel"`
+	Data             string `json:"data"`
+	TimeoutHeight    string `json:"timeout_height"`
+	TimeoutTimestamp uint64 `json:"timeout_timestamp"`
+}
+
+type IBCTransferResponse struct {
+	TxHash string `json:"tx_hash"`
+	Height uint64 `json:"height"`
+	Packet Packet `json:"packet"`
+}
@@ -46,7 +46,7 @@ func GetChannelConnections(ctx context.Context, ibcpaths map[string][]int, chain
 
 	channels := []types.IBCChannel{}
 
-	for _, c := range ibcpaths {
+	for path, c := range ibcpaths {
 		chain1 := chains[c[0]]
 		chain2 := chains[c[1]]
 
@@ -57,6 +57,7 @@ func GetChannelConnections(ctx context.Context, ibcpaths map[string][]int, chain
 
 		channels = append(channels, types.IBCChannel{
 			ChainID: chain1.Config().ChainID,
+			Path:    path,
 			Channel: channel1,
 		})
 
@@ -67,6 +68,7 @@ func GetChannelConnections(ctx context.Context, ibcpaths map[string][]int, chain
 		}
 		channels = append(channels, types.IBCChannel{
 			ChainID: chain2.Config().ChainID,
+			Path:    path,
 			Channel: channel2,
 		})
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	types "github.com/strangelove-ventures/localinterchain/interchain/types"
 	"go.uber.org/zap"
@@ -18,7 +19,7 @@ func WriteRunningChains(configsDir string, bz []byte) {
 	_ = os.WriteFile(filepath, bz, 0644)
 }
 
-func DumpChainsInfoToLogs(configDir string, config *types.Config, chains []ibc.Chain, connections []types.IBCChannel) {
+func DumpChainsInfoToLogs(configDir string, config *types.Config, chains []ibc.Chain, connections []types.IBCChannel, contracts map[string]map[string]string) {
 	mainLogs := types.MainLogs{
 		StartTime: uint64(time.Now().Unix()),
 		Chains:    []types.LogOutput{},
@@ -27,20 +28,25 @@ func DumpChainsInfoToLogs(configDir string, config *types.Config, chains []ibc.C
 
 	// Iterate chain config & get the ibc chain's to save data to logs.
 	for idx, chain := range config.Chains {
-		chainObj := chains[idx].(*cosmos.CosmosChain)
-
 		ibcPaths := chain.IBCPaths
 		if ibcPaths == nil {
 			ibcPaths = []string{}
 		}
 
 		log := types.LogOutput{
-			ChainID:     chainObj.Config().ChainID,
-			ChainName:   chainObj.Config().Name,
-			RPCAddress:  chainObj.GetHostRPCAddress(),
-			RESTAddress: chainObj.GetHostAPIAddress(),
-			GRPCAddress: chainObj.GetHostGRPCAddress(),
-			IBCPath:     ibcPaths,
+			ChainID:   chains[idx].Config().ChainID,
+			ChainName: chains[idx].Config().Name,
+			IBCPath:   ibcPaths,
+		}
+
+		switch chainObj := chains[idx].(type) {
+		case *cosmos.CosmosChain:
+			log.RPCAddress = chainObj.GetHostRPCAddress()
+			log.RESTAddress = chainObj.GetHostAPIAddress()
+			log.GRPCAddress = chainObj.GetHostGRPCAddress()
+		case *ethereum.EthereumChain:
+			log.RPCAddress = chainObj.GetHostRPCAddress()
+			log.Contracts = contracts[log.ChainID]
 		}
 
 		mainLogs.Chains = append(mainLogs.Chains, log)
@@ -0,0 +1,135 @@
+package router
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	ictypes "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"github.com/strangelove-ventures/localinterchain/interchain/util"
+)
+
+// Error codes returned by Protect in handlers.ErrorResponse.
+const (
+	ErrCodeUnauthorized = "unauthorized"
+	ErrCodeReadOnly     = handlers.ErrCodeReadOnly
+)
+
+// Protect wraps every route of h with the CORS, authentication and read-only settings of the server.
+func Protect(h http.Handler, cfg ictypes.RestServer) http.Handler {
+	if cfg.ReadOnly {
+		h = readOnly(h)
+	}
+	if cfg.AuthToken != "" {
+		h = auth(h, cfg.AuthToken)
+	}
+	if len(cfg.CORS.AllowedOrigins) > 0 {
+		h = cors(h, cfg.CORS)
+	}
+	return h
+}
+
+// auth requires token as a bearer token, or as the token query parameter since browsers can not set
+// headers on page loads and websockets.
+func auth(next http.Handler, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.URL.Query().Get("token")
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			got = bearer
+		}
+
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "a valid token is required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readOnly only lets through the GET routes, the v1 wasm query and the actions in handlers.IsReadOnlyAction.
+// Every other route writes to the chains or changes the environment.
+func readOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet || r.Method == http.MethodHead || isWasmQuery(r):
+			next.ServeHTTP(w, r)
+			return
+		case r.Method != http.MethodPost || r.URL.Path != "/":
+			writeError(w, http.StatusForbidden, ErrCodeReadOnly, fmt.Sprintf("%s %s is disabled, the server is read-only", r.Method, r.URL.Path))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			util.WriteError(w, fmt.Errorf("failed to read body: %w", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Decoded the same way as handlers.PostActions, which ignores anything after the first value.
+		var ah handlers.ActionHandler
+		if err := json.NewDecoder(bytes.NewReader(body)).Decode(&ah); err != nil {
+			writeError(w, http.StatusBadRequest, handlers.ErrCodeInvalidRequest, fmt.Sprintf("invalid action: %s", err))
+			return
+		}
+		if !handlers.IsReadOnlyAction(ah.Action) {
+			writeError(w, http.StatusForbidden, ErrCodeReadOnly, fmt.Sprintf("action %s is disabled, the server is read-only", ah.Action))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(handlers.WithReadOnly(r.Context())))
+	})
+}
+
+// isWasmQuery matches POST /v1/chains/{chain_id}/wasm/query.
+func isWasmQuery(r *http.Request) bool {
+	rest, ok := strings.CutPrefix(r.URL.Path, "/v1/chains/")
+	if r.Method != http.MethodPost || !ok {
+		return false
+	}
+	chainID, ok := strings.CutSuffix(rest, "/wasm/query")
+	return ok && chainID != "" && !strings.Contains(chainID, "/")
+}
+
+// cors answers preflight requests and adds the CORS headers for allowed origins.
+func cors(next http.Handler, cfg ictypes.CORS) http.Handler {
+	headers := append([]string{"Authorization", "Content-Type", "Upload-Type"}, cfg.AllowedHeaders...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		origin := r.Header.Get("Origin")
+		if origin == "" || !originAllowed(origin, cfg.AllowedOrigins) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func originAllowed(origin string, allowed []string) bool {
+	for _, o := range allowed {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	util.WriteJSON(w, status, handlers.ErrorResponse{Error: handlers.APIError{Code: code, Message: msg}})
+}
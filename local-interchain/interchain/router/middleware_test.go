@@ -0,0 +1,198 @@
+package router_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+	"github.com/strangelove-ventures/localinterchain/interchain/router"
+	ictypes "github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// echo answers 200 with the request body, so tests can check it reached the handler unchanged.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+})
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func requireError(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	require.Equal(t, status, w.Code, w.Body.String())
+	var res handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, code, res.Error.Code)
+}
+
+func TestProtect_Disabled(t *testing.T) {
+	h := router.Protect(echo, ictypes.RestServer{})
+
+	w := serve(h, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"action":"kill-all"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(h, httptest.NewRequest(http.MethodDelete, "/chains/localjuno-1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestProtect_Auth(t *testing.T) {
+	h := router.Protect(echo, ictypes.RestServer{AuthToken: "secret"})
+
+	for _, tt := range []struct {
+		name   string
+		header string
+		target string
+		want   int
+	}{
+		{"no token", "", "/info", http.StatusUnauthorized},
+		{"wrong bearer", "Bearer wrong", "/info", http.StatusUnauthorized},
+		{"not a bearer", "secret", "/info", http.StatusUnauthorized},
+		{"wrong query", "", "/info?token=wrong", http.StatusUnauthorized},
+		{"bearer", "Bearer secret", "/info", http.StatusOK},
+		{"query", "", "/ws/events?token=secret", http.StatusOK},
+		{"bearer takes precedence", "Bearer wrong", "/info?token=secret", http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := serve(h, r)
+			if tt.want == http.StatusOK {
+				require.Equal(t, http.StatusOK, w.Code)
+				return
+			}
+			requireError(t, w, tt.want, router.ErrCodeUnauthorized)
+			require.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestProtect_ReadOnly(t *testing.T) {
+	h := router.Protect(echo, ictypes.RestServer{ReadOnly: true})
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"exec", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"exec","cmd":"ls"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"bin", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"bin","cmd":"tx bank send"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"relayer exec", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"relayer-exec","cmd":"rly paths list"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"recover key", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"recover-key","cmd":"key1;mnemonic"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"start relayer", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"start-relayer"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"overwrite genesis", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"overwrite-genesis-file","cmd":"{}"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"kill all", http.MethodPost, "/", `{"action":"kill-all"}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"query", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"query","cmd":"bank total"}`, http.StatusOK, ""},
+		{"get channels", http.MethodPost, "/", `{"chain_id":"localjuno-1","action":"get_channels"}`, http.StatusOK, ""},
+		{"invalid action body", http.MethodPost, "/", `not json`, http.StatusBadRequest, handlers.ErrCodeInvalidRequest},
+		// The handler decodes the first value only, as does the middleware.
+		{"trailing exec", http.MethodPost, "/", `{"action":"query","cmd":"bank total"}{"action":"exec","cmd":"ls"}`, http.StatusOK, ""},
+		{"exec with trailing data", http.MethodPost, "/", `{"action":"exec","cmd":"ls"} trailing`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"upload", http.MethodPost, "/upload", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"faucet", http.MethodPost, "/faucet", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"add chain", http.MethodPost, "/chains", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"delete chain", http.MethodDelete, "/chains/localjuno-1", "", http.StatusForbidden, router.ErrCodeReadOnly},
+		{"add ibc path", http.MethodPost, "/ibc/paths", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"snapshot", http.MethodPost, "/snapshot", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"v1 bank send", http.MethodPost, "/v1/chains/localjuno-1/bank/send", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"v1 add key", http.MethodPost, "/v1/chains/localjuno-1/keys", `{}`, http.StatusForbidden, router.ErrCodeReadOnly},
+		{"v1 wasm query", http.MethodPost, "/v1/chains/localjuno-1/wasm/query", `{}`, http.StatusOK, ""},
+		{"list chains", http.MethodGet, "/chains", "", http.StatusOK, ""},
+		{"get ibc paths", http.MethodGet, "/ibc/paths", "", http.StatusOK, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(h, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if tt.status != http.StatusOK {
+				requireError(t, w, tt.status, tt.code)
+				return
+			}
+			require.Equal(t, http.StatusOK, w.Code)
+			// The action body is read by the middleware and must reach the handler unchanged.
+			require.Equal(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestProtect_CORS(t *testing.T) {
+	h := router.Protect(echo, ictypes.RestServer{CORS: ictypes.CORS{
+		AllowedOrigins: []string{"http://localhost:3000"},
+		AllowedHeaders: []string{"X-Custom"},
+	}})
+
+	t.Run("preflight", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "/info", nil)
+		r.Header.Set("Origin", "http://LOCALHOST:3000")
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := serve(h, r)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "http://LOCALHOST:3000", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodDelete)
+		require.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+		require.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "X-Custom")
+		require.Equal(t, "Origin", w.Header().Get("Vary"))
+	})
+
+	t.Run("allowed origin", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/info", nil)
+		r.Header.Set("Origin", "http://localhost:3000")
+		w := serve(h, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("other origin", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "/info", nil)
+		r.Header.Set("Origin", "http://evil.example")
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := serve(h, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("any origin", func(t *testing.T) {
+		h := router.Protect(echo, ictypes.RestServer{CORS: ictypes.CORS{AllowedOrigins: []string{"*"}}})
+		r := httptest.NewRequest(http.MethodGet, "/info", nil)
+		r.Header.Set("Origin", "http://evil.example")
+		w := serve(h, r)
+
+		require.Equal(t, "http://evil.example", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight before auth", func(t *testing.T) {
+		h := router.Protect(echo, ictypes.RestServer{
+			AuthToken: "secret",
+			CORS:      ictypes.CORS{AllowedOrigins: []string{"http://localhost:3000"}},
+		})
+		r := httptest.NewRequest(http.MethodOptions, "/info", nil)
+		r.Header.Set("Origin", "http://localhost:3000")
+		r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		w := serve(h, r)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		r = httptest.NewRequest(http.MethodGet, "/info", nil)
+		r.Header.Set("Origin", "http://localhost:3000")
+		w = serve(h, r)
+		requireError(t, w, http.StatusUnauthorized, router.ErrCodeUnauthorized)
+		// Browsers can only read the error when the CORS headers are set.
+		require.Equal(t, "http://localhost:3000", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
-	ictypes "github.com/strangelove-ventures/localinterchain/interchain/types"
 	"github.com/strangelove-ventures/localinterchain/interchain/util"
 
 	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
@@ -23,24 +24,57 @@ type Route struct {
 func NewRouter(
 	ctx context.Context,
 	ic *interchaintest.Interchain,
-	config *ictypes.Config,
+	config handlers.ConfigSource,
 	cosmosChains map[string]*cosmos.CosmosChain,
+	ethChains map[string]*ethereum.EthereumChain,
 	vals map[string]*cosmos.ChainNode,
 	relayer ibc.Relayer,
 	eRep ibc.RelayerExecReporter,
 	installDir string,
+	snapshotter handlers.Snapshotter,
+	manager handlers.ChainManager,
 ) *mux.Router {
 	r := mux.NewRouter()
 
-	infoH := handlers.NewInfo(config, installDir, ctx, ic, cosmosChains, vals, relayer, eRep)
+	// Chains can be added and removed while the server runs. Requests hold a read lock on the chain maps,
+	// except for the ones which change them or stay open.
+	var chainsMu sync.RWMutex
+	r.Use(readLock(&chainsMu, "/chains", "/chains/{chain_id}", "/ibc/paths", "/ws/events"))
+
+	infoH := handlers.NewInfo(config, installDir, ctx, ic, cosmosChains, ethChains, vals, relayer, eRep)
 	r.HandleFunc("/info", infoH.GetInfo).Methods(http.MethodGet)
 
-	actionsH := handlers.NewActions(ctx, ic, cosmosChains, vals, relayer, eRep)
+	actionsH := handlers.NewActions(ctx, ic, cosmosChains, ethChains, vals, relayer, eRep)
 	r.HandleFunc("/", actionsH.PostActions).Methods(http.MethodPost)
 
 	uploaderH := handlers.NewUploader(ctx, vals)
 	r.HandleFunc("/upload", uploaderH.PostUpload).Methods(http.MethodPost)
 
+	v1Chains := make(map[string]handlers.V1Chain, len(cosmosChains))
+	for chainID, c := range cosmosChains {
+		v1Chains[chainID] = c
+	}
+	RegisterV1(ctx, r, v1Chains)
+
+	faucetH := handlers.NewFaucet(ctx, config, cosmosChains, ethChains)
+	r.HandleFunc("/faucet", faucetH.PostFaucet).Methods(http.MethodPost)
+
+	dashboardH := handlers.NewDashboard(ctx, config, installDir, cosmosChains, ethChains, relayer)
+	r.HandleFunc("/dashboard", dashboardH.GetDashboard).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/status", dashboardH.GetStatus).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/logs/{container}", dashboardH.GetLogs).Methods(http.MethodGet)
+
+	snapshotH := handlers.NewSnapshot(ctx, snapshotter)
+	r.HandleFunc("/snapshot", snapshotH.PostSnapshot).Methods(http.MethodPost)
+
+	eventsH := handlers.NewEvents(ctx, vals)
+	r.HandleFunc("/ws/events", eventsH.GetEvents).Methods(http.MethodGet)
+
+	chainsH := handlers.NewChains(ctx, manager, &chainsMu, cosmosChains, ethChains, vals, v1Chains, eventsH)
+	r.HandleFunc("/chains", chainsH.PostChain).Methods(http.MethodPost)
+	r.HandleFunc("/chains/{chain_id}", chainsH.DeleteChain).Methods(http.MethodDelete)
+	r.HandleFunc("/ibc/paths", chainsH.PostIBCPath).Methods(http.MethodPost)
+
 	availableRoutes := getAllMethods(*r)
 	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		jsonRes, err := json.MarshalIndent(availableRoutes, "", "  ")
@@ -54,6 +88,55 @@ func NewRouter(
 	return r
 }
 
+// RegisterV1 adds the typed v1 API, described by handlers/openapi.yaml, to r.
+func RegisterV1(ctx context.Context, r *mux.Router, chains map[string]handlers.V1Chain) {
+	v1H := handlers.NewV1(ctx, chains)
+	r.HandleFunc("/v1/openapi.yaml", v1H.GetOpenAPI).Methods(http.MethodGet)
+
+	const chain = "/v1/chains/{chain_id}"
+	r.HandleFunc(chain+"/height", v1H.GetHeight).Methods(http.MethodGet)
+
+	r.HandleFunc(chain+"/bank/send", v1H.PostBankSend).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/bank/balances/{address}", v1H.GetBankBalances).Methods(http.MethodGet)
+
+	r.HandleFunc(chain+"/wasm/store", v1H.PostWasmStore).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/wasm/instantiate", v1H.PostWasmInstantiate).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/wasm/execute", v1H.PostWasmExecute).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/wasm/query", v1H.PostWasmQuery).Methods(http.MethodPost)
+
+	r.HandleFunc(chain+"/gov/proposals", v1H.PostGovProposal).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/gov/proposals/{proposal_id}", v1H.GetGovProposal).Methods(http.MethodGet)
+	r.HandleFunc(chain+"/gov/proposals/{proposal_id}/votes", v1H.PostGovVote).Methods(http.MethodPost)
+
+	r.HandleFunc(chain+"/keys", v1H.PostKey).Methods(http.MethodPost)
+	r.HandleFunc(chain+"/keys/{key_name}", v1H.GetKey).Methods(http.MethodGet)
+
+	r.HandleFunc(chain+"/ibc/transfer", v1H.PostIBCTransfer).Methods(http.MethodPost)
+}
+
+// readLock holds a read lock on mu while serving every route except the ones with the given path templates.
+func readLock(mu *sync.RWMutex, unlocked ...string) mux.MiddlewareFunc {
+	skip := make(map[string]bool, len(unlocked))
+	for _, tpl := range unlocked {
+		skip[tpl] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if route := mux.CurrentRoute(r); route != nil {
+				if tpl, err := route.GetPathTemplate(); err == nil && skip[tpl] {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			mu.RLock()
+			defer mu.RUnlock()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func getAllMethods(r mux.Router) []Route {
 	endpoints := make([]Route, 0)
 
@@ -0,0 +1,64 @@
+package scenario
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// StepResult is the outcome of a single step.
+type StepResult struct {
+	Index    int           `json:"index"`
+	Name     string        `json:"name"`
+	Kind     string        `json:"kind"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Output   string        `json:"output,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Report is the outcome of a scenario. It passed when every step passed.
+type Report struct {
+	Scenario string        `json:"scenario"`
+	Passed   bool          `json:"passed"`
+	Steps    []StepResult  `json:"steps"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// WriteText writes a line per step followed by a summary.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Scenario: %s\n", r.Scenario)
+
+	counts := make(map[Status]int)
+	for _, s := range r.Steps {
+		counts[s.Status]++
+		fmt.Fprintf(&b, "  %-7s %2d. %s", strings.ToUpper(string(s.Status)), s.Index, s.Name)
+		if s.Status != StatusSkipped {
+			fmt.Fprintf(&b, " (%s)", s.Duration.Round(time.Millisecond))
+		}
+		b.WriteString("\n")
+		if s.Error != "" {
+			fmt.Fprintf(&b, "          %s\n", s.Error)
+		}
+	}
+
+	result := "PASS"
+	if !r.Passed {
+		result = "FAIL"
+	}
+	fmt.Fprintf(&b, "%s: %d passed, %d failed, %d skipped in %s\n",
+		result, counts[StatusPassed], counts[StatusFailed], counts[StatusSkipped], r.Duration.Round(time.Millisecond))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
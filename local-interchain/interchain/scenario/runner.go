@@ -0,0 +1,309 @@
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/localinterchain/client"
+	"github.com/strangelove-ventures/localinterchain/interchain/handlers"
+)
+
+const assertPollInterval = time.Second
+
+// Runner executes scenarios against the local-ic API of client.
+type Runner struct {
+	client *client.Client
+}
+
+func NewRunner(c *client.Client) *Runner {
+	return &Runner{client: c}
+}
+
+// Run executes every step in order. Once a step fails the remaining steps are skipped.
+func (r *Runner) Run(ctx context.Context, s *Scenario) *Report {
+	start := time.Now()
+	report := &Report{Scenario: s.Name, Passed: true}
+
+	vars := make(map[string]string, len(s.Vars))
+	for k, v := range s.Vars {
+		vars[k] = v
+	}
+
+	for i, step := range s.Steps {
+		res := StepResult{Index: i + 1, Name: step.Title(), Kind: step.Kind()}
+		if !report.Passed {
+			res.Status = StatusSkipped
+			report.Steps = append(report.Steps, res)
+			continue
+		}
+
+		stepStart := time.Now()
+		output, err := r.runStep(ctx, s, step, vars)
+		res.Duration = time.Since(stepStart)
+		res.Output = output
+
+		if err != nil {
+			res.Status = StatusFailed
+			res.Error = err.Error()
+			report.Passed = false
+		} else {
+			res.Status = StatusPassed
+			if step.Save != "" {
+				vars[step.Save] = output
+			}
+		}
+		report.Steps = append(report.Steps, res)
+	}
+
+	report.Duration = time.Since(start)
+	return report
+}
+
+func (r *Runner) runStep(ctx context.Context, s *Scenario, step Step, vars map[string]string) (string, error) {
+	step, err := interpolate(step, vars)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case step.StoreContract != nil:
+		return r.storeContract(ctx, s, step.StoreContract)
+	case step.InstantiateContract != nil:
+		return r.instantiateContract(ctx, step.InstantiateContract)
+	case step.ExecuteContract != nil:
+		return r.executeContract(ctx, step.ExecuteContract)
+	case step.BankSend != nil:
+		return "", r.bankSend(ctx, step.BankSend)
+	case step.IBCTransfer != nil:
+		return r.ibcTransfer(ctx, step.IBCTransfer)
+	case step.Bin != nil:
+		out, err := r.client.Chain(step.Bin.ChainID).Bin(ctx, step.Bin.Cmd)
+		return strings.TrimSpace(string(out)), err
+	case step.RelayerExec != nil:
+		out, err := r.client.Chain(step.RelayerExec.ChainID).Relayer().Exec(ctx, step.RelayerExec.Cmd)
+		return strings.TrimSpace(string(out)), err
+	case step.WaitBlocks != nil:
+		return "", r.client.Chain(step.WaitBlocks.ChainID).WaitForBlocks(ctx, uint64(step.WaitBlocks.Blocks))
+	case step.AssertBalance != nil:
+		return "", retry(ctx, step.AssertBalance.Timeout, func(ctx context.Context) error {
+			return r.assertBalance(ctx, step.AssertBalance)
+		})
+	case step.AssertQuery != nil:
+		return "", retry(ctx, step.AssertQuery.Timeout, func(ctx context.Context) error {
+			return r.assertQuery(ctx, step.AssertQuery)
+		})
+	}
+	return "", fmt.Errorf("step has no action")
+}
+
+func (r *Runner) storeContract(ctx context.Context, s *Scenario, sc *StoreContract) (string, error) {
+	file := sc.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(s.dir, file)
+	}
+
+	return r.client.Chain(sc.ChainID).StoreContract(ctx, sc.KeyName, file)
+}
+
+func (r *Runner) instantiateContract(ctx context.Context, ic *InstantiateContract) (string, error) {
+	return r.client.Chain(ic.ChainID).InstantiateContract(ctx, handlers.WasmInstantiateRequest{
+		KeyName: ic.KeyName,
+		CodeID:  ic.CodeID,
+		Msg:     ic.Msg,
+		Admin:   ic.Admin,
+		NoAdmin: ic.NoAdmin,
+		Funds:   ic.Funds,
+	})
+}
+
+func (r *Runner) executeContract(ctx context.Context, ec *ExecuteContract) (string, error) {
+	res, err := r.client.Chain(ec.ChainID).ExecuteContract(ctx, handlers.WasmExecuteRequest{
+		KeyName:         ec.KeyName,
+		ContractAddress: ec.Contract,
+		Msg:             ec.Msg,
+		Funds:           ec.Funds,
+	})
+	if err != nil {
+		return "", err
+	}
+	if res.Code != 0 {
+		return res.TxHash, fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return res.TxHash, nil
+}
+
+func (r *Runner) bankSend(ctx context.Context, bs *BankSend) error {
+	_, err := r.client.Chain(bs.ChainID).BankSend(ctx, handlers.BankSendRequest{
+		KeyName:   bs.KeyName,
+		ToAddress: bs.ToAddress,
+		Amount:    bs.Amount,
+		Denom:     bs.Denom,
+	})
+	return err
+}
+
+func (r *Runner) ibcTransfer(ctx context.Context, it *IBCTransfer) (string, error) {
+	res, err := r.client.Chain(it.ChainID).IBCTransfer(ctx, handlers.IBCTransferRequest{
+		KeyName:   it.KeyName,
+		ChannelID: it.ChannelID,
+		ToAddress: it.ToAddress,
+		Amount:    it.Amount,
+		Denom:     it.Denom,
+		Memo:      it.Memo,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.TxHash, nil
+}
+
+func (r *Runner) assertBalance(ctx context.Context, ab *AssertBalance) error {
+	if ab.Equals == "" && ab.GTE == "" && ab.LTE == "" {
+		return fmt.Errorf("one of equals, gte or lte is required")
+	}
+
+	chain := r.client.Chain(ab.ChainID)
+	address := ab.Address
+	if address == "" {
+		var err error
+		if address, err = chain.Key(ctx, ab.KeyName); err != nil {
+			return err
+		}
+	}
+
+	balances, err := chain.Balances(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	balance := math.ZeroInt()
+	for _, c := range balances {
+		if c.Denom == ab.Denom {
+			amt, ok := math.NewIntFromString(c.Amount)
+			if !ok {
+				return fmt.Errorf("invalid balance %s%s", c.Amount, c.Denom)
+			}
+			balance = amt
+		}
+	}
+
+	for _, check := range []struct {
+		op, want string
+		ok       func(want math.Int) bool
+	}{
+		{"equal to", ab.Equals, balance.Equal},
+		{">=", ab.GTE, balance.GTE},
+		{"<=", ab.LTE, balance.LTE},
+	} {
+		if check.want == "" {
+			continue
+		}
+		want, ok := math.NewIntFromString(check.want)
+		if !ok {
+			return fmt.Errorf("invalid amount %q", check.want)
+		}
+		if !check.ok(want) {
+			return fmt.Errorf("balance of %s is %s%s, expected %s %s", address, balance, ab.Denom, check.op, want)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) assertQuery(ctx context.Context, aq *AssertQuery) error {
+	data, err := r.client.Chain(aq.ChainID).QueryContract(ctx, handlers.WasmQueryRequest{
+		ContractAddress: aq.Contract,
+		Msg:             aq.Msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	var got, want any
+	if err := json.Unmarshal(data, &got); err != nil {
+		return fmt.Errorf("invalid query result: %w", err)
+	}
+	if err := json.Unmarshal(aq.Expect, &want); err != nil {
+		return fmt.Errorf("invalid expect: %w", err)
+	}
+
+	if !contains(got, want) {
+		return fmt.Errorf("query result %s does not match %s", compact(data), compact(aq.Expect))
+	}
+	return nil
+}
+
+// contains reports whether got matches want, where objects in want only need to match the keys they list.
+func contains(got, want any) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !contains(gv, wv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !contains(g[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(got, want)
+	}
+}
+
+func compact(bz []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, bz); err != nil {
+		return string(bz)
+	}
+	return buf.String()
+}
+
+// retry calls fn until it succeeds or timeout passes. fn is called once when timeout is empty.
+func retry(ctx context.Context, timeout string, fn func(ctx context.Context) error) error {
+	if timeout == "" {
+		return fn(ctx)
+	}
+
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", timeout, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	ticker := time.NewTicker(assertPollInterval)
+	defer ticker.Stop()
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (after %s)", err, timeout)
+		case <-ticker.C:
+		}
+	}
+}
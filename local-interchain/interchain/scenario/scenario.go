@@ -0,0 +1,286 @@
+// Package scenario runs declarative YAML or JSON scenario files against a running local-interchain API.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Scenario is a list of steps executed in order. Steps can save their result to a variable, which later
+// steps reference as ${name} in any string field.
+type Scenario struct {
+	Name  string            `json:"name"`
+	Vars  map[string]string `json:"vars,omitempty"`
+	Steps []Step            `json:"steps"`
+
+	// dir is where relative file paths are resolved from.
+	dir string
+}
+
+// Step is a single action or assertion. Exactly one of the action fields must be set.
+type Step struct {
+	Name string `json:"name,omitempty"`
+
+	// Save stores the step's result in a variable: the code id, contract address, tx hash or command output.
+	Save string `json:"save,omitempty"`
+
+	StoreContract       *StoreContract       `json:"store_contract,omitempty"`
+	InstantiateContract *InstantiateContract `json:"instantiate_contract,omitempty"`
+	ExecuteContract     *ExecuteContract     `json:"execute_contract,omitempty"`
+	BankSend            *BankSend            `json:"bank_send,omitempty"`
+	IBCTransfer         *IBCTransfer         `json:"ibc_transfer,omitempty"`
+	Bin                 *Bin                 `json:"bin,omitempty"`
+	RelayerExec         *RelayerExec         `json:"relayer_exec,omitempty"`
+	WaitBlocks          *WaitBlocks          `json:"wait_blocks,omitempty"`
+	AssertBalance       *AssertBalance       `json:"assert_balance,omitempty"`
+	AssertQuery         *AssertQuery         `json:"assert_query,omitempty"`
+}
+
+type StoreContract struct {
+	ChainID string `json:"chain_id"`
+	KeyName string `json:"key_name"`
+	// File is relative to the scenario file.
+	File string `json:"file"`
+}
+
+type InstantiateContract struct {
+	ChainID string          `json:"chain_id"`
+	KeyName string          `json:"key_name"`
+	CodeID  string          `json:"code_id"`
+	Msg     json.RawMessage `json:"msg"`
+	Admin   string          `json:"admin,omitempty"`
+	NoAdmin bool            `json:"no_admin,omitempty"`
+	Funds   string          `json:"funds,omitempty"`
+}
+
+type ExecuteContract struct {
+	ChainID  string          `json:"chain_id"`
+	KeyName  string          `json:"key_name"`
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+	Funds    string          `json:"funds,omitempty"`
+}
+
+type BankSend struct {
+	ChainID   string `json:"chain_id"`
+	KeyName   string `json:"key_name"`
+	ToAddress string `json:"to_address"`
+	Amount    string `json:"amount"`
+	Denom     string `json:"denom,omitempty"`
+}
+
+type IBCTransfer struct {
+	ChainID   string `json:"chain_id"`
+	KeyName   string `json:"key_name"`
+	ChannelID string `json:"channel_id"`
+	ToAddress string `json:"to_address"`
+	Amount    string `json:"amount"`
+	Denom     string `json:"denom,omitempty"`
+	Memo      string `json:"memo,omitempty"`
+}
+
+// Bin runs a command of the chain's binary, such as a tx which has no dedicated step.
+type Bin struct {
+	ChainID string `json:"chain_id"`
+	Cmd     string `json:"cmd"`
+}
+
+type RelayerExec struct {
+	ChainID string `json:"chain_id"`
+	Cmd     string `json:"cmd"`
+}
+
+type WaitBlocks struct {
+	ChainID string `json:"chain_id"`
+	Blocks  int    `json:"blocks"`
+}
+
+// AssertBalance checks the balance of an address, or of a key when KeyName is set.
+// At least one of Equals, GTE and LTE must be set.
+type AssertBalance struct {
+	ChainID string `json:"chain_id"`
+	Address string `json:"address,omitempty"`
+	KeyName string `json:"key_name,omitempty"`
+	Denom   string `json:"denom"`
+	Equals  string `json:"equals,omitempty"`
+	GTE     string `json:"gte,omitempty"`
+	LTE     string `json:"lte,omitempty"`
+
+	// Timeout retries the assertion until it passes, e.g. while waiting for an IBC packet to be relayed.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// AssertQuery smart queries a contract and checks the result contains Expect. Objects in Expect only
+// need to match the keys they list.
+type AssertQuery struct {
+	ChainID  string          `json:"chain_id"`
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+	Expect   json.RawMessage `json:"expect"`
+
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Kind is the name of the step's action.
+func (s Step) Kind() string {
+	kinds := s.kinds()
+	if len(kinds) != 1 {
+		return ""
+	}
+	return kinds[0]
+}
+
+func (s Step) kinds() []string {
+	var kinds []string
+	set := map[string]bool{
+		"store_contract":       s.StoreContract != nil,
+		"instantiate_contract": s.InstantiateContract != nil,
+		"execute_contract":     s.ExecuteContract != nil,
+		"bank_send":            s.BankSend != nil,
+		"ibc_transfer":         s.IBCTransfer != nil,
+		"bin":                  s.Bin != nil,
+		"relayer_exec":         s.RelayerExec != nil,
+		"wait_blocks":          s.WaitBlocks != nil,
+		"assert_balance":       s.AssertBalance != nil,
+		"assert_query":         s.AssertQuery != nil,
+	}
+	for _, k := range stepKinds {
+		if set[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+var stepKinds = []string{
+	"store_contract", "instantiate_contract", "execute_contract", "bank_send", "ibc_transfer",
+	"bin", "relayer_exec", "wait_blocks", "assert_balance", "assert_query",
+}
+
+// savesResult holds the kinds of steps which have a result to save.
+var savesResult = map[string]bool{
+	"store_contract":       true,
+	"instantiate_contract": true,
+	"execute_contract":     true,
+	"ibc_transfer":         true,
+	"bin":                  true,
+	"relayer_exec":         true,
+}
+
+// Title is the step's name, or its kind when it has none.
+func (s Step) Title() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Kind()
+}
+
+// Load reads a scenario from a .yaml, .yml or .json file.
+func Load(path string) (*Scenario, error) {
+	bz, err := readJSON(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Scenario
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+
+	if s.Name == "" {
+		s.Name = filepath.Base(path)
+	}
+	if s.dir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// IsScenario reports whether path is a YAML or JSON file with steps, rather than e.g. a chain config.
+func IsScenario(path string) bool {
+	bz, err := readJSON(path)
+	if err != nil {
+		return false
+	}
+
+	var s struct {
+		Steps json.RawMessage `json:"steps"`
+	}
+	return json.Unmarshal(bz, &s) == nil && len(s.Steps) > 0
+}
+
+// readJSON reads a YAML or JSON file. YAML is converted to JSON so both formats share the json tags.
+func readJSON(path string) ([]byte, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		if bz, err = yaml.YAMLToJSON(bz); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return bz, nil
+}
+
+// Validate checks every step has exactly one action.
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("no steps")
+	}
+	for i, step := range s.Steps {
+		switch kinds := step.kinds(); len(kinds) {
+		case 0:
+			return fmt.Errorf("step %d has no action, expected one of %v", i+1, stepKinds)
+		case 1:
+		default:
+			return fmt.Errorf("step %d has more than one action: %v", i+1, kinds)
+		}
+		if step.Save != "" && !savesResult[step.Kind()] {
+			return fmt.Errorf("step %d: %s has no result to save", i+1, step.Kind())
+		}
+	}
+	return nil
+}
+
+var varRef = regexp.MustCompile(`\$\{([a-zA-Z0-9_.-]+)\}`)
+
+// interpolate replaces the ${name} references in every string of step with their values.
+func interpolate(step Step, vars map[string]string) (Step, error) {
+	bz, err := json.Marshal(step)
+	if err != nil {
+		return step, err
+	}
+
+	var missing []string
+	bz = varRef.ReplaceAllFunc(bz, func(ref []byte) []byte {
+		name := string(varRef.FindSubmatch(ref)[1])
+		val, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return ref
+		}
+
+		// References are always inside a JSON string, so the value is escaped without its quotes.
+		escaped, _ := json.Marshal(val)
+		return escaped[1 : len(escaped)-1]
+	})
+	if len(missing) > 0 {
+		return step, fmt.Errorf("undefined variables %v", missing)
+	}
+
+	var out Step
+	if err := json.Unmarshal(bz, &out); err != nil {
+		return step, err
+	}
+	return out, nil
+}
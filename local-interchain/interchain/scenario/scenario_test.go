@@ -0,0 +1,114 @@
+package scenario
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	wait := &WaitBlocks{ChainID: "localjuno-1", Blocks: 1}
+	bin := &Bin{ChainID: "localjuno-1", Cmd: "status"}
+
+	for _, tt := range []struct {
+		name    string
+		steps   []Step
+		wantErr string
+	}{
+		{"one action per step", []Step{{WaitBlocks: wait}, {Bin: bin, Save: "status"}}, ""},
+		{"no steps", nil, "no steps"},
+		{"no action", []Step{{WaitBlocks: wait}, {Name: "empty"}}, "step 2 has no action"},
+		{"several actions", []Step{{WaitBlocks: wait, Bin: bin}}, "step 1 has more than one action: [bin wait_blocks]"},
+		{"nothing to save", []Step{{WaitBlocks: wait, Save: "height"}}, "step 1: wait_blocks has no result to save"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Scenario{Steps: tt.steps}
+			err := s.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_Examples(t *testing.T) {
+	paths, err := filepath.Glob("../../scenarios/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		s, err := Load(path)
+		require.NoError(t, err, path)
+		require.NotEmpty(t, s.Name)
+		require.True(t, filepath.IsAbs(s.dir))
+	}
+}
+
+func TestIsScenario(t *testing.T) {
+	require.True(t, IsScenario("../../scenarios/ibc_transfer.yaml"))
+	require.False(t, IsScenario("../../chains/juno_ibc.json"))
+	require.False(t, IsScenario("../../scenarios/missing.yaml"))
+}
+
+func TestInterpolate(t *testing.T) {
+	vars := map[string]string{
+		"contract": "juno1contract",
+		"memo":     `say "hi"`,
+		"code.id":  "7",
+	}
+
+	step := Step{
+		Name: "execute ${code.id}",
+		ExecuteContract: &ExecuteContract{
+			ChainID:  "localjuno-1",
+			Contract: "${contract}",
+			Msg:      json.RawMessage(`{"note":"${memo}","ids":["${code.id}"]}`),
+		},
+	}
+	out, err := interpolate(step, vars)
+	require.NoError(t, err)
+	require.Equal(t, "execute 7", out.Name)
+	require.Equal(t, "juno1contract", out.ExecuteContract.Contract)
+	require.JSONEq(t, `{"note":"say \"hi\"","ids":["7"]}`, string(out.ExecuteContract.Msg))
+
+	// The step is not modified.
+	require.Equal(t, "${contract}", step.ExecuteContract.Contract)
+
+	_, err = interpolate(Step{Bin: &Bin{Cmd: "query ${a} ${contract} ${b}"}}, vars)
+	require.EqualError(t, err, "undefined variables [a b]")
+}
+
+func TestContains(t *testing.T) {
+	got := map[string]any{
+		"count": float64(3),
+		"owner": "juno1owner",
+		"items": []any{"a", map[string]any{"id": float64(1), "name": "one"}},
+	}
+
+	for _, tt := range []struct {
+		name string
+		want any
+		ok   bool
+	}{
+		{"equal", got, true},
+		{"subset of keys", map[string]any{"count": float64(3)}, true},
+		{"nested subset", map[string]any{"items": []any{"a", map[string]any{"id": float64(1)}}}, true},
+		{"different value", map[string]any{"count": float64(4)}, false},
+		{"missing key", map[string]any{"admin": "juno1owner"}, false},
+		{"array length", map[string]any{"items": []any{"a"}}, false},
+		{"array order", map[string]any{"items": []any{map[string]any{"id": float64(1)}, "a"}}, false},
+		{"object for scalar", map[string]any{"owner": map[string]any{}}, false},
+		{"scalar", float64(3), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.ok, contains(got, tt.want))
+		})
+	}
+
+	require.True(t, contains("juno1owner", "juno1owner"))
+	require.False(t, contains([]any{"a"}, map[string]any{}))
+}
@@ -0,0 +1,113 @@
+package interchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/localinterchain/interchain/types"
+)
+
+// StartSidecars starts the sidecars of the chains in config, which are in the same order as chains.
+// running are all the chains whose addresses the sidecars can reference, along with their deployed contracts.
+func StartSidecars(
+	ctx context.Context,
+	installDir, testName string,
+	cli *client.Client,
+	network string,
+	config *types.Config,
+	chains []ibc.Chain,
+	running []ibc.Chain,
+	contracts map[string]map[string]string,
+) error {
+	placeholders := sidecarPlaceholders(running, contracts)
+
+	for idx, cfg := range config.Chains {
+		if len(cfg.Sidecars) == 0 {
+			continue
+		}
+		chain, ok := chains[idx].(*cosmos.CosmosChain)
+		if !ok {
+			return fmt.Errorf("sidecars are only supported on cosmos chains, %s is not one", cfg.ChainID)
+		}
+
+		for _, sc := range cfg.Sidecars {
+			if err := startSidecar(ctx, installDir, testName, cli, network, chain, sc, placeholders); err != nil {
+				return fmt.Errorf("failed to start sidecar %s of %s: %w", sc.Name, cfg.ChainID, err)
+			}
+			log.Println("Started sidecar", cfg.ChainID, sc.Name)
+		}
+	}
+	return nil
+}
+
+func startSidecar(
+	ctx context.Context,
+	installDir, testName string,
+	cli *client.Client,
+	network string,
+	chain *cosmos.CosmosChain,
+	sc types.Sidecar,
+	placeholders *strings.Replacer,
+) error {
+	cmd := make([]string, len(sc.StartCmd))
+	for i, arg := range sc.StartCmd {
+		cmd[i] = placeholders.Replace(arg)
+	}
+
+	image := ibc.DockerImage{Repository: sc.Image.Repository, Version: sc.Image.Version, UidGid: sc.Image.UidGid}
+	if err := chain.NewSidecarProcess(ctx, false, sc.Name, testName, cli, network, image, sc.HomeDir, len(chain.Sidecars), sc.Ports, cmd); err != nil {
+		return err
+	}
+	s := chain.Sidecars[len(chain.Sidecars)-1]
+
+	for dst, src := range sc.Files {
+		bz, err := os.ReadFile(filepath.Join(installDir, src))
+		if err != nil {
+			return err
+		}
+		if err := s.WriteFile(ctx, []byte(placeholders.Replace(string(bz))), dst); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dst, err)
+		}
+	}
+
+	if err := s.CreateContainer(ctx); err != nil {
+		return err
+	}
+	return s.StartContainer(ctx)
+}
+
+// sidecarPlaceholders replaces %RPC:<chain_id>%, %GRPC:<chain_id>%, %HOST:<chain_id>% and
+// %CONTRACT:<chain_id>:<name>% with the addresses of chains and contracts in the docker network.
+func sidecarPlaceholders(chains []ibc.Chain, contracts map[string]map[string]string) *strings.Replacer {
+	var oldnew []string
+	for _, chain := range chains {
+		chainID := chain.Config().ChainID
+		rpc := chain.GetRPCAddress()
+		oldnew = append(oldnew,
+			"%RPC:"+chainID+"%", rpc,
+			"%GRPC:"+chainID+"%", chain.GetGRPCAddress(),
+		)
+		if u, err := url.Parse(rpc); err == nil {
+			oldnew = append(oldnew, "%HOST:"+chainID+"%", u.Hostname())
+		}
+
+		names := make([]string, 0, len(contracts[chainID]))
+		for name := range contracts[chainID] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			oldnew = append(oldnew, fmt.Sprintf("%%CONTRACT:%s:%s%%", chainID, name), contracts[chainID][name])
+		}
+	}
+	return strings.NewReplacer(oldnew...)
+}
@@ -0,0 +1,36 @@
+package interchain
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// addrChain implements the addresses of ibc.Chain, calling any other method panics.
+type addrChain struct {
+	ibc.Chain
+
+	chainID   string
+	rpc, grpc string
+}
+
+func (c addrChain) Config() ibc.ChainConfig { return ibc.ChainConfig{ChainID: c.chainID} }
+func (c addrChain) GetRPCAddress() string   { return c.rpc }
+func (c addrChain) GetGRPCAddress() string  { return c.grpc }
+
+func TestSidecarPlaceholders(t *testing.T) {
+	chains := []ibc.Chain{
+		addrChain{chainID: "puppy-1", rpc: "http://puppy-1-fn-0-ic:26657", grpc: "puppy-1-fn-0-ic:9090"},
+		addrChain{chainID: "31337", rpc: "http://anvil-31337-ic:8545"},
+	}
+	contracts := map[string]map[string]string{
+		"31337": {"SimpleStorage": "0x5FbDB2315678afecb367f032d93F642f64180aa3"},
+	}
+
+	r := sidecarPlaceholders(chains, contracts)
+	require.Equal(t,
+		"http://puppy-1-fn-0-ic:26657 puppy-1-fn-0-ic:9090 ws://anvil-31337-ic:8545 0x5FbDB2315678afecb367f032d93F642f64180aa3 %RPC:other-1%",
+		r.Replace("%RPC:puppy-1% %GRPC:puppy-1% ws://%HOST:31337%:8545 %CONTRACT:31337:SimpleStorage% %RPC:other-1%"),
+	)
+}
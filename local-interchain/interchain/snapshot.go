@@ -0,0 +1,318 @@
+package interchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	types "github.com/strangelove-ventures/localinterchain/interchain/types"
+	"golang.org/x/sync/errgroup"
+)
+
+const snapshotManifestFile = "snapshot.json"
+
+var validSnapshotName = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// homeArchiver is implemented by relayers whose home directory can be saved and restored.
+type homeArchiver interface {
+	ExportHome(ctx context.Context, w io.Writer) error
+	ImportHome(ctx context.Context, r io.Reader) error
+}
+
+// SnapshotDir is the directory a snapshot named name is stored in.
+func SnapshotDir(installDir, name string) string {
+	return filepath.Join(installDir, "snapshots", name)
+}
+
+// Snapshot saves every node's home, the relayer's home, the anvil state of ethereum chains and the
+// IBC channels to installDir/snapshots/<name>. Nodes are stopped while their homes are copied, so their databases
+// are consistent, and started again afterwards. Sidecars from the config are not saved, they start again on restore.
+// It returns the snapshot directory.
+func (e *Environment) Snapshot(ctx context.Context, name string) (string, error) {
+	if !validSnapshotName.MatchString(name) {
+		return "", fmt.Errorf("invalid snapshot name %q, only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	dir := SnapshotDir(e.installDir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("snapshot %s already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	snapshot := types.Snapshot{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		TestName:  e.testName,
+		Config:    e.config,
+		Channels:  e.channels,
+		Contracts: e.contracts,
+	}
+
+	err := e.saveSnapshot(ctx, dir, &snapshot)
+	if err == nil {
+		err = writeSnapshotManifest(dir, snapshot)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+
+	log.Println("Saved snapshot", name, "to", dir)
+	return dir, nil
+}
+
+func (e *Environment) saveSnapshot(ctx context.Context, dir string, snapshot *types.Snapshot) (err error) {
+	// Stop relaying so the chains and the relayer's view of them stay consistent.
+	if e.relayer != nil && len(e.ibcpaths) > 0 {
+		if err := e.relayer.StopRelayer(ctx, e.eRep); err != nil {
+			return fmt.Errorf("failed to stop relayer: %w", err)
+		}
+		defer func() {
+			if startErr := e.relayer.StartRelayer(ctx, e.eRep, e.relayerPaths()...); startErr != nil && err == nil {
+				err = fmt.Errorf("failed to restart relayer: %w", startErr)
+			}
+		}()
+	}
+
+	for _, chain := range e.chains {
+		chainID := chain.Config().ChainID
+		chainDir := filepath.Join(dir, chainID)
+		if err := os.MkdirAll(chainDir, 0755); err != nil {
+			return err
+		}
+
+		sc := types.SnapshotChain{ChainID: chainID}
+		switch c := chain.(type) {
+		case *cosmos.CosmosChain:
+			if sc.Nodes, err = snapshotCosmosChain(ctx, dir, chainDir, c); err != nil {
+				return fmt.Errorf("failed to snapshot %s: %w", chainID, err)
+			}
+		case *ethereum.EthereumChain:
+			state, err := c.DumpState(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to dump anvil state of %s: %w", chainID, err)
+			}
+			sc.AnvilState = filepath.Join(chainID, "anvil-state.hex")
+			if err := os.WriteFile(filepath.Join(dir, sc.AnvilState), []byte(state), 0644); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("snapshots are not supported for chain %s of type %T", chainID, chain)
+		}
+		snapshot.Chains = append(snapshot.Chains, sc)
+	}
+
+	if e.relayer != nil {
+		archiver, ok := e.relayer.(homeArchiver)
+		if !ok {
+			return fmt.Errorf("snapshots are not supported for relayer %T", e.relayer)
+		}
+		snapshot.Relayer = "relayer.tar"
+		if err := writeArchive(filepath.Join(dir, snapshot.Relayer), func(w io.Writer) error {
+			return archiver.ExportHome(ctx, w)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshotCosmosChain stops every node of the chain, archives their homes, starts the nodes again
+// and returns the archive paths relative to dir.
+func snapshotCosmosChain(ctx context.Context, dir, chainDir string, c *cosmos.CosmosChain) (files []string, err error) {
+	nodes := c.Nodes()
+	for _, n := range nodes {
+		// Validator sidecars run from the chain config of interchaintest, which local-ic can not recreate.
+		if len(n.Sidecars) > 0 {
+			return nil, fmt.Errorf("snapshots are not supported for nodes with sidecars, %s has %d", n.Name(), len(n.Sidecars))
+		}
+	}
+
+	var stopped []*cosmos.ChainNode
+	defer func() {
+		// The nodes of a chain only make progress once a quorum of them runs, so they start together.
+		var eg errgroup.Group
+		for _, n := range stopped {
+			n := n
+			eg.Go(func() error {
+				return n.StartContainer(ctx)
+			})
+		}
+		if startErr := eg.Wait(); startErr != nil && err == nil {
+			err = fmt.Errorf("failed to start nodes again: %w", startErr)
+		}
+	}()
+	for _, n := range nodes {
+		if err := n.StopContainer(ctx); err != nil {
+			return nil, err
+		}
+		stopped = append(stopped, n)
+	}
+
+	files = make([]string, len(nodes))
+	var eg errgroup.Group
+	for i, n := range nodes {
+		i, n := i, n
+		files[i] = filepath.Join(filepath.Base(chainDir), n.Name()+".tar")
+		eg.Go(func() error {
+			return writeArchive(filepath.Join(dir, files[i]), func(w io.Writer) error {
+				return n.ExportHome(ctx, w)
+			})
+		})
+	}
+	return files, eg.Wait()
+}
+
+func writeArchive(path string, export func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := export(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readArchive(path string, restore func(r io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return restore(f)
+}
+
+func writeSnapshotManifest(dir string, snapshot types.Snapshot) error {
+	bz, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, snapshotManifestFile), bz, 0644)
+}
+
+// LoadSnapshot reads the manifest of the snapshot named name.
+func LoadSnapshot(installDir, name string) (*types.Snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(SnapshotDir(installDir, name), snapshotManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", name, err)
+	}
+
+	var snapshot types.Snapshot
+	if err := json.Unmarshal(bz, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", name, err)
+	}
+	return &snapshot, nil
+}
+
+// RestoreSnapshot creates new containers for chains, which must be built from the snapshot's config, and loads
+// their state from the snapshot instead of running genesis. The relayer's home is restored but it is not started.
+func RestoreSnapshot(
+	ctx context.Context,
+	installDir string,
+	snapshot *types.Snapshot,
+	chains []ibc.Chain,
+	relayer ibc.Relayer,
+	cli *client.Client,
+	networkID string,
+) error {
+	dir := SnapshotDir(installDir, snapshot.Name)
+
+	if len(chains) != len(snapshot.Chains) {
+		return fmt.Errorf("snapshot %s has %d chains, config has %d", snapshot.Name, len(snapshot.Chains), len(chains))
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, chain := range chains {
+		chain, sc := chain, snapshot.Chains[i]
+		if chainID := chain.Config().ChainID; chainID != sc.ChainID {
+			return fmt.Errorf("snapshot chain %d is %s, config has %s", i, sc.ChainID, chainID)
+		}
+
+		eg.Go(func() error {
+			if err := chain.Initialize(egCtx, snapshot.TestName, cli, networkID); err != nil {
+				return fmt.Errorf("failed to initialize %s: %w", sc.ChainID, err)
+			}
+
+			switch c := chain.(type) {
+			case *cosmos.CosmosChain:
+				return restoreCosmosChain(egCtx, dir, c, sc)
+			case *ethereum.EthereumChain:
+				return restoreEthereumChain(egCtx, dir, snapshot.TestName, c, sc)
+			default:
+				return fmt.Errorf("snapshots are not supported for chain %s of type %T", sc.ChainID, chain)
+			}
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if relayer != nil && snapshot.Relayer != "" {
+		archiver, ok := relayer.(homeArchiver)
+		if !ok {
+			return fmt.Errorf("snapshots are not supported for relayer %T", relayer)
+		}
+		if err := readArchive(filepath.Join(dir, snapshot.Relayer), func(r io.Reader) error {
+			return archiver.ImportHome(ctx, r)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func restoreCosmosChain(ctx context.Context, dir string, c *cosmos.CosmosChain, sc types.SnapshotChain) error {
+	nodes := c.Nodes()
+	if len(nodes) != len(sc.Nodes) {
+		return fmt.Errorf("snapshot of %s has %d nodes, config has %d", sc.ChainID, len(sc.Nodes), len(nodes))
+	}
+
+	for i, n := range nodes {
+		n := n
+		if err := readArchive(filepath.Join(dir, sc.Nodes[i]), func(r io.Reader) error {
+			return n.ImportHome(ctx, r)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fmt.Errorf("failed to start %s: %w", sc.ChainID, err)
+	}
+	return testutil.WaitForBlocks(ctx, 2, c)
+}
+
+func restoreEthereumChain(ctx context.Context, dir, testName string, c *ethereum.EthereumChain, sc types.SnapshotChain) error {
+	state, err := os.ReadFile(filepath.Join(dir, sc.AnvilState))
+	if err != nil {
+		return err
+	}
+
+	if err := c.Start(testName, ctx); err != nil {
+		return fmt.Errorf("failed to start %s: %w", sc.ChainID, err)
+	}
+	if err := c.LoadState(ctx, string(state)); err != nil {
+		return fmt.Errorf("failed to load anvil state of %s: %w", sc.ChainID, err)
+	}
+	return nil
+}
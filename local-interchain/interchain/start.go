@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/strangelove-ventures/interchaintest/v7"
 	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/ethereum"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	interchaintestrelayer "github.com/strangelove-ventures/interchaintest/v7/relayer"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
-	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"github.com/strangelove-ventures/localinterchain/interchain/router"
 	"github.com/strangelove-ventures/localinterchain/interchain/types"
 	"go.uber.org/zap"
@@ -22,6 +25,9 @@ import (
 type AppConfig struct {
 	Address string
 	Port    uint16
+
+	// FromSnapshot is the name of a snapshot to restore instead of starting the chains from genesis.
+	FromSnapshot string
 }
 
 func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
@@ -54,32 +60,40 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 		panic(err)
 	}
 
-	config, err := LoadConfig(installDir, chainCfgFile)
-	if err != nil {
-		// try again with .json, then if it still fails - panic
-		config, err = LoadConfig(installDir, chainCfgFile+".json")
+	var snapshot *types.Snapshot
+	var config *types.Config
+	if ac.FromSnapshot != "" {
+		// The snapshot holds the config the environment was started with.
+		snapshot, err = LoadSnapshot(installDir, ac.FromSnapshot)
 		if err != nil {
-			panic(err)
+			log.Fatal("LoadSnapshot ", err)
+		}
+		config = snapshot.Config
+	} else {
+		config, err = LoadConfig(installDir, chainCfgFile)
+		if err != nil {
+			// try again with .json, then if it still fails - panic
+			config, err = LoadConfig(installDir, chainCfgFile+".json")
+			if err != nil {
+				panic(err)
+			}
 		}
 	}
 
+	if err := LoadServerConfig(installDir, config); err != nil {
+		log.Fatal("LoadServerConfig ", err)
+	}
+
 	WriteRunningChains(installDir, []byte("{}"))
 
-	// ibc-path-name -> index of []cosmos.CosmosChain
-	ibcpaths := make(map[string][]int)
 	chainSpecs := []*interchaintest.ChainSpec{}
-
-	for idx, cfg := range config.Chains {
+	for _, cfg := range config.Chains {
 		_, chainSpec := CreateChainConfigs(cfg)
 		chainSpecs = append(chainSpecs, chainSpec)
-
-		if len(cfg.IBCPaths) > 0 {
-			for _, path := range cfg.IBCPaths {
-				ibcpaths[path] = append(ibcpaths[path], idx)
-			}
-		}
 	}
 
+	// ibc-path-name -> index of []cosmos.CosmosChain
+	ibcpaths := ibcPathsFromConfig(config)
 	if err := VerifyIBCPaths(ibcpaths); err != nil {
 		log.Fatal("VerifyIBCPaths", err)
 	}
@@ -89,6 +103,9 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 
 	// Get chains from the chain factory
 	name := strings.ReplaceAll(chainCfgFile, ".json", "") + "ic"
+	if snapshot != nil {
+		name = snapshot.TestName
+	}
 	chains, err := cf.Chains(name)
 	if err != nil {
 		log.Fatal("cf.Chains", err)
@@ -109,53 +126,58 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 
 	client, network := interchaintest.DockerSetup(fakeT)
 
-	// setup a relayer if we have IBC paths to use.
-	if len(ibcpaths) > 0 {
-		rlyCfg := config.Relayer
-
-		relayerType, relayerName := ibc.CosmosRly, "relay"
-		rf := interchaintest.NewBuiltinRelayerFactory(
-			relayerType,
-			logger,
-			interchaintestrelayer.CustomDockerImage(
-				rlyCfg.DockerImage.Repository,
-				rlyCfg.DockerImage.Version,
-				rlyCfg.DockerImage.UidGid,
-			),
-			interchaintestrelayer.StartupFlags(rlyCfg.StartupFlags...),
-		)
-
-		// This also just needs the name.
-		relayer = rf.Build(fakeT, client, network)
-		ic = ic.AddRelayer(relayer, relayerName)
-
-		// Add links between chains
-		LinkIBCPaths(ibcpaths, chains, ic, relayer)
-	}
-
-	// Build all chains & begin.
-	err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
-		TestName:         name,
-		Client:           client,
-		NetworkID:        network,
-		SkipPathCreation: false,
-		// BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
-	})
-	if err != nil {
-		logger.Fatal("ic.Build", zap.Error(err))
+	// The relayer is always set up so IBC paths can also be added while running.
+	rlyCfg := config.Relayer
+
+	relayerType, relayerName := ibc.CosmosRly, "relay"
+	rf := interchaintest.NewBuiltinRelayerFactory(
+		relayerType,
+		logger,
+		interchaintestrelayer.CustomDockerImage(
+			rlyCfg.DockerImage.Repository,
+			rlyCfg.DockerImage.Version,
+			rlyCfg.DockerImage.UidGid,
+		),
+		interchaintestrelayer.StartupFlags(rlyCfg.StartupFlags...),
+	)
+
+	// This also just needs the name.
+	relayer = rf.Build(fakeT, client, network)
+	ic = ic.AddRelayer(relayer, relayerName)
+
+	// Add links between chains
+	LinkIBCPaths(ibcpaths, chains, ic, relayer)
+
+	if snapshot != nil {
+		// Create new containers from the snapshot's volumes instead of running genesis.
+		if err := RestoreSnapshot(ctx, installDir, snapshot, chains, relayer, client, network); err != nil {
+			logger.Fatal("RestoreSnapshot", zap.Error(err))
+		}
+	} else {
+		// Build all chains & begin.
+		err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:         name,
+			Client:           client,
+			NetworkID:        network,
+			SkipPathCreation: false,
+			// BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		})
+		if err != nil {
+			logger.Fatal("ic.Build", zap.Error(err))
+		}
 	}
 
-	if relayer != nil && len(ibcpaths) > 0 {
+	if len(ibcpaths) > 0 {
 		paths := make([]string, 0, len(ibcpaths))
 		for k := range ibcpaths {
 			paths = append(paths, k)
 		}
 
 		relayer.StartRelayer(ctx, eRep, paths...)
-		defer func() {
-			relayer.StopRelayer(ctx, eRep)
-		}()
 	}
+	defer func() {
+		relayer.StopRelayer(ctx, eRep)
+	}()
 
 	for _, chain := range chains {
 		if cosmosChain, ok := chain.(*cosmos.CosmosChain); ok {
@@ -164,41 +186,84 @@ func StartChain(installDir, chainCfgFile string, ac *AppConfig) {
 		}
 	}
 
+	config.Server.Host = ac.Address
+	config.Server.Port = fmt.Sprintf("%d", ac.Port)
+	if config.Server.AuthToken == "" && !isLoopback(config.Server.Host) {
+		log.Printf("WARNING: the API on %s accepts any request. Set server.auth_token to require a token.\n", config.Server.Host)
+	}
+
+	env := NewEnvironment(installDir, name, config, logger, client, network, chains, relayer, eRep)
+
 	// Starts a non blocking REST server to take action on the chain.
 	go func() {
 		cosmosChains := map[string]*cosmos.CosmosChain{}
+		ethChains := map[string]*ethereum.EthereumChain{}
 		for _, chain := range chains {
-			if cosmosChain, ok := chain.(*cosmos.CosmosChain); ok {
-				cosmosChains[cosmosChain.Config().ChainID] = cosmosChain
+			switch c := chain.(type) {
+			case *cosmos.CosmosChain:
+				cosmosChains[c.Config().ChainID] = c
+			case *ethereum.EthereumChain:
+				ethChains[c.Config().ChainID] = c
 			}
 		}
 
-		r := router.NewRouter(ctx, ic, config, cosmosChains, vals, relayer, eRep, installDir)
+		r := router.NewRouter(ctx, ic, env, cosmosChains, ethChains, vals, relayer, eRep, installDir, env, env)
 
-		config.Server = types.RestServer{
-			Host: ac.Address,
-			Port: fmt.Sprintf("%d", ac.Port),
+		server := &http.Server{
+			Addr:    fmt.Sprintf("%s:%s", config.Server.Host, config.Server.Port),
+			Handler: router.Protect(r, config.Server),
 		}
 
-		server := fmt.Sprintf("%s:%s", config.Server.Host, config.Server.Port)
-		if err := http.ListenAndServe(server, r); err != nil {
+		var err error
+		if config.Server.TLS.Enabled() {
+			err = server.ListenAndServeTLS(config.Server.TLS.CertFile, config.Server.TLS.KeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
 			log.Default().Println(err)
 		}
 	}()
 
-	AddGenesisKeysToKeyring(ctx, config, chains)
+	var contracts map[string]map[string]string
+	var connections []types.IBCChannel
+	if snapshot != nil {
+		// Keys, startup commands and contracts are already part of the restored state.
+		contracts, connections = snapshot.Contracts, snapshot.Channels
+	} else {
+		AddGenesisKeysToKeyring(ctx, config, chains)
+
+		// run commands for each server after startup. Iterate chain configs
+		PostStartupCommands(ctx, config, chains)
 
-	// run commands for each server after startup. Iterate chain configs
-	PostStartupCommands(ctx, config, chains)
+		contracts, err = DeployContracts(ctx, installDir, config, chains)
+		if err != nil {
+			logger.Fatal("DeployContracts", zap.Error(err))
+		}
 
-	connections := GetChannelConnections(ctx, ibcpaths, chains, ic, relayer, eRep)
+		connections = GetChannelConnections(ctx, ibcpaths, chains, ic, relayer, eRep)
+	}
 
+	// Sidecars are not part of snapshots, they start again from the config.
+	if err := StartSidecars(ctx, installDir, name, client, network, config, chains, chains, contracts); err != nil {
+		logger.Fatal("StartSidecars", zap.Error(err))
+	}
 	// Save to logs.json file for runtime chain information.
-	DumpChainsInfoToLogs(installDir, config, chains, connections)
+	env.SetState(connections, contracts)
 
-	log.Println("\nLocal-IC API is running on ", fmt.Sprintf("http://%s:%s", config.Server.Host, config.Server.Port))
+	log.Println("\nLocal-IC API is running on ", fmt.Sprintf("%s://%s:%s", config.Server.Scheme(), config.Server.Host, config.Server.Port))
+
+	// Chains can be added and removed through the API, so run until interrupted rather than until the first chain stops.
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+}
 
-	if err = testutil.WaitForBlocks(ctx, math.MaxInt, chains[0]); err != nil {
-		log.Fatal("WaitForBlocks StartChain: ", err)
+// isLoopback reports whether host only accepts connections from this machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
 	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
@@ -28,8 +28,30 @@ type Chain struct {
 	NumberNode    int      `json:"number_node"`
 	IBCPaths      []string `json:"ibc_paths"`
 	Genesis       Genesis  `json:"genesis"`
+	Faucet        Faucet   `json:"faucet"`
+
+	// Sidecars are only supported on cosmos chains.
+	Sidecars []Sidecar `json:"sidecars"`
+}
+
+// Sidecar is a container started next to a cosmos chain once every chain is running, such as a bridge relayer.
+// %RPC:<chain_id>%, %GRPC:<chain_id>%, %HOST:<chain_id>% and %CONTRACT:<chain_id>:<name>% in StartCmd and in
+// the Files are replaced by the addresses of the running chains and their deployed contracts in the docker network.
+type Sidecar struct {
+	Name     string      `json:"name"`
+	Image    DockerImage `json:"image"`
+	HomeDir  string      `json:"home_dir"`
+	Ports    []string    `json:"ports"`
+	StartCmd []string    `json:"start_cmd"`
+	// Files maps paths in HomeDir to files relative to the local-interchain directory, copied before the sidecar starts.
+	Files map[string]string `json:"files"`
 }
 
+const (
+	ChainTypeCosmos   = "cosmos"
+	ChainTypeEthereum = "ethereum"
+)
+
 func (chain *Chain) Validate() error {
 	validate := validator.New()
 	return validate.Struct(chain)
@@ -37,7 +59,11 @@ func (chain *Chain) Validate() error {
 
 func (chain *Chain) SetChainDefaults() {
 	if chain.ChainType == "" {
-		chain.ChainType = "cosmos"
+		chain.ChainType = ChainTypeCosmos
+	}
+
+	if chain.ChainType == ChainTypeEthereum {
+		chain.setEthereumDefaults()
 	}
 
 	if chain.CoinType == 0 {
@@ -74,6 +100,17 @@ func (chain *Chain) SetChainDefaults() {
 	if chain.Genesis.Modify == nil {
 		chain.Genesis.Modify = []cosmos.GenesisKV{}
 	}
+	if chain.Genesis.Contracts == nil {
+		chain.Genesis.Contracts = []Contract{}
+	}
+
+	chain.Faucet.setDefaults(chain.ChainType)
+
+	for i := range chain.Sidecars {
+		if chain.Sidecars[i].Image.UidGid == "" {
+			chain.Sidecars[i].Image.UidGid = chain.DockerImage.UidGid
+		}
+	}
 
 	// TODO: Error here instead?
 	if chain.Binary == "" {
@@ -86,3 +123,38 @@ func (chain *Chain) SetChainDefaults() {
 		panic("'bech32_prefix' is required in your config for " + chain.ChainID)
 	}
 }
+
+// setEthereumDefaults fills in the anvil settings so an ethereum chain only needs a name and chain_type.
+func (chain *Chain) setEthereumDefaults() {
+	if chain.ChainID == "" {
+		chain.ChainID = "31337" // default anvil chain-id
+	}
+	if chain.Binary == "" {
+		chain.Binary = "anvil"
+	}
+	if chain.Denom == "" {
+		chain.Denom = "wei"
+	}
+	if chain.Bech32Prefix == "" {
+		chain.Bech32Prefix = "n/a"
+	}
+	if chain.CoinType == 0 {
+		chain.CoinType = 60
+	}
+	if chain.GasPrices == "" {
+		chain.GasPrices = "0"
+	}
+	if chain.TrustingPeriod == "" {
+		chain.TrustingPeriod = "0"
+	}
+	if chain.DockerImage.Repository == "" {
+		chain.DockerImage.Repository = "ghcr.io/foundry-rs/foundry"
+	}
+	if chain.DockerImage.Version == "" {
+		chain.DockerImage.Version = "latest"
+	}
+	// The foundry image runs as the foundry user rather than the cosmos default.
+	if chain.DockerImage.UidGid == "" {
+		chain.DockerImage.UidGid = "1000:1000"
+	}
+}
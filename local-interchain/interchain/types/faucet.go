@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+const (
+	// DefaultFaucetKeyName is the key every chain started by local-ic funds at genesis.
+	DefaultFaucetKeyName = "faucet"
+
+	DefaultFaucetCooldown = "1m"
+
+	// 100 tokens of a 6 decimal denom, or 100 ether.
+	defaultFaucetMaxAmount    = "100000000"
+	defaultEthFaucetMaxAmount = "100000000000000000000"
+)
+
+// Faucet limits what the /faucet endpoint sends to addresses on a chain.
+type Faucet struct {
+	Disabled bool `json:"disabled,omitempty"`
+
+	// KeyName signs the faucet's transactions. It must be the admin of any tokenfactory denom the faucet mints.
+	KeyName string `json:"key_name,omitempty"`
+
+	// MaxAmount is the most a single request may ask for, in the smallest unit of any denom.
+	MaxAmount string `json:"max_amount,omitempty"`
+
+	// Cooldown is how long an address waits between requests.
+	Cooldown string `json:"cooldown,omitempty"`
+}
+
+func (f *Faucet) setDefaults(chainType string) {
+	if f.KeyName == "" {
+		f.KeyName = DefaultFaucetKeyName
+	}
+	if f.MaxAmount == "" {
+		f.MaxAmount = defaultFaucetMaxAmount
+		if chainType == ChainTypeEthereum {
+			f.MaxAmount = defaultEthFaucetMaxAmount
+		}
+	}
+	if f.Cooldown == "" {
+		f.Cooldown = DefaultFaucetCooldown
+	}
+}
+
+// CooldownDuration parses Cooldown.
+func (f Faucet) CooldownDuration() (time.Duration, error) {
+	return time.ParseDuration(f.Cooldown)
+}
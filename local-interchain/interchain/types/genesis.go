@@ -9,6 +9,16 @@ type GenesisAccount struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+// Contract is a Solidity contract deployed to an ethereum chain once it has started.
+type Contract struct {
+	// Name of the contract within the source file.
+	Name string `json:"name"`
+	// Path of the source file, relative to the local-interchain directory.
+	Path string `json:"path"`
+	// Arguments passed to the contract's constructor.
+	ConstructorArgs []string `json:"constructor_args"`
+}
+
 type Genesis struct {
 	// Only apart of my fork for now.
 	Modify []cosmos.GenesisKV `json:"modify"` // 'key' & 'val' in the config.
@@ -18,4 +28,7 @@ type Genesis struct {
 	// A list of commands which run after chains are good to go.
 	// May need to move out of genesis into its own section? Seems silly though.
 	StartupCommands []string `json:"startup_commands"`
+
+	// Contracts to deploy after startup, ethereum chains only.
+	Contracts []Contract `json:"contracts"`
 }
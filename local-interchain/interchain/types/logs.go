@@ -13,4 +13,7 @@ type LogOutput struct {
 	RESTAddress string   `json:"rest_address"`
 	GRPCAddress string   `json:"grpc_address"`
 	IBCPath     []string `json:"ibc_paths"`
+
+	// Contracts maps the name of each deployed Solidity contract to its address, ethereum chains only.
+	Contracts map[string]string `json:"contracts,omitempty"`
 }
@@ -0,0 +1,31 @@
+package types
+
+import "time"
+
+// Snapshot describes a saved local-interchain environment. It is stored as snapshot.json next to the
+// archived volumes of each node and the relayer.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// TestName is the name the environment was started with. Restored containers reuse it so their
+	// hostnames, which are written in the node and relayer configs, do not change.
+	TestName string  `json:"test_name"`
+	Config   *Config `json:"config"`
+
+	Chains    []SnapshotChain              `json:"chains"`
+	Relayer   string                       `json:"relayer,omitempty"`
+	Channels  []IBCChannel                 `json:"ibc_channels"`
+	Contracts map[string]map[string]string `json:"contracts,omitempty"`
+}
+
+// SnapshotChain lists the files holding a chain's state, relative to the snapshot directory.
+type SnapshotChain struct {
+	ChainID string `json:"chain_id"`
+
+	// Nodes are tar archives of each node's home, validators first, in the order of CosmosChain.Nodes.
+	Nodes []string `json:"nodes,omitempty"`
+
+	// AnvilState is the hex encoded output of anvil_dumpState.
+	AnvilState string `json:"anvil_state,omitempty"`
+}
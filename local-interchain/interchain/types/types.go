@@ -13,6 +13,41 @@ type Config struct {
 type RestServer struct {
 	Host string `json:"host"`
 	Port string `json:"port"`
+
+	// AuthToken, when set, must be sent with every request as `Authorization: Bearer <token>`,
+	// or as the token query parameter for browsers and websockets.
+	AuthToken string `json:"auth_token,omitempty"`
+	// ReadOnly only serves the GET routes, the v1 wasm query and the query, get_channels and
+	// dump-contract-state actions. Every other request is refused.
+	ReadOnly bool `json:"read_only,omitempty"`
+	CORS     CORS `json:"cors"`
+	TLS      TLS  `json:"tls"`
+}
+
+// CORS allows browsers on other origins to call the API.
+type CORS struct {
+	// AllowedOrigins such as "http://localhost:3000", or "*" for any origin. CORS is off when empty.
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	// AllowedHeaders are added to the Authorization, Content-Type and Upload-Type headers.
+	AllowedHeaders []string `json:"allowed_headers,omitempty"`
+}
+
+// TLS serves the API over https when both files are set.
+type TLS struct {
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+// Scheme is the URL scheme the API is served with.
+func (s RestServer) Scheme() string {
+	if s.TLS.Enabled() {
+		return "https"
+	}
+	return "http"
 }
 
 type DockerImage struct {
@@ -23,5 +58,6 @@ type DockerImage struct {
 
 type IBCChannel struct {
 	ChainID string             `json:"chain_id"`
+	Path    string             `json:"path,omitempty"`
 	Channel *ibc.ChannelOutput `json:"channel"`
 }
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 )
@@ -14,3 +15,12 @@ func Write(w http.ResponseWriter, bz []byte) {
 func WriteError(w http.ResponseWriter, err error) {
 	Write(w, []byte(`{"error": "`+err.Error()+`"}`))
 }
+
+// WriteJSON writes v as a JSON response with the given status code.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Default().Println(err)
+	}
+}
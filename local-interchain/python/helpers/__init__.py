@@ -2,3 +2,4 @@
 
 from .cosmwasm import CosmWasm
 from .transactions import *
+from .events import wait_for_blocks, wait_for_event, wait_for_tx
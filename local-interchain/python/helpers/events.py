@@ -0,0 +1,77 @@
+import json
+import time
+from typing import Callable
+from urllib.parse import urlencode
+
+from websockets.sync.client import connect
+
+
+def events_url(api_url: str) -> str:
+    return api_url.replace("http://", "ws://", 1).replace("https://", "wss://", 1) + "/ws/events"
+
+
+def wait_for_event(
+    api_url: str,
+    match: Callable[[dict], bool] = lambda _: True,
+    chain_ids: list[str] | None = None,
+    types: list[str] | None = None,
+    event_types: list[str] | None = None,
+    contract_address: str = "",
+    timeout: float = 60,
+) -> dict:
+    """Returns the first message streamed by the server which passes the filters and match.
+    The filters are sent in the query string, so they apply from the first message streamed."""
+    deadline = time.monotonic() + timeout
+
+    params: dict[str, list[str] | str] = {
+        "chain_id": chain_ids or [],
+        "type": types or [],
+        "event_type": event_types or [],
+    }
+    if contract_address:
+        params["contract_address"] = contract_address
+
+    url = events_url(api_url)
+    query = urlencode(params, doseq=True)
+    if query:
+        url += "?" + query
+
+    with connect(url) as ws:
+        while True:
+            remaining = deadline - time.monotonic()
+            if remaining <= 0:
+                break
+
+            try:
+                msg = json.loads(ws.recv(timeout=remaining))
+            except TimeoutError:
+                break
+
+            if match(msg):
+                return msg
+
+    raise TimeoutError(f"no matching event within {timeout} seconds")
+
+
+def wait_for_blocks(api_url: str, chain_id: str, blocks: int = 1, timeout: float = 60) -> int:
+    """Waits for the chain to produce blocks and returns the last height."""
+    seen: list[int] = []
+
+    def counted(msg: dict) -> bool:
+        seen.append(msg.get("height", 0))
+        return len(seen) >= blocks
+
+    msg = wait_for_event(api_url, counted, chain_ids=[chain_id], types=["block"], timeout=timeout)
+    return msg["height"]
+
+
+def wait_for_tx(api_url: str, chain_id: str, tx_hash: str, timeout: float = 60) -> dict:
+    """Waits for the transaction to be included in a block.
+    Only blocks produced after the call are seen, so start waiting before the tx can be included."""
+    return wait_for_event(
+        api_url,
+        lambda msg: msg.get("tx_hash", "").upper() == tx_hash.upper(),
+        chain_ids=[chain_id],
+        types=["tx"],
+        timeout=timeout,
+    )
@@ -40,4 +40,10 @@ pub enum LocalError {
 
     #[error("Could not get filesystem files. {error}")]
     GetFilesError { error: String },
+
+    #[error("event stream failed. reason: {reason}")]
+    EventStreamFailed { reason: String },
+
+    #[error("no matching event within {seconds} seconds.")]
+    EventTimeout { seconds: u64 },
 }
@@ -0,0 +1,190 @@
+// ref: handlers/events.go
+use std::net::TcpStream;
+use std::time::{Duration, Instant};
+
+use reqwest::Url;
+use serde_json::Value;
+use tungstenite::{stream::MaybeTlsStream, Message, WebSocket};
+
+use crate::errors::LocalError;
+
+/// Selects the messages streamed by the `/ws/events` endpoint. Empty fields match everything.
+#[derive(Debug, Default, Clone)]
+pub struct EventFilter {
+    pub chain_ids: Vec<String>,
+    /// Message types: `block`, `tx`, `event` or `packet`.
+    pub types: Vec<String>,
+    /// ABCI event types such as `wasm` or `recv_packet`.
+    pub event_types: Vec<String>,
+    pub contract_address: String,
+}
+
+impl EventFilter {
+    /// Returns the websocket URL of the events endpoint with the filter in its query string,
+    /// so that it applies from the first message streamed.
+    ///
+    /// # Errors
+    ///
+    /// If `api_url` is not a valid URL.
+    pub fn url(&self, api_url: &str) -> Result<Url, LocalError> {
+        let ws_url = api_url
+            .replacen("http://", "ws://", 1)
+            .replacen("https://", "wss://", 1);
+        let mut url = Url::parse(&format!("{}/ws/events", ws_url.trim_end_matches('/')))
+            .map_err(|e| stream_error(&e))?;
+
+        let mut params: Vec<(&str, &str)> = Vec::new();
+        params.extend(self.chain_ids.iter().map(|v| ("chain_id", v.as_str())));
+        params.extend(self.types.iter().map(|v| ("type", v.as_str())));
+        params.extend(self.event_types.iter().map(|v| ("event_type", v.as_str())));
+        if !self.contract_address.is_empty() {
+            params.push(("contract_address", self.contract_address.as_str()));
+        }
+        if !params.is_empty() {
+            url.query_pairs_mut().extend_pairs(params);
+        }
+        Ok(url)
+    }
+}
+
+fn stream_error(e: &impl ToString) -> LocalError {
+    LocalError::EventStreamFailed {
+        reason: e.to_string(),
+    }
+}
+
+fn set_read_timeout(
+    socket: &mut WebSocket<MaybeTlsStream<TcpStream>>,
+    timeout: Duration,
+) -> Result<(), LocalError> {
+    if let MaybeTlsStream::Plain(stream) = socket.get_mut() {
+        stream
+            .set_read_timeout(Some(timeout))
+            .map_err(|e| stream_error(&e))?;
+    }
+    Ok(())
+}
+
+/// Returns the first message streamed by the server which passes the filter and `matches`.
+///
+/// # Errors
+///
+/// If the connection fails, or no message matches within `timeout`.
+pub fn wait_for_event(
+    api_url: &str,
+    filter: &EventFilter,
+    timeout: Duration,
+    mut matches: impl FnMut(&Value) -> bool,
+) -> Result<Value, LocalError> {
+    let url = filter.url(api_url)?;
+    let (mut socket, _) = tungstenite::connect(url.as_str()).map_err(|e| stream_error(&e))?;
+
+    let timed_out = LocalError::EventTimeout {
+        seconds: timeout.as_secs(),
+    };
+    let deadline = Instant::now() + timeout;
+    loop {
+        let remaining = deadline.saturating_duration_since(Instant::now());
+        if remaining.is_zero() {
+            return Err(timed_out);
+        }
+        set_read_timeout(&mut socket, remaining)?;
+
+        let text = match socket.read() {
+            Ok(Message::Text(text)) => text,
+            Ok(Message::Close(_)) => {
+                return Err(LocalError::EventStreamFailed {
+                    reason: "connection closed by the server".to_string(),
+                })
+            }
+            Ok(_) => continue,
+            Err(tungstenite::Error::Io(e))
+                if matches!(
+                    e.kind(),
+                    std::io::ErrorKind::WouldBlock | std::io::ErrorKind::TimedOut
+                ) =>
+            {
+                return Err(timed_out);
+            }
+            Err(e) => return Err(stream_error(&e)),
+        };
+
+        let msg: Value = serde_json::from_str(&text).map_err(|e| stream_error(&e))?;
+        if matches(&msg) {
+            let _ = socket.close(None);
+            return Ok(msg);
+        }
+    }
+}
+
+/// Waits for the chain to produce `blocks` blocks and returns the last height.
+///
+/// # Errors
+///
+/// If the connection fails, or the blocks are not produced within `timeout`.
+pub fn wait_for_blocks(
+    api_url: &str,
+    chain_id: &str,
+    blocks: u64,
+    timeout: Duration,
+) -> Result<u64, LocalError> {
+    let filter = EventFilter {
+        chain_ids: vec![chain_id.to_string()],
+        types: vec!["block".to_string()],
+        ..Default::default()
+    };
+
+    let mut seen = 0;
+    let msg = wait_for_event(api_url, &filter, timeout, |_| {
+        seen += 1;
+        seen >= blocks
+    })?;
+    Ok(msg["height"].as_u64().unwrap_or_default())
+}
+
+/// Waits for the transaction to be included in a block and returns its tx message.
+/// Only blocks produced after the call are seen, so start waiting before the tx can be included.
+///
+/// # Errors
+///
+/// If the connection fails, or the transaction is not included within `timeout`.
+pub fn wait_for_tx(
+    api_url: &str,
+    chain_id: &str,
+    tx_hash: &str,
+    timeout: Duration,
+) -> Result<Value, LocalError> {
+    let filter = EventFilter {
+        chain_ids: vec![chain_id.to_string()],
+        types: vec!["tx".to_string()],
+        ..Default::default()
+    };
+
+    wait_for_event(api_url, &filter, timeout, |msg| {
+        msg.get("tx_hash")
+            .and_then(Value::as_str)
+            .is_some_and(|hash| hash.eq_ignore_ascii_case(tx_hash))
+    })
+}
+
+#[cfg(test)]
+mod tests {
+    use super::EventFilter;
+
+    #[test]
+    fn url_includes_filter() {
+        let filter = EventFilter {
+            chain_ids: vec!["localjuno-1".to_string(), "localosmo-1".to_string()],
+            types: vec!["tx".to_string()],
+            ..Default::default()
+        };
+        let url = filter.url("http://127.0.0.1:8080/").unwrap();
+        assert_eq!(
+            url.as_str(),
+            "ws://127.0.0.1:8080/ws/events?chain_id=localjuno-1&chain_id=localosmo-1&type=tx"
+        );
+
+        let url = EventFilter::default().url("https://example.com").unwrap();
+        assert_eq!(url.as_str(), "wss://example.com/ws/events");
+    }
+}
@@ -1,4 +1,5 @@
 pub mod errors;
+pub mod events;
 pub mod filesystem;
 pub mod polling;
 pub mod transactions;
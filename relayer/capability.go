@@ -17,6 +17,24 @@ const (
 
 	// Whether the relayer supports a one-off flush command.
 	Flush
+
+	// Whether the relayer relays ICS-20 packets carrying packet forward middleware memos across multiple hops.
+	PacketForward
+
+	// Whether the relayer supports ICS-29 fee middleware channels and counterparty payee registration.
+	FeeMiddleware
+
+	// Whether the relayer can create and relay on ordered channels.
+	OrderedChannels
+
+	// Whether the relayer can complete the channel upgrade handshake.
+	ChannelUpgrades
+
+	// Whether the relayer completes interchain account channel handshakes initiated on chain.
+	InterchainAccounts
+
+	// Whether the relayer can upgrade a client after a counterparty chain upgrade.
+	ClientUpgrades
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -29,5 +47,12 @@ func FullCapabilities() map[Capability]bool {
 		HeightTimeout:    true,
 
 		Flush: true,
+
+		PacketForward:      true,
+		FeeMiddleware:      true,
+		OrderedChannels:    true,
+		ChannelUpgrades:    true,
+		InterchainAccounts: true,
+		ClientUpgrades:     true,
 	}
 }
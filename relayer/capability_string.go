@@ -11,11 +11,17 @@ func _() {
 	_ = x[TimestampTimeout-0]
 	_ = x[HeightTimeout-1]
 	_ = x[Flush-2]
+	_ = x[PacketForward-3]
+	_ = x[FeeMiddleware-4]
+	_ = x[OrderedChannels-5]
+	_ = x[ChannelUpgrades-6]
+	_ = x[InterchainAccounts-7]
+	_ = x[ClientUpgrades-8]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlush"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketForwardFeeMiddlewareOrderedChannelsChannelUpgradesInterchainAccountsClientUpgrades"
 
-var _Capability_index = [...]uint8{0, 16, 29, 34}
+var _Capability_index = [...]uint8{0, 16, 29, 34, 47, 60, 75, 90, 108, 122}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
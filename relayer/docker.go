@@ -13,10 +13,12 @@ import (
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 )
 
@@ -148,6 +150,24 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 	return bytes, nil
 }
 
+// ExportHome writes the relayer's entire home directory to w as a tar stream.
+func (r *DockerRelayer) ExportHome(ctx context.Context, w io.Writer) error {
+	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
+	if err := fr.Archive(ctx, r.volumeName, w); err != nil {
+		return fmt.Errorf("failed to export home: %w", err)
+	}
+	return nil
+}
+
+// ImportHome extracts a tar stream written by ExportHome into the relayer's home directory.
+func (r *DockerRelayer) ImportHome(ctx context.Context, rd io.Reader) error {
+	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
+	if err := fw.WriteArchive(ctx, r.volumeName, rd); err != nil {
+		return fmt.Errorf("failed to import home: %w", err)
+	}
+	return nil
+}
+
 // Modify a toml config file in relayer home directory
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
 	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
@@ -306,8 +326,12 @@ func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, c
 		Binds: r.Bind(),
 	}
 
+	ctx, span := tracing.Start(ctx, "relayer exec",
+		attribute.String("relayer", r.Name()), attribute.StringSlice("command", cmd))
 	startedAt := time.Now()
 	res := job.Run(ctx, cmd, opts)
+	span.SetAttributes(attribute.Int("exit_code", res.ExitCode))
+	tracing.End(span, res.Err)
 
 	defer func() {
 		rep.TrackRelayerExec(
@@ -451,6 +475,19 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 	return nil
 }
 
+// IsRunning reports whether the relayer was started and has not been stopped since.
+func (r *DockerRelayer) IsRunning() bool {
+	return r.containerLifecycle != nil
+}
+
+// Logs returns the last tail lines of the running relayer's output, or all of it when tail is 0.
+func (r *DockerRelayer) Logs(ctx context.Context, tail int) ([]byte, error) {
+	if r.containerLifecycle == nil {
+		return nil, fmt.Errorf("relayer is not running")
+	}
+	return r.containerLifecycle.Logs(ctx, tail)
+}
+
 func (r *DockerRelayer) PauseRelayer(ctx context.Context) error {
 	if r.containerLifecycle == nil {
 		return fmt.Errorf("container not running")
@@ -75,6 +75,17 @@ func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 	}
 }
 
+// Capabilities returns the set of capabilities of the hermes relayer.
+//
+// Note, this API may change if the hermes package eventually needs
+// to distinguish between multiple hermes versions.
+func Capabilities() map[relayer.Capability]bool {
+	caps := relayer.FullCapabilities()
+	// The channel upgrade handshake is not supported by the default container version.
+	caps[relayer.ChannelUpgrades] = false
+	return caps
+}
+
 // AddChainConfiguration is called once per chain configuration, which means that in the case of hermes, the single
 // config file is overwritten with a new entry each time this function is called.
 func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
@@ -58,13 +58,25 @@ func NewHyperspaceRelayer(log *zap.Logger, testName string, cli *client.Client,
 	return r
 }
 
-// HyperspaceCapabilities returns the set of capabilities of the Cosmos relayer.
+// HyperspaceCapabilities returns the set of capabilities of the hyperspace relayer.
 //
-// Note, this API may change if the rly package eventually needs
-// to distinguish between multiple rly versions.
+// Note, this API may change if the hyperspace package eventually needs
+// to distinguish between multiple hyperspace versions.
 func HyperspaceCapabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return nil // relayer.FullCapabilities()
+	// Hyperspace has no flush command and only relays unordered ICS-20 channels.
+	return map[relayer.Capability]bool{
+		relayer.TimestampTimeout: false,
+		relayer.HeightTimeout:    false,
+
+		relayer.Flush: false,
+
+		relayer.PacketForward:      false,
+		relayer.FeeMiddleware:      false,
+		relayer.OrderedChannels:    false,
+		relayer.ChannelUpgrades:    false,
+		relayer.InterchainAccounts: false,
+		relayer.ClientUpgrades:     false,
+	}
 }
 
 // LinkPath performs the operations that happen when a path is linked. This includes creating clients, creating connections
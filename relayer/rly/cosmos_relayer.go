@@ -71,8 +71,10 @@ const (
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	caps := relayer.FullCapabilities()
+	// The channel upgrade handshake is not supported as of v2.4.
+	caps[relayer.ChannelUpgrades] = false
+	return caps
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
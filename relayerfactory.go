@@ -109,8 +109,9 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
 	case ibc.Hermes:
-		// TODO: specify capability for hermes.
-		return rly.Capabilities()
+		return hermes.Capabilities()
+	case ibc.Hyperspace:
+		return hyperspace.HyperspaceCapabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
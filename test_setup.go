@@ -3,6 +3,9 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +33,46 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// CaptureContainerLogsOnFailure tracks the logs of every container of a failed test in rep,
+// before DockerSetup's cleanup removes the containers.
+// Only the last lines are kept, 50 by default or the value of the environment variable CONTAINER_LOG_TAIL.
+//
+// If artifactsDir is not empty, the logs are also written to artifactsDir/$TEST_NAME/$CONTAINER_NAME.log.
+// Passing a nil rep stops capturing logs.
+func CaptureContainerLogsOnFailure(rep *testreporter.Reporter, artifactsDir string) {
+	if rep == nil {
+		dockerutil.FailedContainerLogsHandler = nil
+		return
+	}
+	dockerutil.FailedContainerLogsHandler = func(logs dockerutil.ContainerLogs) error {
+		var path string
+		if artifactsDir != "" {
+			var err error
+			if path, err = writeContainerLogs(artifactsDir, logs); err != nil {
+				return err
+			}
+		}
+		rep.TrackContainerLogs(logs.TestName, logs.ContainerName, logs.Image, logs.Logs, path)
+		return nil
+	}
+}
+
+// writeContainerLogs writes logs to a file in a directory per test and subtest, returning the path of the file.
+func writeContainerLogs(artifactsDir string, logs dockerutil.ContainerLogs) (string, error) {
+	dir := artifactsDir
+	for _, name := range strings.Split(logs.TestName, "/") {
+		dir = filepath.Join(dir, sanitizeTestName(name))
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create artifacts dir: %w", err)
+	}
+	path := filepath.Join(dir, sanitizeTestName(logs.ContainerName)+".log")
+	if err := os.WriteFile(path, logs.Logs, 0644); err != nil {
+		return "", fmt.Errorf("write container logs: %w", err)
+	}
+	return path, nil
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
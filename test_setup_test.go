@@ -0,0 +1,56 @@
+package interchaintest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestCaptureContainerLogsOnFailure(t *testing.T) {
+	defer interchaintest.CaptureContainerLogsOnFailure(nil, "")
+
+	buf := new(bytes.Buffer)
+	rep := testreporter.NewReporter(nopCloser{buf})
+	dir := t.TempDir()
+	interchaintest.CaptureContainerLogsOnFailure(rep, dir)
+
+	require.NotNil(t, dockerutil.FailedContainerLogsHandler)
+	require.NoError(t, dockerutil.FailedContainerLogsHandler(dockerutil.ContainerLogs{
+		TestName:      "TestRelay/gaia+osmosis",
+		ContainerID:   "abc123",
+		ContainerName: "gaia-1-val-0-TestRelay",
+		Image:         "gaia:v7.0.1",
+		Logs:          []byte("panic: boom\n"),
+	}))
+	require.NoError(t, rep.Close())
+
+	wantPath := filepath.Join(dir, "TestRelay", "gaia+osmosis", "gaia-1-val-0-TestRelay.log")
+	b, err := os.ReadFile(wantPath)
+	require.NoError(t, err)
+	require.Equal(t, "panic: boom\n", string(b))
+
+	msgs, err := testreporter.ReadMessages(buf)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	msg := msgs[1].(testreporter.ContainerLogsMessage)
+	require.Equal(t, "TestRelay/gaia+osmosis", msg.Name)
+	require.Equal(t, "gaia-1-val-0-TestRelay", msg.ContainerName)
+	require.Equal(t, "gaia:v7.0.1", msg.Image)
+	require.Equal(t, "panic: boom\n", msg.Logs)
+	require.Equal(t, wantPath, msg.ArtifactPath)
+
+	interchaintest.CaptureContainerLogsOnFailure(nil, "")
+	require.Nil(t, dockerutil.FailedContainerLogsHandler)
+}
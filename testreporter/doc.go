@@ -73,4 +73,7 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// A report can be converted to JUnit XML or a static HTML page with Convert,
+// or summarized per test with ReadMessages and Summarize.
 package testreporter
@@ -0,0 +1,66 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a file format of a test report.
+type Format string
+
+const (
+	// FormatJSON is the newline-delimited JSON written by a Reporter.
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+	FormatHTML  Format = "html"
+)
+
+// Ext is the file extension for the format, including the leading dot.
+func (f Format) Ext() string {
+	switch f {
+	case FormatJUnit:
+		return ".xml"
+	case FormatHTML:
+		return ".html"
+	default:
+		return ".json"
+	}
+}
+
+// ParseFormats parses a comma separated list of formats, e.g. "junit,html".
+func ParseFormats(s string) ([]Format, error) {
+	var formats []Format
+	for _, name := range strings.Split(s, ",") {
+		f := Format(strings.TrimSpace(name))
+		switch f {
+		case FormatJSON, FormatJUnit, FormatHTML:
+			formats = append(formats, f)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown report format %q, must be one of json|junit|html", f)
+		}
+	}
+	return formats, nil
+}
+
+// Convert reads a report written by a Reporter from r and writes it to w in the given format.
+func Convert(w io.Writer, r io.Reader, f Format) error {
+	if f == FormatJSON {
+		_, err := io.Copy(w, r)
+		return err
+	}
+	msgs, err := ReadMessages(r)
+	if err != nil {
+		return err
+	}
+	suite := Summarize(msgs)
+	switch f {
+	case FormatJUnit:
+		return WriteJUnit(w, suite)
+	case FormatHTML:
+		return WriteHTML(w, suite)
+	default:
+		return fmt.Errorf("unknown report format %q", f)
+	}
+}
@@ -0,0 +1,179 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func reportMessages() []testreporter.Message {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	return []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: start},
+		testreporter.BeginTestMessage{Name: "TestRelay/pass", StartedAt: at(time.Second)},
+		testreporter.PauseTestMessage{Name: "TestRelay/pass", When: at(time.Second)},
+		testreporter.ContinueTestMessage{Name: "TestRelay/pass", When: at(3 * time.Second)},
+		testreporter.RelayerExecMessage{
+			Name:          "TestRelay/pass",
+			StartedAt:     at(4 * time.Second),
+			FinishedAt:    at(5 * time.Second),
+			ContainerName: "rly-1",
+			Command:       []string{"rly", "tx", "flush"},
+			Stdout:        "flushed <packets>",
+			Stderr:        "warning",
+		},
+		testreporter.FinishTestMessage{Name: "TestRelay/pass", FinishedAt: at(6 * time.Second)},
+		testreporter.BeginTestMessage{Name: "TestRelay/fail", StartedAt: at(7 * time.Second)},
+		testreporter.TestErrorMessage{Name: "TestRelay/fail", Message: "\n\tError Trace:\tfoo_test.go:12\n\tError:      \tbalance mismatch\n"},
+		testreporter.ContainerLogsMessage{
+			Name:          "TestRelay/fail",
+			When:          at(9 * time.Second),
+			ContainerName: "gaia-1-val-0-TestRelay",
+			Image:         "gaia:v7.0.1",
+			Logs:          "panic: <boom>\n",
+			ArtifactPath:  "artifacts/TestRelay/fail/gaia-1-val-0-TestRelay.log",
+		},
+		testreporter.FinishTestMessage{Name: "TestRelay/fail", FinishedAt: at(9 * time.Second), Failed: true},
+		testreporter.BeginTestMessage{Name: "TestSkip", StartedAt: at(10 * time.Second)},
+		testreporter.TestSkipMessage{Name: "TestSkip", Message: "no docker"},
+		testreporter.FinishTestMessage{Name: "TestSkip", FinishedAt: at(10 * time.Second), Skipped: true},
+		testreporter.BeginTestMessage{Name: "TestPanic", StartedAt: at(11 * time.Second)},
+		// Messages of untracked tests are ignored.
+		testreporter.TestErrorMessage{Name: "TestUntracked", Message: "ignored"},
+		testreporter.FinishSuiteMessage{FinishedAt: at(12 * time.Second)},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	suite := testreporter.Summarize(reportMessages())
+	require.Equal(t, 12*time.Second, suite.Duration())
+	require.Len(t, suite.Tests, 4)
+	require.Equal(t, 2, suite.Failures())
+	require.Equal(t, 1, suite.Skips())
+
+	pass := suite.Tests[0]
+	require.Equal(t, "TestRelay/pass", pass.Name)
+	require.Equal(t, 2*time.Second, pass.Paused)
+	require.Equal(t, 3*time.Second, pass.Duration())
+	require.False(t, pass.Failed)
+	require.Len(t, pass.RelayerExecs, 1)
+
+	fail := suite.Tests[1]
+	require.True(t, fail.Failed)
+	require.Equal(t, "balance mismatch", testreporter.FailureSummary(fail))
+	require.Len(t, fail.ContainerLogs, 1)
+
+	require.Equal(t, "no docker", suite.Tests[2].SkipMessage)
+
+	panicked := suite.Tests[3]
+	require.False(t, panicked.Finished)
+	require.True(t, panicked.Failed)
+	require.Zero(t, panicked.Duration())
+	require.Equal(t, "test did not finish", testreporter.FailureSummary(panicked))
+}
+
+func reportJSON(t *testing.T) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	for _, m := range reportMessages() {
+		require.NoError(t, enc.Encode(testreporter.JSONMessage(m)))
+	}
+	return buf
+}
+
+func TestConvert_JUnit(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	require.NoError(t, testreporter.Convert(&out, reportJSON(t), testreporter.FormatJUnit))
+
+	var got struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name      string `xml:"name,attr"`
+			Timestamp string `xml:"timestamp,attr"`
+			Cases     []struct {
+				Name      string `xml:"name,attr"`
+				ClassName string `xml:"classname,attr"`
+				Time      string `xml:"time,attr"`
+				Failure   *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+				SystemOut string `xml:"system-out"`
+				SystemErr string `xml:"system-err"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &got))
+	require.Equal(t, 4, got.Tests)
+	require.Equal(t, 2, got.Failures)
+	require.Len(t, got.Suites, 1)
+
+	suite := got.Suites[0]
+	require.Equal(t, testreporter.JUnitSuiteName, suite.Name)
+	require.Equal(t, "2023-01-02T03:04:05", suite.Timestamp)
+	require.Len(t, suite.Cases, 4)
+
+	pass := suite.Cases[0]
+	require.Equal(t, "TestRelay", pass.ClassName)
+	require.Equal(t, "3.000", pass.Time)
+	require.Nil(t, pass.Failure)
+	require.Contains(t, pass.SystemOut, "$ rly tx flush\n")
+	require.Contains(t, pass.SystemOut, "stdout:\nflushed <packets>\n")
+
+	fail := suite.Cases[1]
+	require.Equal(t, "balance mismatch", fail.Failure.Message)
+	require.Contains(t, fail.Failure.Text, "foo_test.go:12")
+	require.Equal(t, "=== container gaia-1-val-0-TestRelay (gaia:v7.0.1) ===\npanic: <boom>\n", fail.SystemErr)
+
+	require.Equal(t, "no docker", suite.Cases[2].Skipped.Message)
+	require.Equal(t, "test did not finish", suite.Cases[3].Failure.Message)
+}
+
+func TestConvert_HTML(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	require.NoError(t, testreporter.Convert(&out, reportJSON(t), testreporter.FormatHTML))
+	html := out.String()
+
+	require.Contains(t, html, "4 tests, 2 failed, 1 skipped in 12s.")
+	require.Contains(t, html, "<td>TestRelay/pass</td>")
+	require.Contains(t, html, "<td>3s</td>\n<td>2s</td>")
+	require.Contains(t, html, `<p class="fail">balance mismatch</p>`)
+	require.Contains(t, html, "<summary><code>rly tx flush</code> exit 0 in 1s</summary>")
+	// Output is escaped.
+	require.Contains(t, html, "flushed &lt;packets&gt;")
+	require.Contains(t, html, "<summary>Logs of container <code>gaia-1-val-0-TestRelay</code> (gaia:v7.0.1)</summary>")
+	require.Contains(t, html, "<pre>panic: &lt;boom&gt;\n</pre>")
+	require.Equal(t, 2, strings.Count(html, "<details>"))
+}
+
+func TestParseFormats(t *testing.T) {
+	t.Parallel()
+
+	formats, err := testreporter.ParseFormats("junit, html")
+	require.NoError(t, err)
+	require.Equal(t, []testreporter.Format{testreporter.FormatJUnit, testreporter.FormatHTML}, formats)
+
+	formats, err = testreporter.ParseFormats("")
+	require.NoError(t, err)
+	require.Empty(t, formats)
+
+	_, err = testreporter.ParseFormats("junit,pdf")
+	require.ErrorContains(t, err, `unknown report format "pdf"`)
+}
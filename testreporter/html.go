@@ -0,0 +1,103 @@
+package testreporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string { return d.Round(time.Millisecond).String() },
+	"time":     func(t time.Time) string { return t.Format("2006-01-02 15:04:05.000 MST") },
+	"status":   htmlStatus,
+	"failure":  FailureSummary,
+	"join":     strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>interchaintest report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.4em; text-align: left; vertical-align: top; }
+pre { background: #f6f8fa; padding: 0.5em; overflow-x: auto; white-space: pre-wrap; }
+summary { cursor: pointer; }
+.pass { color: #1a7f37; }
+.fail { color: #cf222e; }
+.skip { color: #9a6700; }
+</style>
+</head>
+<body>
+<h1>interchaintest report</h1>
+<p>
+{{- if not .StartedAt.IsZero}}Started {{time .StartedAt}}. {{end -}}
+{{len .Tests}} tests, {{.Failures}} failed, {{.Skips}} skipped{{if not .FinishedAt.IsZero}} in {{duration .Duration}}{{end}}.
+</p>
+<table>
+<tr><th>Test</th><th>Result</th><th>Started</th><th>Duration</th><th>Paused</th></tr>
+{{- range .Tests}}
+<tr>
+<td>{{.Name}}</td>
+<td class="{{status .}}">{{status .}}</td>
+<td>{{time .StartedAt}}</td>
+<td>{{duration .Duration}}</td>
+<td>{{if .Paused}}{{duration .Paused}}{{end}}</td>
+</tr>
+{{- if or .Failed .Skipped .RelayerExecs .ContainerLogs}}
+<tr><td colspan="5">
+{{- if .Failed}}
+<p class="fail">{{failure .}}</p>
+{{- range .Errors}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- else if .Skipped}}
+<p class="skip">{{.SkipMessage}}</p>
+{{- end}}
+{{- range .RelayerExecs}}
+<details>
+<summary><code>{{join .Command " "}}</code> exit {{.ExitCode}} in {{duration (.FinishedAt.Sub .StartedAt)}}</summary>
+{{- if .ContainerName}}<p>Container {{.ContainerName}}</p>{{end}}
+{{- if .Error}}<p class="fail">{{.Error}}</p>{{end}}
+<p>Stdout</p>
+<pre>{{.Stdout}}</pre>
+<p>Stderr</p>
+<pre>{{.Stderr}}</pre>
+</details>
+{{- end}}
+{{- range .ContainerLogs}}
+<details>
+<summary>Logs of container <code>{{.ContainerName}}</code>{{if .Image}} ({{.Image}}){{end}}</summary>
+{{- if .ArtifactPath}}<p>Saved to {{.ArtifactPath}}</p>{{end}}
+<pre>{{.Logs}}</pre>
+</details>
+{{- end}}
+</td></tr>
+{{- end}}
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+func htmlStatus(test TestResult) string {
+	switch {
+	case test.Failed:
+		return "fail"
+	case test.Skipped:
+		return "skip"
+	default:
+		return "pass"
+	}
+}
+
+// WriteHTML writes the suite as a static HTML page with the timing and failure messages of each test.
+// The output of relayer commands and the logs of containers are collapsed under each test.
+func WriteHTML(w io.Writer, suite SuiteResult) error {
+	if err := htmlReport.Execute(w, suite); err != nil {
+		return fmt.Errorf("execute html template: %w", err)
+	}
+	return nil
+}
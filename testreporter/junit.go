@@ -0,0 +1,165 @@
+package testreporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JUnitSuiteName is the name of the single test suite in JUnit reports.
+const JUnitSuiteName = "interchaintest"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the suite as JUnit XML. Each test is a test case whose class name is its top-level test.
+// The output of its relayer commands is the test case's system-out, and the logs of its containers its system-err.
+func WriteJUnit(w io.Writer, suite SuiteResult) error {
+	ts := junitTestSuite{
+		Name:     JUnitSuiteName,
+		Tests:    len(suite.Tests),
+		Failures: suite.Failures(),
+		Skipped:  suite.Skips(),
+		Time:     junitSeconds(suite.Duration()),
+	}
+	if !suite.StartedAt.IsZero() {
+		ts.Timestamp = suite.StartedAt.UTC().Format("2006-01-02T15:04:05")
+	}
+	for _, test := range suite.Tests {
+		tc := junitTestCase{
+			Name:      test.Name,
+			ClassName: strings.SplitN(test.Name, "/", 2)[0],
+			Time:      junitSeconds(test.Duration()),
+		}
+		switch {
+		case test.Failed:
+			msg := FailureSummary(test)
+			tc.Failure = &junitMessage{Message: msg, Text: strings.Join(test.Errors, "\n")}
+			if tc.Failure.Text == "" {
+				tc.Failure.Text = msg
+			}
+		case test.Skipped:
+			tc.Skipped = &junitMessage{Message: test.SkipMessage}
+		}
+		execs := make([]string, len(test.RelayerExecs))
+		for i, exec := range test.RelayerExecs {
+			execs[i] = FormatRelayerExec(exec)
+		}
+		tc.SystemOut = strings.Join(execs, "\n")
+		logs := make([]string, len(test.ContainerLogs))
+		for i, l := range test.ContainerLogs {
+			logs[i] = FormatContainerLogs(l)
+		}
+		tc.SystemErr = strings.Join(logs, "\n")
+		ts.Cases = append(ts.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err := enc.Encode(junitTestSuites{
+		Tests:    ts.Tests,
+		Failures: ts.Failures,
+		Skipped:  ts.Skipped,
+		Time:     ts.Time,
+		Suites:   []junitTestSuite{ts},
+	})
+	if err != nil {
+		return fmt.Errorf("encode junit xml: %w", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// FailureSummary is a one line reason for the failure of a test: its first tracked assertion failure,
+// or a generic message if the failure was not tracked.
+func FailureSummary(test TestResult) string {
+	if !test.Finished {
+		return "test did not finish"
+	}
+	if len(test.Errors) == 0 {
+		return "test failed"
+	}
+	// Testify messages span several lines, the first of which is the error trace.
+	// Prefer the "Error:" line if there is one.
+	for _, line := range strings.Split(test.Errors[0], "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Error:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Error:"))
+		}
+	}
+	return strings.TrimSpace(strings.SplitN(test.Errors[0], "\n", 2)[0])
+}
+
+// FormatRelayerExec formats a relayer command and its output as plain text, e.g. for a log.
+func FormatRelayerExec(m RelayerExecMessage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ %s\n", strings.Join(m.Command, " "))
+	if m.ContainerName != "" {
+		fmt.Fprintf(&b, "container: %s\n", m.ContainerName)
+	}
+	fmt.Fprintf(&b, "started: %s, duration: %s, exit code: %d\n", m.StartedAt.UTC().Format(time.RFC3339Nano), m.FinishedAt.Sub(m.StartedAt), m.ExitCode)
+	if m.Error != "" {
+		fmt.Fprintf(&b, "error: %s\n", m.Error)
+	}
+	if m.Stdout != "" {
+		fmt.Fprintf(&b, "stdout:\n%s\n", strings.TrimRight(m.Stdout, "\n"))
+	}
+	if m.Stderr != "" {
+		fmt.Fprintf(&b, "stderr:\n%s\n", strings.TrimRight(m.Stderr, "\n"))
+	}
+	return b.String()
+}
+
+// FormatContainerLogs formats the logs of a container with a header naming the container.
+func FormatContainerLogs(m ContainerLogsMessage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== container %s", m.ContainerName)
+	if m.Image != "" {
+		fmt.Fprintf(&b, " (%s)", m.Image)
+	}
+	b.WriteString(" ===\n")
+	if m.Logs != "" {
+		fmt.Fprintf(&b, "%s\n", strings.TrimRight(m.Logs, "\n"))
+	}
+	return b.String()
+}
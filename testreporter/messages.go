@@ -133,6 +133,27 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// ContainerLogsMessage is the tail of the logs of a container of a failed test,
+// captured before the container is removed.
+// This message is populated through (*Reporter).TrackContainerLogs,
+// typically set up with interchaintest.CaptureContainerLogsOnFailure.
+type ContainerLogsMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	ContainerName string
+	Image         string `json:",omitempty"`
+
+	Logs string
+
+	// Path of the file the logs were also written to, if any.
+	ArtifactPath string `json:",omitempty"`
+}
+
+func (m ContainerLogsMessage) typ() string {
+	return "ContainerLogs"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -199,6 +220,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "ContainerLogs":
+		x := ContainerLogsMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
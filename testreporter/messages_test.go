@@ -39,6 +39,16 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.ContainerLogsMessage{
+				Name:          "foo",
+				When:          time.Now(),
+				ContainerName: "gaia-1-val-0-foo",
+				Image:         "ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.1",
+				Logs:          "panic: boom",
+				ArtifactPath:  "/tmp/artifacts/foo/gaia-1-val-0-foo.log",
+			},
+		},
 	}
 
 	for _, tc := range tcs {
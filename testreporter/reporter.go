@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -128,6 +129,9 @@ func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 type RelayerExecReporter struct {
 	r        *Reporter
 	testName string
+
+	mu    sync.Mutex
+	execs []RelayerExecMessage
 }
 
 // TrackRelayerExec tracks the execution of an individual relayer command.
@@ -143,7 +147,7 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	if err != nil {
 		errMsg = err.Error()
 	}
-	r.r.in <- RelayerExecMessage{
+	msg := RelayerExecMessage{
 		Name:          r.testName,
 		StartedAt:     startedAt,
 		FinishedAt:    finishedAt,
@@ -154,6 +158,32 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 		ExitCode:      exitCode,
 		Error:         errMsg,
 	}
+
+	r.mu.Lock()
+	r.execs = append(r.execs, msg)
+	r.mu.Unlock()
+
+	r.r.in <- msg
+}
+
+// Execs returns the relayer commands tracked so far by r, in the order they were tracked.
+func (r *RelayerExecReporter) Execs() []RelayerExecMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RelayerExecMessage(nil), r.execs...)
+}
+
+// TrackContainerLogs records the logs of a container of the test named testName,
+// and the path of the file they were written to, if any.
+func (r *Reporter) TrackContainerLogs(testName, containerName, image string, logs []byte, artifactPath string) {
+	r.in <- ContainerLogsMessage{
+		Name:          testName,
+		When:          time.Now(),
+		ContainerName: containerName,
+		Image:         image,
+		Logs:          string(logs),
+		ArtifactPath:  artifactPath,
+	}
 }
 
 // TestifyT returns a TestifyReporter which will track logged errors in test.
@@ -229,7 +229,8 @@ func TestReporter_RelayerExec(t *testing.T) {
 
 	execStartedAt := time.Now()
 	execFinishedAt := execStartedAt.Add(time.Second)
-	r.RelayerExecReporter(mt).TrackRelayerExec(
+	eRep := r.RelayerExecReporter(mt)
+	eRep.TrackRelayerExec(
 		"my_container",
 		[]string{"rly", "fake_command"},
 		"stdout", "stderr",
@@ -257,6 +258,37 @@ func TestReporter_RelayerExec(t *testing.T) {
 		Error:         "",
 	}, msgs[2].(testreporter.RelayerExecMessage))
 	require.Empty(t, diff)
+
+	execs := eRep.Execs()
+	require.Len(t, execs, 1)
+	require.Empty(t, cmp.Diff(msgs[2], execs[0]))
+}
+
+func TestReporter_TrackContainerLogs(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	before := time.Now()
+	r.TrackContainerLogs("my_test", "my_container", "my_image:v1", []byte("panic: boom\n"), "/tmp/my_container.log")
+	after := time.Now()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 3)
+
+	msg := msgs[1].(testreporter.ContainerLogsMessage)
+	requireTimeInRange(t, msg.When, before, after)
+	msg.When = time.Time{}
+	require.Equal(t, testreporter.ContainerLogsMessage{
+		Name:          "my_test",
+		ContainerName: "my_container",
+		Image:         "my_image:v1",
+		Logs:          "panic: boom\n",
+		ArtifactPath:  "/tmp/my_container.log",
+	}, msg)
 }
 
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
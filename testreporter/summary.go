@@ -0,0 +1,155 @@
+package testreporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReadMessages decodes the newline-delimited WrappedMessages written by a Reporter.
+func ReadMessages(r io.Reader) ([]Message, error) {
+	var msgs []Message
+	dec := json.NewDecoder(r)
+	for {
+		var wm WrappedMessage
+		if err := dec.Decode(&wm); err != nil {
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			}
+			return msgs, fmt.Errorf("decode message %d: %w", len(msgs)+1, err)
+		}
+		msgs = append(msgs, wm.Message)
+	}
+}
+
+// SuiteResult is the outcome of a test suite, built from the messages of a report.
+type SuiteResult struct {
+	StartedAt, FinishedAt time.Time
+
+	// Tests in the order they began.
+	Tests []TestResult
+}
+
+// Failures returns the number of tests that failed.
+func (s SuiteResult) Failures() int {
+	var n int
+	for _, test := range s.Tests {
+		if test.Failed {
+			n++
+		}
+	}
+	return n
+}
+
+// Skips returns the number of tests that were skipped.
+func (s SuiteResult) Skips() int {
+	var n int
+	for _, test := range s.Tests {
+		if test.Skipped {
+			n++
+		}
+	}
+	return n
+}
+
+// Duration of the suite, or zero if it never finished.
+func (s SuiteResult) Duration() time.Duration {
+	if s.FinishedAt.IsZero() {
+		return 0
+	}
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+// TestResult is the outcome of a single tracked test.
+type TestResult struct {
+	Name                  string
+	StartedAt, FinishedAt time.Time
+
+	// Paused is the time spent waiting for parallel execution.
+	Paused time.Duration
+
+	// Finished is false if the report has no FinishTestMessage for the test,
+	// e.g. because the test binary panicked or timed out. Such tests are reported as failed.
+	Finished        bool
+	Failed, Skipped bool
+
+	// Messages of the tracked assertion failures.
+	Errors      []string
+	SkipMessage string
+
+	RelayerExecs  []RelayerExecMessage
+	ContainerLogs []ContainerLogsMessage
+}
+
+// Duration is the time the test ran, excluding the time paused waiting for parallel execution.
+func (t TestResult) Duration() time.Duration {
+	if t.FinishedAt.IsZero() {
+		return 0
+	}
+	return t.FinishedAt.Sub(t.StartedAt) - t.Paused
+}
+
+// Summarize aggregates the messages of a report per test.
+// Messages of tests without a BeginTestMessage are ignored.
+func Summarize(msgs []Message) SuiteResult {
+	var (
+		suite  SuiteResult
+		byName = make(map[string]*TestResult)
+		paused = make(map[string]time.Time)
+	)
+	// Tests are collected as pointers first, so results stay addressable while the slice grows.
+	var tests []*TestResult
+	for _, m := range msgs {
+		switch m := m.(type) {
+		case BeginSuiteMessage:
+			suite.StartedAt = m.StartedAt
+		case FinishSuiteMessage:
+			suite.FinishedAt = m.FinishedAt
+		case BeginTestMessage:
+			test := &TestResult{Name: m.Name, StartedAt: m.StartedAt}
+			byName[m.Name] = test
+			tests = append(tests, test)
+		case PauseTestMessage:
+			paused[m.Name] = m.When
+		case ContinueTestMessage:
+			if test, ok := byName[m.Name]; ok && !paused[m.Name].IsZero() {
+				test.Paused += m.When.Sub(paused[m.Name])
+			}
+			delete(paused, m.Name)
+		case FinishTestMessage:
+			if test, ok := byName[m.Name]; ok {
+				test.FinishedAt = m.FinishedAt
+				test.Finished = true
+				test.Failed = m.Failed
+				test.Skipped = m.Skipped
+			}
+		case TestErrorMessage:
+			if test, ok := byName[m.Name]; ok {
+				test.Errors = append(test.Errors, m.Message)
+			}
+		case TestSkipMessage:
+			if test, ok := byName[m.Name]; ok {
+				test.SkipMessage = m.Message
+			}
+		case RelayerExecMessage:
+			if test, ok := byName[m.Name]; ok {
+				test.RelayerExecs = append(test.RelayerExecs, m)
+			}
+		case ContainerLogsMessage:
+			if test, ok := byName[m.Name]; ok {
+				test.ContainerLogs = append(test.ContainerLogs, m)
+			}
+		}
+	}
+
+	suite.Tests = make([]TestResult, len(tests))
+	for i, test := range tests {
+		if !test.Finished {
+			test.Failed = true
+		}
+		suite.Tests[i] = *test
+	}
+	return suite
+}
@@ -0,0 +1,155 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+)
+
+// PacketEvent locates an IBC packet event in a chain's history.
+type PacketEvent struct {
+	Height uint64
+	Time   time.Time
+	TxHash string
+}
+
+// PacketInspector is a chain that can report the IBC state of a packet.
+// The source chain is asked about commitments and the counterparty about receipts and acknowledgements.
+type PacketInspector interface {
+	// HasPacketCommitment reports whether the packet commitment is still stored on the sending chain.
+	HasPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// HasPacketReceipt reports whether the receiving chain stored a receipt for the packet.
+	// Receipts are only written for unordered channels.
+	HasPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// PacketAcknowledgement returns the acknowledgement commitment written by the receiving chain, or nil if none exists.
+	PacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
+
+	// PacketEvents returns every event of eventType emitted for the packet identified by its source port, channel and sequence.
+	PacketEvents(ctx context.Context, eventType, srcPort, srcChannel string, sequence uint64) ([]PacketEvent, error)
+}
+
+// PacketStep is one step of a packet's lifecycle.
+type PacketStep struct {
+	PacketEvent
+
+	// RelayerExecs are the relayer commands that were running when the step's block was produced.
+	RelayerExecs []testreporter.RelayerExecMessage
+}
+
+// PacketLifecycle is the state of a packet on both ends of a channel.
+// Steps which have not happened yet are nil.
+type PacketLifecycle struct {
+	SourcePort, SourceChannel string
+	DestPort, DestChannel     string
+	Sequence                  uint64
+
+	Sent *PacketStep
+	// CommitmentExists is true until the packet is acknowledged or timed out on the source chain.
+	CommitmentExists bool
+
+	Received *PacketStep
+	// ReceiptExists is true once an unordered channel's counterparty received the packet.
+	ReceiptExists bool
+
+	AckWritten *PacketStep
+	// Acknowledgement is the acknowledgement commitment stored on the counterparty.
+	Acknowledgement []byte
+
+	AckRelayed *PacketStep
+	TimedOut   *PacketStep
+}
+
+// Stage summarizes how far the packet progressed through its lifecycle.
+func (l PacketLifecycle) Stage() string {
+	switch {
+	case l.TimedOut != nil:
+		return "timed out"
+	case l.AckRelayed != nil:
+		return "acknowledged"
+	case l.AckWritten != nil:
+		return "awaiting acknowledgement relay"
+	case l.Received != nil:
+		return "awaiting acknowledgement"
+	case l.Sent != nil:
+		return "awaiting receive"
+	default:
+		return "not sent"
+	}
+}
+
+// InspectPacket gathers the lifecycle of the packet with the given sequence sent over channel, which is the
+// channel end on src. Each step is attributed to the relayer execs, typically from (*testreporter.RelayerExecReporter).Execs,
+// that were running at the time of the step's block.
+func InspectPacket(
+	ctx context.Context,
+	src, dst PacketInspector,
+	channel ibc.ChannelOutput,
+	sequence uint64,
+	execs []testreporter.RelayerExecMessage,
+) (PacketLifecycle, error) {
+	l := PacketLifecycle{
+		SourcePort:    channel.PortID,
+		SourceChannel: channel.ChannelID,
+		DestPort:      channel.Counterparty.PortID,
+		DestChannel:   channel.Counterparty.ChannelID,
+		Sequence:      sequence,
+	}
+
+	step := func(chain PacketInspector, eventType string) (*PacketStep, error) {
+		events, err := chain.PacketEvents(ctx, eventType, l.SourcePort, l.SourceChannel, l.Sequence)
+		if err != nil {
+			return nil, fmt.Errorf("query %s events: %w", eventType, err)
+		}
+		if len(events) == 0 {
+			return nil, nil
+		}
+		// Relayers may submit the same packet more than once; the first event is the one that took effect.
+		return &PacketStep{PacketEvent: events[0], RelayerExecs: execsAt(execs, events[0].Time)}, nil
+	}
+
+	var err error
+	if l.Sent, err = step(src, chantypes.EventTypeSendPacket); err != nil {
+		return l, err
+	}
+	if l.Received, err = step(dst, chantypes.EventTypeRecvPacket); err != nil {
+		return l, err
+	}
+	if l.AckWritten, err = step(dst, chantypes.EventTypeWriteAck); err != nil {
+		return l, err
+	}
+	if l.AckRelayed, err = step(src, chantypes.EventTypeAcknowledgePacket); err != nil {
+		return l, err
+	}
+	if l.TimedOut, err = step(src, chantypes.EventTypeTimeoutPacket); err != nil {
+		return l, err
+	}
+
+	if l.CommitmentExists, err = src.HasPacketCommitment(ctx, l.SourcePort, l.SourceChannel, l.Sequence); err != nil {
+		return l, fmt.Errorf("query packet commitment: %w", err)
+	}
+	if l.ReceiptExists, err = dst.HasPacketReceipt(ctx, l.DestPort, l.DestChannel, l.Sequence); err != nil {
+		return l, fmt.Errorf("query packet receipt: %w", err)
+	}
+	if l.Acknowledgement, err = dst.PacketAcknowledgement(ctx, l.DestPort, l.DestChannel, l.Sequence); err != nil {
+		return l, fmt.Errorf("query packet acknowledgement: %w", err)
+	}
+
+	return l, nil
+}
+
+// execsAt returns the execs which were running at t.
+func execsAt(execs []testreporter.RelayerExecMessage, t time.Time) []testreporter.RelayerExecMessage {
+	var running []testreporter.RelayerExecMessage
+	for _, exec := range execs {
+		if !t.Before(exec.StartedAt) && !t.After(exec.FinishedAt) {
+			running = append(running, exec)
+		}
+	}
+	return running
+}
@@ -0,0 +1,122 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+type mockInspector struct {
+	Commitment bool
+	Receipt    bool
+	Ack        []byte
+	Events     map[string][]PacketEvent
+	EventsErr  error
+
+	GotEventQueries []string
+}
+
+func (m *mockInspector) HasPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	return m.Commitment, nil
+}
+
+func (m *mockInspector) HasPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	return m.Receipt, nil
+}
+
+func (m *mockInspector) PacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	return m.Ack, nil
+}
+
+func (m *mockInspector) PacketEvents(ctx context.Context, eventType, srcPort, srcChannel string, sequence uint64) ([]PacketEvent, error) {
+	m.GotEventQueries = append(m.GotEventQueries, eventType+" "+srcPort+"/"+srcChannel)
+	return m.Events[eventType], m.EventsErr
+}
+
+func TestInspectPacket(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	channel := ibc.ChannelOutput{
+		PortID:       "transfer",
+		ChannelID:    "channel-0",
+		Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-7"},
+	}
+
+	t.Run("acknowledged", func(t *testing.T) {
+		src := &mockInspector{Events: map[string][]PacketEvent{
+			chantypes.EventTypeSendPacket:        {{Height: 10, Time: base}},
+			chantypes.EventTypeAcknowledgePacket: {{Height: 14, Time: base.Add(20 * time.Second), TxHash: "ack"}},
+		}}
+		dst := &mockInspector{Receipt: true, Ack: []byte{1}, Events: map[string][]PacketEvent{
+			chantypes.EventTypeRecvPacket: {{Height: 5, Time: base.Add(10 * time.Second)}, {Height: 6, Time: base.Add(15 * time.Second)}},
+			chantypes.EventTypeWriteAck:   {{Height: 5, Time: base.Add(10 * time.Second)}},
+		}}
+		execs := []testreporter.RelayerExecMessage{
+			{Command: []string{"flush"}, StartedAt: base.Add(8 * time.Second), FinishedAt: base.Add(12 * time.Second)},
+			{Command: []string{"relay-acks"}, StartedAt: base.Add(18 * time.Second), FinishedAt: base.Add(20 * time.Second)},
+		}
+
+		l, err := InspectPacket(ctx, src, dst, channel, 3, execs)
+		require.NoError(t, err)
+
+		require.Equal(t, "acknowledged", l.Stage())
+		require.Equal(t, "channel-7", l.DestChannel)
+		require.EqualValues(t, 3, l.Sequence)
+
+		require.EqualValues(t, 10, l.Sent.Height)
+		require.Empty(t, l.Sent.RelayerExecs)
+
+		require.EqualValues(t, 5, l.Received.Height)
+		require.Len(t, l.Received.RelayerExecs, 1)
+		require.Equal(t, []string{"flush"}, l.Received.RelayerExecs[0].Command)
+
+		require.Equal(t, "ack", l.AckRelayed.TxHash)
+		require.Len(t, l.AckRelayed.RelayerExecs, 1)
+		require.Equal(t, []string{"relay-acks"}, l.AckRelayed.RelayerExecs[0].Command)
+
+		require.Nil(t, l.TimedOut)
+		require.False(t, l.CommitmentExists)
+		require.True(t, l.ReceiptExists)
+		require.Equal(t, []byte{1}, l.Acknowledgement)
+
+		// Both ends are queried by the packet's source identifiers.
+		require.Contains(t, dst.GotEventQueries, chantypes.EventTypeRecvPacket+" transfer/channel-0")
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		src := &mockInspector{Commitment: true, Events: map[string][]PacketEvent{
+			chantypes.EventTypeSendPacket: {{Height: 10, Time: base}},
+		}}
+		dst := &mockInspector{}
+
+		l, err := InspectPacket(ctx, src, dst, channel, 3, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, "awaiting receive", l.Stage())
+		require.True(t, l.CommitmentExists)
+		require.Nil(t, l.Received)
+		require.Nil(t, l.Acknowledgement)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		src := &mockInspector{Events: map[string][]PacketEvent{
+			chantypes.EventTypeSendPacket:    {{Height: 10, Time: base}},
+			chantypes.EventTypeTimeoutPacket: {{Height: 30, Time: base.Add(time.Minute)}},
+		}}
+
+		l, err := InspectPacket(ctx, src, &mockInspector{}, channel, 3, nil)
+		require.NoError(t, err)
+		require.Equal(t, "timed out", l.Stage())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		_, err := InspectPacket(ctx, &mockInspector{EventsErr: errors.New("boom")}, &mockInspector{}, channel, 3, nil)
+		require.ErrorContains(t, err, "boom")
+	})
+}
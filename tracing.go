@@ -0,0 +1,30 @@
+package interchaintest
+
+import (
+	"context"
+	"os"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/tracing"
+)
+
+// TraceExporterEnv is the environment variable SetupTracing reads when called with an empty exporter.
+const TraceExporterEnv = "IBCTEST_TRACE"
+
+// SetupTracing records OpenTelemetry spans for the steps of Interchain.Build,
+// such as pulling images, starting chains, relayer commands and linking paths,
+// so that slow builds can be profiled in a trace viewer.
+//
+// Spans are written in the OTLP JSON file format to stdout if exporter is "stdout",
+// or to a file if exporter is "file:" followed by its path, e.g. "file:trace.jsonl".
+// If exporter is empty, the value of the IBCTEST_TRACE environment variable is used,
+// and if that is empty too, no spans are recorded.
+//
+// Instead of calling SetupTracing, importers may set their own global TracerProvider with otel.SetTracerProvider.
+//
+// shutdown flushes the recorded spans and must be called before the test binary exits, e.g. at the end of TestMain.
+func SetupTracing(ctx context.Context, exporter string) (shutdown func(context.Context) error, err error) {
+	if exporter == "" {
+		exporter = os.Getenv(TraceExporterEnv)
+	}
+	return tracing.Setup(ctx, exporter)
+}